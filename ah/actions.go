@@ -16,21 +16,112 @@ limitations under the License.
 
 package ah
 
+import "time"
+
+// ActionState is a state of the action
+type ActionState string
+
+// Action states
+const (
+	ActionStatePending  ActionState = "pending"
+	ActionStateRunning  ActionState = "running"
+	ActionStateSuccess  ActionState = "success"
+	ActionStateFailed   ActionState = "failed"
+	ActionStateError    ActionState = "error"
+	ActionStateCanceled ActionState = "canceled"
+)
+
+// Valid reports whether the state is known
+func (s ActionState) Valid() bool {
+	switch s {
+	case ActionStatePending, ActionStateRunning, ActionStateSuccess, ActionStateFailed, ActionStateError, ActionStateCanceled:
+		return true
+	}
+	return false
+}
+
+// IsError reports whether the action has failed
+func (s ActionState) IsError() bool {
+	switch s {
+	case ActionStateFailed, ActionStateError, ActionStateCanceled:
+		return true
+	}
+	return false
+}
+
+// IsTerminal reports whether the action has finished successfully or not
+func (s ActionState) IsTerminal() bool {
+	return s == ActionStateSuccess || s.IsError()
+}
+
+// ActionType is a type of the action
+type ActionType string
+
+// Action types
+const (
+	ActionTypeShutdown     ActionType = "shutdown"
+	ActionTypePowerOff     ActionType = "power_off"
+	ActionTypePowerOn      ActionType = "power_on"
+	ActionTypeReboot       ActionType = "reboot"
+	ActionTypeHardReboot   ActionType = "hard_reboot"
+	ActionTypeReset        ActionType = "reset"
+	ActionTypeRebuild      ActionType = "rebuild"
+	ActionTypeRestore      ActionType = "restore"
+	ActionTypeUpgrade      ActionType = "upgrade"
+	ActionTypeSetPrimaryIP ActionType = "set_primary_ip"
+	ActionTypeAttachVolume ActionType = "attach_volume"
+	ActionTypeDetachVolume ActionType = "detach_volume"
+	ActionTypeBackup       ActionType = "backup"
+	ActionTypeCopy         ActionType = "copy"
+	ActionTypeResize       ActionType = "resize"
+)
+
+// Valid reports whether the action type is known
+func (t ActionType) Valid() bool {
+	switch t {
+	case ActionTypeShutdown, ActionTypePowerOff, ActionTypePowerOn, ActionTypeReboot, ActionTypeHardReboot,
+		ActionTypeReset, ActionTypeRebuild, ActionTypeRestore, ActionTypeUpgrade, ActionTypeSetPrimaryIP,
+		ActionTypeAttachVolume, ActionTypeDetachVolume, ActionTypeBackup, ActionTypeCopy, ActionTypeResize:
+		return true
+	}
+	return false
+}
+
 // Action object
 type Action struct {
-	ID           string `json:"id,omitempty"`
-	State        string `json:"state,omitempty"`
-	ResourceID   string `json:"resource_id,omitempty"`
-	CreatedAt    string `json:"created_at,omitempty"`
-	ResourceType string `json:"resource_type,omitempty"`
-	Type         string `json:"type,omitempty"`
-	UserID       string `json:"user_id,omitempty"`
-	Note         string `json:"note,omitempty"`
-	UpdatedAt    string `json:"updated_at,omitempty"`
-	StartedAt    string `json:"started_at,omitempty"`
-	CompletedAt  string `json:"completed_at,omitempty"`
+	ID           string      `json:"id,omitempty"`
+	State        ActionState `json:"state,omitempty"`
+	ResourceID   string      `json:"resource_id,omitempty"`
+	CreatedAt    Timestamp   `json:"created_at,omitempty"`
+	ResourceType string      `json:"resource_type,omitempty"`
+	Type         ActionType  `json:"type,omitempty"`
+	UserID       string      `json:"user_id,omitempty"`
+	Note         string      `json:"note,omitempty"`
+	UpdatedAt    Timestamp   `json:"updated_at,omitempty"`
+	StartedAt    Timestamp   `json:"started_at,omitempty"`
+	CompletedAt  Timestamp   `json:"completed_at,omitempty"`
 }
 
 type actionRoot struct {
 	Action *Action `json:"action"`
 }
+
+// Duration returns how long the action has been running, or ran if it's completed.
+// It's zero if the action hasn't started yet.
+func (a *Action) Duration() time.Duration {
+	if a.StartedAt.IsZero() {
+		return 0
+	}
+	if a.CompletedAt.IsZero() {
+		return time.Since(a.StartedAt.Time)
+	}
+	return a.CompletedAt.Sub(a.StartedAt.Time)
+}
+
+func (a *Action) isError() bool {
+	return a.State.IsError()
+}
+
+func (a *Action) isTerminal() bool {
+	return a.State.IsTerminal()
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2023 Advanced Hosting
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ah
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestActionState(t *testing.T) {
+	cases := []struct {
+		state    ActionState
+		valid    bool
+		err      bool
+		terminal bool
+	}{
+		{ActionStatePending, true, false, false},
+		{ActionStateRunning, true, false, false},
+		{ActionStateSuccess, true, false, true},
+		{ActionStateFailed, true, true, true},
+		{ActionStateError, true, true, true},
+		{ActionStateCanceled, true, true, true},
+		{"queued", false, false, false},
+	}
+	for _, c := range cases {
+		if c.state.Valid() != c.valid || c.state.IsError() != c.err || c.state.IsTerminal() != c.terminal {
+			t.Errorf("Unexpected helpers of %q state", c.state)
+		}
+	}
+}
+
+func TestAction_UnknownValues(t *testing.T) {
+	data := `{"id":"action_id","state":"queued","type":"migrate"}`
+	var action Action
+	if err := json.Unmarshal([]byte(data), &action); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if action.State != "queued" || action.State.Valid() || action.Type != "migrate" || action.Type.Valid() {
+		t.Errorf("Unexpected action %+v", action)
+	}
+
+	encoded, _ := json.Marshal(&action)
+	var decoded Action
+	if err := json.Unmarshal(encoded, &decoded); err != nil || decoded != action {
+		t.Errorf("Unexpected round trip %s: %v", encoded, err)
+	}
+}
@@ -24,12 +24,20 @@ import (
 	"fmt"
 	"golang.org/x/oauth2"
 	"io"
+	"log/slog"
 	"net/http"
 	"net/url"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 )
 
 var (
-	// ErrResourceNotFound is returned when resource is not found
+	// ErrResourceNotFound is returned when resource is not found.
+	// API errors with 404 status code match it with errors.Is.
 	ErrResourceNotFound = errors.New("resource not found")
 )
 
@@ -38,7 +46,13 @@ const defaultAPIURL = "https://api.websa.com"
 // APIClient implements communication with AH API
 type APIClient struct {
 	client                  *http.Client
+	handler                 RequestHandler
 	apiURL                  *url.URL
+	retryPolicy             *RetryPolicy
+	rateLimiter             *RateLimiter
+	logger                  *slog.Logger
+	logBodies               bool
+	telemetry               *telemetry
 	Instances               InstancesAPI
 	IPAddresses             IPAddressesAPI
 	IPAddressAssignments    IPAddressAssignmentsAPI
@@ -63,11 +77,32 @@ type APIClient struct {
 // ClientOptions represents options to communicate with AH API
 type ClientOptions struct {
 	HTTPClient *http.Client
-	BaseURL    string
-	Token      string
+	// TokenSource provides access tokens for every request instead of Token, e.g. FileTokenSource.
+	// It's ignored if HTTPClient is set.
+	TokenSource oauth2.TokenSource
+	// RetryPolicy enables retries of failed requests. Requests aren't retried if it's nil.
+	RetryPolicy *RetryPolicy
+	// RateLimiter limits the rate of requests. It can be shared between clients using the same token.
+	RateLimiter *RateLimiter
+	// Middlewares wrap sending of every request. The first middleware is the outermost one.
+	Middlewares []Middleware
+	// Logger logs requests and responses at debug level. Secrets are redacted.
+	Logger *slog.Logger
+	// TracerProvider enables tracing of API calls. Spans are named after the service method, e.g. "Instances.Create".
+	TracerProvider trace.TracerProvider
+	// MeterProvider enables recording of API calls count and latency
+	MeterProvider metric.MeterProvider
+	// Propagator injects the trace context into request headers. The global propagator is used if it's nil.
+	Propagator propagation.TextMapPropagator
+	// LogBodies enables logging of request and response bodies
+	LogBodies bool
+	BaseURL   string
+	Token     string
+	// Timeout limits every HTTP request attempt. It's ignored if HTTPClient is set.
+	Timeout time.Duration
 }
 
-func (c *APIClient) newRequest(method string, path string, body interface{}) (*http.Request, error) {
+func (c *APIClient) newRequest(method string, path string, body interface{}, opts ...RequestOption) (*http.Request, error) {
 	var buf io.ReadWriter
 	if body != nil {
 		buf = new(bytes.Buffer)
@@ -89,16 +124,26 @@ func (c *APIClient) newRequest(method string, path string, body interface{}) (*h
 	if body != nil {
 		req.Header.Add("Content-Type", "application/json")
 	}
+	if len(opts) > 0 {
+		options := newRequestOptions(opts)
+		for key, values := range options.header {
+			req.Header[key] = values
+		}
+		req = withRequestOptions(req, options)
+	}
 	return req, nil
 
 }
 
-func (c *APIClient) list(ctx context.Context, path string, options *ListOptions, v interface{}) error {
+func (c *APIClient) list(ctx context.Context, path string, options *ListOptions, v interface{}, opts ...RequestOption) error {
 	if options != nil {
-		params := buildListQuery(options)
-		path = fmt.Sprintf("%s?%s", path, params)
+		separator := "?"
+		if strings.Contains(path, "?") {
+			separator = "&"
+		}
+		path = path + separator + buildListQuery(options)
 	}
-	req, err := c.newRequest(http.MethodGet, path, nil)
+	req, err := c.newRequest(http.MethodGet, path, nil, opts...)
 	if err != nil {
 		return err
 	}
@@ -114,25 +159,43 @@ func (c *APIClient) list(ctx context.Context, path string, options *ListOptions,
 
 // Do sends an API request
 func (c *APIClient) Do(ctx context.Context, req *http.Request, v interface{}) (*http.Response, error) {
+	if c.telemetry == nil {
+		return c.do(ctx, req, v)
+	}
+
+	ctx, finish := c.telemetry.start(ctx, req)
+	resp, err := c.do(ctx, req, v)
+	finish(resp, err)
+	return resp, err
+}
+
+func (c *APIClient) do(ctx context.Context, req *http.Request, v interface{}) (*http.Response, error) {
+	options := requestOptionsFrom(req)
+	if options != nil && options.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, options.timeout)
+		defer cancel()
+	}
 	req = req.WithContext(ctx)
-	resp, err := c.client.Do(req)
+	resp, err := c.handler(req)
 
 	if err != nil {
 		return nil, err
 	}
+	if resp.Body == nil {
+		resp.Body = http.NoBody
+	}
+	if resp.Request == nil {
+		resp.Request = req
+	}
 	defer resp.Body.Close()
 
+	if options != nil && options.response != nil {
+		*options.response = *newResponse(resp)
+	}
+
 	if c := resp.StatusCode; !(c >= 200 && c <= 299) {
-		switch c {
-		case http.StatusNotFound:
-			err = ErrResourceNotFound
-		case http.StatusBadRequest:
-			err = fmt.Errorf("bad Request")
-		default:
-			body, _ := io.ReadAll(resp.Body)
-			err = fmt.Errorf(string(body))
-		}
-		return nil, err
+		return nil, newAPIError(resp)
 	}
 
 	if v != nil {
@@ -146,6 +209,60 @@ func (c *APIClient) Do(ctx context.Context, req *http.Request, v interface{}) (*
 
 }
 
+// send sends the request, retrying it according to the client's retry policy
+func (c *APIClient) send(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	retryable := c.retryPolicy.retryableRequest(req)
+	maxAttempts := c.retryPolicy.maxAttempts()
+
+	for attempt := 1; ; attempt++ {
+		attemptReq := req
+		if attempt > 1 && req.GetBody != nil {
+			attemptReq = req.Clone(ctx)
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			attemptReq.Body = body
+		}
+
+		if c.rateLimiter != nil {
+			if err := c.rateLimiter.Wait(ctx); err != nil {
+				return nil, err
+			}
+		}
+
+		recordAttempt(ctx, attempt)
+		resp, err := c.roundTrip(attemptReq, attempt)
+		if c.rateLimiter != nil && err == nil {
+			c.rateLimiter.Update(resp)
+		}
+		if !retryable || attempt >= maxAttempts {
+			return resp, err
+		}
+
+		delay := c.retryPolicy.backoff(attempt)
+		if err != nil {
+			if !isTransientNetworkError(err) {
+				return nil, err
+			}
+		} else {
+			if !retryableStatus(resp.StatusCode) {
+				return resp, nil
+			}
+			if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+				delay = retryAfter
+			}
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		if err := sleepContext(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
 // NewAPIClient returns APIClient instance
 func NewAPIClient(options *ClientOptions) (*APIClient, error) {
 
@@ -158,21 +275,39 @@ func NewAPIClient(options *ClientOptions) (*APIClient, error) {
 	if err != nil {
 		return nil, err
 	}
-	if options.Token == "" {
+	if options.Token == "" && options.TokenSource == nil {
 		return nil, fmt.Errorf("%s", "invalid token")
 	}
+	telemetry, err := newTelemetry(options.TracerProvider, options.MeterProvider, options.Propagator)
+	if err != nil {
+		return nil, err
+	}
+
 	var httpClient *http.Client
 	if options.HTTPClient != nil {
 		httpClient = options.HTTPClient
 	} else {
-		token := &oauth2.Token{AccessToken: options.Token}
-		httpClient = oauth2.NewClient(context.Background(), oauth2.StaticTokenSource(token))
+		tokenSource := options.TokenSource
+		if tokenSource == nil {
+			tokenSource = oauth2.StaticTokenSource(&oauth2.Token{AccessToken: options.Token})
+		}
+		// Token sources are used as is, without caching of oauth2.NewClient, so reloaded tokens apply immediately
+		httpClient = &http.Client{
+			Transport: &oauth2.Transport{Source: tokenSource},
+			Timeout:   options.Timeout,
+		}
 	}
 
 	c := &APIClient{
-		client: httpClient,
-		apiURL: apiURL,
+		client:      httpClient,
+		apiURL:      apiURL,
+		retryPolicy: options.RetryPolicy,
+		rateLimiter: options.RateLimiter,
+		logger:      options.Logger,
+		logBodies:   options.LogBodies,
+		telemetry:   telemetry,
 	}
+	c.handler = chainMiddlewares(c.send, options.Middlewares)
 	c.Instances = &InstancesService{client: c}
 	c.IPAddresses = &IPAddressesService{client: c}
 	c.IPAddressAssignments = &IPAddressAssignmentsService{client: c}
@@ -190,5 +325,8 @@ func NewAPIClient(options *ClientOptions) (*APIClient, error) {
 	c.VolumePlans = &VolumePlansService{client: c}
 	c.VolumeProducts = &VolumeProductsService{client: c}
 	c.InstanceProducts = &InstanceProductsService{client: c}
+	if rotating, ok := options.TokenSource.(*RotatingTokenSource); ok {
+		rotating.setTokens(c.Tokens)
+	}
 	return c, nil
 }
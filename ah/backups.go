@@ -18,26 +18,56 @@ package ah
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"iter"
 	"net/http"
+	"time"
 )
 
+// bytesPerGB converts sizes of backups in bytes to disk sizes of instances and plans in GB
+const bytesPerGB = 1000 * 1000 * 1000
+
+// ErrDiskTooSmall is returned when the backup doesn't fit the disk of the instance or the plan
+var ErrDiskTooSmall = errors.New("disk is smaller than the minimal disk size of the backup")
+
 // Backup object
 type Backup struct {
-	ID                         string `json:"id,omitempty"`
-	CreatedAt                  string `json:"created_at,omitempty"`
-	UpdatedAt                  string `json:"updated_at,omitempty"`
-	Name                       string `json:"name,omitempty"`
-	Status                     string `json:"status,omitempty"`
-	Type                       string `json:"type,omitempty"`
-	Note                       string `json:"note,omitempty"`
-	InstanceID                 string `json:"instance_id,omitempty"`
-	InstanceName               string `json:"instance_name,omitempty"`
-	Size                       int    `json:"size,omitempty"`
-	MinDiskSize                int    `json:"min_disk_size,omitempty"`
-	InstanceRemoved            bool   `json:"instance_removed,omitempty"`
-	InstanceSnapshotBySchedule bool   `json:"instance_snapshot_by_schedule,omitempty"`
-	Public                     bool   `json:"public,omitempty"`
+	ID                         string    `json:"id,omitempty"`
+	CreatedAt                  Timestamp `json:"created_at,omitempty"`
+	UpdatedAt                  Timestamp `json:"updated_at,omitempty"`
+	Name                       string    `json:"name,omitempty"`
+	Status                     string    `json:"status,omitempty"`
+	Type                       string    `json:"type,omitempty"`
+	Note                       string    `json:"note,omitempty"`
+	InstanceID                 string    `json:"instance_id,omitempty"`
+	InstanceName               string    `json:"instance_name,omitempty"`
+	Size                       int       `json:"size,omitempty"`
+	MinDiskSize                int       `json:"min_disk_size,omitempty"`
+	InstanceRemoved            bool      `json:"instance_removed,omitempty"`
+	InstanceSnapshotBySchedule bool      `json:"instance_snapshot_by_schedule,omitempty"`
+	Public                     bool      `json:"public,omitempty"`
+}
+
+// Age returns the time passed since the backup was created
+func (b *Backup) Age() time.Duration {
+	if b.CreatedAt.IsZero() {
+		return 0
+	}
+	return time.Since(b.CreatedAt.Time)
+}
+
+// MinDiskSizeGB returns the minimal disk size in GB required to restore the backup
+func (b *Backup) MinDiskSizeGB() int {
+	return (b.MinDiskSize + bytesPerGB - 1) / bytesPerGB
+}
+
+// checkDisk returns ErrDiskTooSmall if the backup doesn't fit the disk of the size in GB
+func (b *Backup) checkDisk(diskSize int) error {
+	if minDiskSize := b.MinDiskSizeGB(); diskSize < minDiskSize {
+		return fmt.Errorf("%w: backup %s requires %d GB, disk has %d GB", ErrDiskTooSmall, b.ID, minDiskSize, diskSize)
+	}
+	return nil
 }
 
 // InstanceBackups object
@@ -51,10 +81,12 @@ type InstanceBackups struct {
 
 // BackupsAPI is an interface for backups.
 type BackupsAPI interface {
-	List(context.Context, *ListOptions) ([]InstanceBackups, error)
-	Get(context.Context, string) (*Backup, error)
-	Update(context.Context, string, *BackUpUpdateRequest) (*Backup, error)
-	Delete(context.Context, string) (*Action, error)
+	List(context.Context, *ListOptions, ...RequestOption) ([]InstanceBackups, error)
+	ListPage(context.Context, *ListOptions, ...RequestOption) ([]BackupWithEmbeddedInstance, *Meta, error)
+	All(context.Context, *ListOptions, ...RequestOption) iter.Seq2[BackupWithEmbeddedInstance, error]
+	Get(context.Context, string, ...RequestOption) (*Backup, error)
+	Update(context.Context, string, *BackUpUpdateRequest, ...RequestOption) (*Backup, error)
+	Delete(context.Context, string, ...RequestOption) (*Action, error)
 }
 
 // BackupsService implements BackupsAPI interface.
@@ -63,6 +95,7 @@ type BackupsService struct {
 }
 
 type BackupListRoot struct {
+	Meta    *Meta                        `json:"meta"`
 	Backups []BackupWithEmbeddedInstance `json:"backups"`
 }
 
@@ -78,20 +111,20 @@ type BackupWithEmbeddedInstance struct {
 	Backup
 }
 
-// List returns all available private networks
-func (bs *BackupsService) List(ctx context.Context, options *ListOptions) ([]InstanceBackups, error) {
-	path := "api/v1/backups"
-
-	var bRoot BackupListRoot
-
-	if err := bs.client.list(ctx, path, options, &bRoot); err != nil {
+// List returns backups grouped by instance
+//
+// Deprecated: Please use ListPage instead.
+func (bs *BackupsService) List(ctx context.Context, options *ListOptions, opts ...RequestOption) ([]InstanceBackups, error) {
+	ctx = withOperation(ctx, "Backups.List")
+	backups, _, err := bs.listPage(ctx, options, opts...)
+	if err != nil {
 		return nil, err
 	}
 
 	// Group backups by instance ID
 	grouped := map[string]*InstanceBackups{}
 
-	for _, b := range bRoot.Backups {
+	for _, b := range backups {
 		instID := b.InstanceID
 
 		if _, exists := grouped[instID]; !exists {
@@ -117,14 +150,38 @@ func (bs *BackupsService) List(ctx context.Context, options *ListOptions) ([]Ins
 	return result, nil
 }
 
+// ListPage returns a page of backups with pagination metadata
+func (bs *BackupsService) ListPage(ctx context.Context, options *ListOptions, opts ...RequestOption) ([]BackupWithEmbeddedInstance, *Meta, error) {
+	return bs.listPage(withOperation(ctx, "Backups.ListPage"), options, opts...)
+}
+
+// listPage is ListPage without the operation name, so that wrappers keep their own
+func (bs *BackupsService) listPage(ctx context.Context, options *ListOptions, opts ...RequestOption) ([]BackupWithEmbeddedInstance, *Meta, error) {
+	path := "api/v1/backups"
+
+	var bRoot BackupListRoot
+
+	if err := bs.client.list(ctx, path, options, &bRoot, opts...); err != nil {
+		return nil, nil, err
+	}
+
+	return bRoot.Backups, bRoot.Meta, nil
+}
+
+// All returns an iterator over all backups fetching pages lazily
+func (bs *BackupsService) All(ctx context.Context, options *ListOptions, opts ...RequestOption) iter.Seq2[BackupWithEmbeddedInstance, error] {
+	return paginate(withOperation(ctx, "Backups.All"), options, bs.listPage, opts...)
+}
+
 type backupRoot struct {
 	Backup *Backup `json:"backup"`
 }
 
 // Get backup info
-func (bs *BackupsService) Get(ctx context.Context, backupID string) (*Backup, error) {
+func (bs *BackupsService) Get(ctx context.Context, backupID string, opts ...RequestOption) (*Backup, error) {
+	ctx = withOperation(ctx, "Backups.Get")
 	path := fmt.Sprintf("api/v1/backups/%s", backupID)
-	req, err := bs.client.newRequest(http.MethodGet, path, nil)
+	req, err := bs.client.newRequest(http.MethodGet, path, nil, opts...)
 
 	if err != nil {
 		return nil, err
@@ -145,9 +202,10 @@ type BackUpUpdateRequest struct {
 }
 
 // Update backup
-func (bs *BackupsService) Update(ctx context.Context, backupID string, request *BackUpUpdateRequest) (*Backup, error) {
+func (bs *BackupsService) Update(ctx context.Context, backupID string, request *BackUpUpdateRequest, opts ...RequestOption) (*Backup, error) {
+	ctx = withOperation(ctx, "Backups.Update")
 	path := fmt.Sprintf("api/v1/backups/%s", backupID)
-	req, err := bs.client.newRequest(http.MethodPut, path, request)
+	req, err := bs.client.newRequest(http.MethodPut, path, request, opts...)
 
 	if err != nil {
 		return nil, err
@@ -162,9 +220,10 @@ func (bs *BackupsService) Update(ctx context.Context, backupID string, request *
 }
 
 // Delete backup
-func (bs *BackupsService) Delete(ctx context.Context, backupID string) (*Action, error) {
+func (bs *BackupsService) Delete(ctx context.Context, backupID string, opts ...RequestOption) (*Action, error) {
+	ctx = withOperation(ctx, "Backups.Delete")
 	path := fmt.Sprintf("api/v1/backups/%s", backupID)
-	req, err := bs.client.newRequest(http.MethodDelete, path, nil)
+	req, err := bs.client.newRequest(http.MethodDelete, path, nil, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -19,6 +19,7 @@ package ah
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"reflect"
 	"testing"
@@ -94,8 +95,8 @@ func TestBackups_List(t *testing.T) {
 				{
 					ID:          "437696c6-6b56-466d-92b2-6f5231124fbb",
 					InstanceID:  "61463ad8-f5a2-493a-80a0-7b0059ccaafb",
-					CreatedAt:   "2020-07-03T08:33:27.127Z",
-					UpdatedAt:   "2020-07-03T08:34:29.148Z",
+					CreatedAt:   mustParseTimestamp("2020-07-03T08:33:27.127Z"),
+					UpdatedAt:   mustParseTimestamp("2020-07-03T08:34:29.148Z"),
 					Name:        "WVDS113828_2020-07-03T083327",
 					Size:        1759379456,
 					Public:      false,
@@ -198,3 +199,16 @@ func TestBackups_Delete(t *testing.T) {
 		t.Errorf("unexpected result, expected %v. got: %v", expectedResult, action)
 	}
 }
+
+func TestBackup_MinDiskSizeGB(t *testing.T) {
+	cases := map[int]int{0: 0, 40000000000: 40, 40000000001: 41, 1759379456: 2}
+	for minDiskSize, expected := range cases {
+		backup := &Backup{MinDiskSize: minDiskSize}
+		if backup.MinDiskSizeGB() != expected {
+			t.Errorf("Unexpected size %d of %d bytes", backup.MinDiskSizeGB(), minDiskSize)
+		}
+	}
+	if err := (&Backup{MinDiskSize: 40000000000}).checkDisk(30); !errors.Is(err, ErrDiskTooSmall) {
+		t.Errorf("Unexpected error %v", err)
+	}
+}
@@ -17,11 +17,16 @@ limitations under the License.
 package ah
 
 import (
-	"strings"
+	"net/url"
 
 	"github.com/google/go-querystring/query"
 )
 
+// Ptr returns a pointer to the value, e.g. for optional fields of update requests
+func Ptr[T any](v T) *T {
+	return &v
+}
+
 // ListOptions represents options to get list of resources.
 type ListOptions struct {
 	Meta     *ListMetaOptions
@@ -31,25 +36,22 @@ type ListOptions struct {
 
 // ListMetaOptions represents meta options.
 type ListMetaOptions struct {
-	Page int `url:"page,omitempty"`
+	Page    int `url:"page,omitempty"`
+	PerPage int `url:"per_page,omitempty"`
 }
 
 func buildListQuery(options *ListOptions) string {
-
-	var queryString []string
+	values := url.Values{}
 
 	if options.Meta != nil {
 		metaParams, _ := query.Values(options.Meta)
-		queryString = append(queryString, metaParams.Encode())
+		for key, metaValues := range metaParams {
+			values[key] = metaValues
+		}
 	}
 
-	if len(options.Filters) > 0 {
-		queryString = append(queryString, BuildFilterQuery(options.Filters))
-	}
-
-	if len(options.Sortings) > 0 {
-		queryString = append(queryString, BuildSortingQuery(options.Sortings))
-	}
+	addFilters(values, options.Filters)
+	addSortings(values, options.Sortings)
 
-	return strings.Join(queryString, "&")
+	return values.Encode()
 }
@@ -38,7 +38,7 @@ func TestListOptions_Basic(t *testing.T) {
 			},
 		},
 	}
-	expectedResult := "page=1&q[test_in][]=1&q[test_in][]=3&q[s]=test2+asc"
+	expectedResult := "page=1&q%5Bs%5D=test2+asc&q%5Btest_in%5D%5B%5D=1&q%5Btest_in%5D%5B%5D=3"
 	result := buildListQuery(options)
 
 	if result != expectedResult {
@@ -80,7 +80,7 @@ func TestListOptions_OnlyFilters(t *testing.T) {
 			},
 		},
 	}
-	expectedResult := "q[test_in][]=1&q[test_in][]=3"
+	expectedResult := "q%5Btest_in%5D%5B%5D=1&q%5Btest_in%5D%5B%5D=3"
 	result := buildListQuery(options)
 
 	if result != expectedResult {
@@ -97,7 +97,7 @@ func TestListOptions_OnlySorting(t *testing.T) {
 			},
 		},
 	}
-	expectedResult := "q[s]=test2+asc"
+	expectedResult := "q%5Bs%5D=test2+asc"
 	result := buildListQuery(options)
 
 	if result != expectedResult {
@@ -0,0 +1,179 @@
+/*
+Copyright 2023 Advanced Hosting
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ah
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Environment variables used by NewAPIClientFromEnv
+const (
+	// EnvToken overrides the access token of the profile
+	EnvToken = "AH_TOKEN"
+	// EnvAPIURL overrides the base URL of the profile
+	EnvAPIURL = "AH_API_URL"
+	// EnvProfile selects the profile of the config file
+	EnvProfile = "AH_PROFILE"
+	// EnvConfigFile overrides the path of the config file
+	EnvConfigFile = "AH_CONFIG"
+)
+
+const defaultProfileName = "default"
+
+var (
+	// ErrProfileNotFound is returned when the requested profile isn't defined in the config file
+	ErrProfileNotFound = errors.New("profile not found")
+)
+
+// Config represents the config file with named profiles:
+//
+//	default_profile: production
+//	profiles:
+//	  production:
+//	    token: ACCESS_TOKEN
+//	    timeout: 30s
+//	    retry:
+//	      max_attempts: 5
+//	      base_backoff: 1s
+//	  staging:
+//	    base_url: https://staging.example.com
+//	    token: STAGING_ACCESS_TOKEN
+type Config struct {
+	Profiles       map[string]*Profile `yaml:"profiles"`
+	DefaultProfile string              `yaml:"default_profile"`
+}
+
+// Profile represents client settings of the config file
+type Profile struct {
+	// Retry enables retries of failed requests
+	Retry   *RetryPolicy `yaml:"retry"`
+	BaseURL string       `yaml:"base_url"`
+	Token   string       `yaml:"token"`
+	// Timeout limits every HTTP request attempt
+	Timeout time.Duration `yaml:"timeout"`
+}
+
+// DefaultConfigPath returns the path of the config file: AH_CONFIG if it's set,
+// otherwise advancedhosting/config.yaml in $XDG_CONFIG_HOME or ~/.config
+func DefaultConfigPath() (string, error) {
+	if path := os.Getenv(EnvConfigFile); path != "" {
+		return path, nil
+	}
+	configDir := os.Getenv("XDG_CONFIG_HOME")
+	if configDir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		configDir = filepath.Join(home, ".config")
+	}
+	return filepath.Join(configDir, "advancedhosting", "config.yaml"), nil
+}
+
+// LoadConfig reads the config file. The default path is used if path is empty,
+// in this case a missing file results in an empty config.
+func LoadConfig(path string) (*Config, error) {
+	optional := path == ""
+	if optional {
+		var err error
+		if path, err = DefaultConfigPath(); err != nil {
+			return nil, err
+		}
+	}
+
+	config := &Config{}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if optional && errors.Is(err, os.ErrNotExist) {
+			return config, nil
+		}
+		return nil, err
+	}
+	if err := yaml.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("parsing config %s: %w", path, err)
+	}
+	return config, nil
+}
+
+// Profile returns the named profile. If name is empty, AH_PROFILE, the config's
+// default profile or "default" is used, and a missing profile results in an empty one.
+func (c *Config) Profile(name string) (*Profile, error) {
+	optional := name == ""
+	if optional {
+		name = os.Getenv(EnvProfile)
+		optional = name == ""
+	}
+	if name == "" {
+		name = c.DefaultProfile
+	}
+	if name == "" {
+		name = defaultProfileName
+	}
+
+	if profile, ok := c.Profiles[name]; ok && profile != nil {
+		return profile, nil
+	}
+	if optional && c.DefaultProfile == "" {
+		return &Profile{}, nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrProfileNotFound, name)
+}
+
+// ClientOptions returns options of the named profile overridden with AH_TOKEN and AH_API_URL
+func (c *Config) ClientOptions(profileName string) (*ClientOptions, error) {
+	profile, err := c.Profile(profileName)
+	if err != nil {
+		return nil, err
+	}
+
+	options := &ClientOptions{
+		BaseURL: profile.BaseURL,
+		Token:   profile.Token,
+		Timeout: profile.Timeout,
+	}
+	if profile.Retry != nil {
+		retryPolicy := *profile.Retry
+		options.RetryPolicy = &retryPolicy
+	}
+	if token := os.Getenv(EnvToken); token != "" {
+		options.Token = token
+	}
+	if apiURL := os.Getenv(EnvAPIURL); apiURL != "" {
+		options.BaseURL = apiURL
+	}
+	return options, nil
+}
+
+// NewAPIClientFromEnv returns APIClient configured with the profile of the default config file
+// selected by AH_PROFILE and overridden with AH_TOKEN and AH_API_URL environment variables
+func NewAPIClientFromEnv() (*APIClient, error) {
+	config, err := LoadConfig("")
+	if err != nil {
+		return nil, err
+	}
+	options, err := config.ClientOptions("")
+	if err != nil {
+		return nil, err
+	}
+	return NewAPIClient(options)
+}
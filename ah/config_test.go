@@ -0,0 +1,152 @@
+/*
+Copyright 2023 Advanced Hosting
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ah
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+const configYAML = `
+default_profile: production
+profiles:
+  production:
+    token: production-token
+    timeout: 30s
+    retry:
+      max_attempts: 5
+      base_backoff: 1s
+  staging:
+    base_url: https://staging.example.com
+    token: staging-token
+`
+
+func writeConfig(t *testing.T, data string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func unsetConfigEnv(t *testing.T) {
+	t.Helper()
+	for _, key := range []string{EnvToken, EnvAPIURL, EnvProfile, EnvConfigFile} {
+		t.Setenv(key, "")
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	unsetConfigEnv(t)
+	config, err := LoadConfig(writeConfig(t, configYAML))
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	options, err := config.ClientOptions("")
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if options.Token != "production-token" {
+		t.Errorf("Expected production token, got %q", options.Token)
+	}
+	if options.Timeout != 30*time.Second {
+		t.Errorf("Expected 30s timeout, got %v", options.Timeout)
+	}
+	expectedRetry := &RetryPolicy{MaxAttempts: 5, BaseBackoff: time.Second}
+	if options.RetryPolicy == nil || *options.RetryPolicy != *expectedRetry {
+		t.Errorf("Expected retry policy %+v, got %+v", expectedRetry, options.RetryPolicy)
+	}
+
+	options, err = config.ClientOptions("staging")
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if options.BaseURL != "https://staging.example.com" || options.Token != "staging-token" {
+		t.Errorf("Unexpected staging options %+v", options)
+	}
+	if options.RetryPolicy != nil {
+		t.Errorf("Expected no retry policy, got %+v", options.RetryPolicy)
+	}
+
+	if _, err := config.ClientOptions("missing"); !errors.Is(err, ErrProfileNotFound) {
+		t.Errorf("Expected ErrProfileNotFound, got %v", err)
+	}
+}
+
+func TestConfig_EnvOverrides(t *testing.T) {
+	unsetConfigEnv(t)
+	t.Setenv(EnvConfigFile, writeConfig(t, configYAML))
+	t.Setenv(EnvProfile, "staging")
+	t.Setenv(EnvToken, "env-token")
+
+	config, err := LoadConfig("")
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	options, err := config.ClientOptions("")
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if options.Token != "env-token" {
+		t.Errorf("Expected env token, got %q", options.Token)
+	}
+	if options.BaseURL != "https://staging.example.com" {
+		t.Errorf("Expected staging base url, got %q", options.BaseURL)
+	}
+
+	t.Setenv(EnvAPIURL, "https://env.example.com")
+	api, err := NewAPIClientFromEnv()
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if api.apiURL.String() != "https://env.example.com" {
+		t.Errorf("Expected env base url, got %s", api.apiURL)
+	}
+}
+
+func TestNewAPIClientFromEnv_WithoutConfigFile(t *testing.T) {
+	unsetConfigEnv(t)
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv(EnvToken, "env-token")
+
+	api, err := NewAPIClientFromEnv()
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if api.apiURL.String() != defaultAPIURL {
+		t.Errorf("Expected default base url, got %s", api.apiURL)
+	}
+
+	t.Setenv(EnvToken, "")
+	if _, err := NewAPIClientFromEnv(); err == nil {
+		t.Errorf("Expected error without token")
+	}
+}
+
+func TestLoadConfig_Invalid(t *testing.T) {
+	if _, err := LoadConfig(writeConfig(t, "profiles: [")); err == nil {
+		t.Errorf("Expected parse error")
+	}
+	if _, err := LoadConfig(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Errorf("Expected error for missing explicit config file")
+	}
+}
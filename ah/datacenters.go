@@ -19,6 +19,7 @@ package ah
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 )
 
@@ -42,8 +43,10 @@ type Datacenter struct {
 
 // DatacentersAPI is an interface for datacenters.
 type DatacentersAPI interface {
-	List(context.Context, *ListOptions) ([]Datacenter, error)
-	Get(context.Context, string) (*Datacenter, error)
+	List(context.Context, *ListOptions, ...RequestOption) ([]Datacenter, error)
+	ListPage(context.Context, *ListOptions, ...RequestOption) ([]Datacenter, *Meta, error)
+	All(context.Context, *ListOptions, ...RequestOption) iter.Seq2[Datacenter, error]
+	Get(context.Context, string, ...RequestOption) (*Datacenter, error)
 }
 
 // DatacentersService implements DatacentersAPI interface.
@@ -52,20 +55,39 @@ type DatacentersService struct {
 }
 
 type datacentersRoot struct {
+	Meta        *Meta        `json:"meta"`
 	Datacenters []Datacenter `json:"datacenters"`
 }
 
 // List returns all available datacenters
-func (ds *DatacentersService) List(ctx context.Context, options *ListOptions) ([]Datacenter, error) {
+//
+// Deprecated: Please use ListPage instead.
+func (ds *DatacentersService) List(ctx context.Context, options *ListOptions, opts ...RequestOption) ([]Datacenter, error) {
+	ctx = withOperation(ctx, "Datacenters.List")
+	datacenters, _, err := ds.listPage(ctx, options, opts...)
+	return datacenters, err
+}
+
+// ListPage returns a page of datacenters with pagination metadata
+func (ds *DatacentersService) ListPage(ctx context.Context, options *ListOptions, opts ...RequestOption) ([]Datacenter, *Meta, error) {
+	return ds.listPage(withOperation(ctx, "Datacenters.ListPage"), options, opts...)
+}
 
+// listPage is ListPage without the operation name, so that wrappers keep their own
+func (ds *DatacentersService) listPage(ctx context.Context, options *ListOptions, opts ...RequestOption) ([]Datacenter, *Meta, error) {
 	path := "api/v1/datacenters"
 
 	var dRoot datacentersRoot
 
-	if err := ds.client.list(ctx, path, options, &dRoot); err != nil {
-		return nil, err
+	if err := ds.client.list(ctx, path, options, &dRoot, opts...); err != nil {
+		return nil, nil, err
 	}
-	return dRoot.Datacenters, nil
+	return dRoot.Datacenters, dRoot.Meta, nil
+}
+
+// All returns an iterator over all datacenters fetching pages lazily
+func (ds *DatacentersService) All(ctx context.Context, options *ListOptions, opts ...RequestOption) iter.Seq2[Datacenter, error] {
+	return paginate(withOperation(ctx, "Datacenters.All"), options, ds.listPage, opts...)
 }
 
 type datacenterRoot struct {
@@ -73,10 +95,11 @@ type datacenterRoot struct {
 }
 
 // Get datacenter info by ID
-func (ds *DatacentersService) Get(ctx context.Context, datacenterID string) (*Datacenter, error) {
+func (ds *DatacentersService) Get(ctx context.Context, datacenterID string, opts ...RequestOption) (*Datacenter, error) {
+	ctx = withOperation(ctx, "Datacenters.Get")
 
 	path := fmt.Sprintf("api/v1/datacenters/%s", datacenterID)
-	req, err := ds.client.newRequest(http.MethodGet, path, nil)
+	req, err := ds.client.newRequest(http.MethodGet, path, nil, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,172 @@
+/*
+Copyright 2023 Advanced Hosting
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ah
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// requestIDHeader is the response header carrying the server request ID
+const requestIDHeader = "X-Request-Id"
+
+// APIError is returned when AH API responds with a non-2xx status code
+type APIError struct {
+	// Errors contains per-field validation errors, if any
+	Errors     map[string][]string
+	Method     string
+	URL        string
+	Message    string
+	RequestID  string
+	Body       []byte
+	StatusCode int
+}
+
+// Error returns a human readable error description
+func (e *APIError) Error() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s %s: %d", e.Method, e.URL, e.StatusCode)
+	if text := http.StatusText(e.StatusCode); text != "" {
+		fmt.Fprintf(&sb, " %s", text)
+	}
+	if e.Message != "" {
+		fmt.Fprintf(&sb, ": %s", e.Message)
+	}
+	if len(e.Errors) > 0 {
+		fields := make([]string, 0, len(e.Errors))
+		for field := range e.Errors {
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+		var details []string
+		for _, field := range fields {
+			details = append(details, fmt.Sprintf("%s %s", field, strings.Join(e.Errors[field], ", ")))
+		}
+		fmt.Fprintf(&sb, " (%s)", strings.Join(details, "; "))
+	}
+	if e.RequestID != "" {
+		fmt.Fprintf(&sb, " [request id: %s]", e.RequestID)
+	}
+	return sb.String()
+}
+
+// Is reports whether the error matches target. A 404 response matches ErrResourceNotFound.
+func (e *APIError) Is(target error) bool {
+	return target == ErrResourceNotFound && e.StatusCode == http.StatusNotFound
+}
+
+// IsValidationError returns true if the API rejected the request parameters
+func (e *APIError) IsValidationError() bool {
+	return e.StatusCode == http.StatusUnprocessableEntity || (e.StatusCode == http.StatusBadRequest && len(e.Errors) > 0)
+}
+
+type apiErrorBody struct {
+	Error   json.RawMessage `json:"error"`
+	Errors  json.RawMessage `json:"errors"`
+	Message string          `json:"message"`
+}
+
+func newAPIError(resp *http.Response) *APIError {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		RequestID:  resp.Header.Get(requestIDHeader),
+	}
+	if resp.Request != nil {
+		apiErr.Method = resp.Request.Method
+		apiErr.URL = resp.Request.URL.String()
+	}
+
+	body, _ := io.ReadAll(resp.Body)
+	apiErr.Body = body
+	apiErr.parseBody(body)
+	return apiErr
+}
+
+func (e *APIError) parseBody(body []byte) {
+	var errBody apiErrorBody
+	if err := json.Unmarshal(body, &errBody); err != nil {
+		e.Message = strings.TrimSpace(string(body))
+		return
+	}
+
+	e.Message = errBody.Message
+	if message, fieldErrors := parseErrorValue(errBody.Error); message != "" || fieldErrors != nil {
+		if e.Message == "" {
+			e.Message = message
+		}
+		e.Errors = fieldErrors
+	}
+	if message, fieldErrors := parseErrorValue(errBody.Errors); message != "" || fieldErrors != nil {
+		if e.Message == "" {
+			e.Message = message
+		}
+		if fieldErrors != nil {
+			e.Errors = fieldErrors
+		}
+	}
+}
+
+// parseErrorValue parses the "error"/"errors" value which can be a string, a list of strings,
+// an object with a message or an object with per-field messages
+func parseErrorValue(raw json.RawMessage) (string, map[string][]string) {
+	if len(raw) == 0 {
+		return "", nil
+	}
+
+	var message string
+	if err := json.Unmarshal(raw, &message); err == nil {
+		return message, nil
+	}
+
+	var messages []string
+	if err := json.Unmarshal(raw, &messages); err == nil {
+		return strings.Join(messages, ", "), nil
+	}
+
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &object); err != nil {
+		return "", nil
+	}
+
+	var fields map[string][]string
+	for key, value := range object {
+		var text string
+		if err := json.Unmarshal(value, &text); err == nil {
+			if key == "message" {
+				message = text
+				continue
+			}
+			if fields == nil {
+				fields = map[string][]string{}
+			}
+			fields[key] = append(fields[key], text)
+			continue
+		}
+		var texts []string
+		if err := json.Unmarshal(value, &texts); err == nil {
+			if fields == nil {
+				fields = map[string][]string{}
+			}
+			fields[key] = append(fields[key], texts...)
+		}
+	}
+	return message, fields
+}
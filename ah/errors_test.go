@@ -0,0 +1,109 @@
+/*
+Copyright 2023 Advanced Hosting
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ah
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestAPIError_NotFound(t *testing.T) {
+	fakeResponse := &fakeServerResponse{responseBody: `{"error": "Instance not found"}`, statusCode: 404}
+	api, _ := newFakeAPIClient("/api/v1/instances/not_existed_id", fakeResponse)
+
+	ctx := context.Background()
+	_, err := api.Instances.Get(ctx, "not_existed_id")
+
+	if !errors.Is(err, ErrResourceNotFound) {
+		t.Errorf("Unexpected error %v", err)
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Expected APIError, got %T", err)
+	}
+
+	if apiErr.StatusCode != http.StatusNotFound {
+		t.Errorf("Unexpected status code %d", apiErr.StatusCode)
+	}
+
+	if apiErr.Method != http.MethodGet {
+		t.Errorf("Unexpected method %s", apiErr.Method)
+	}
+
+	if apiErr.Message != "Instance not found" {
+		t.Errorf("Unexpected message %s", apiErr.Message)
+	}
+}
+
+func TestAPIError_ValidationErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Header().Set("X-Request-Id", "test-request-id")
+		rw.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = rw.Write([]byte(`{"errors": {"name": ["can't be blank"], "plan_id": ["is invalid"]}}`))
+	}))
+	api, _ := NewAPIClient(newFakeClientOptions(server))
+
+	ctx := context.Background()
+	_, err := api.Instances.Create(ctx, &InstanceCreateRequest{})
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Expected APIError, got %T", err)
+	}
+
+	if errors.Is(err, ErrResourceNotFound) {
+		t.Errorf("Validation error shouldn't match ErrResourceNotFound")
+	}
+
+	if !apiErr.IsValidationError() {
+		t.Errorf("Expected validation error")
+	}
+
+	expectedErrors := map[string][]string{
+		"name":    {"can't be blank"},
+		"plan_id": {"is invalid"},
+	}
+	if !reflect.DeepEqual(expectedErrors, apiErr.Errors) {
+		t.Errorf("unexpected errors, expected %v. got: %v", expectedErrors, apiErr.Errors)
+	}
+
+	if apiErr.RequestID != "test-request-id" {
+		t.Errorf("Unexpected request id %s", apiErr.RequestID)
+	}
+}
+
+func TestAPIError_PlainTextBody(t *testing.T) {
+	fakeResponse := &fakeServerResponse{responseBody: "Quota exceeded", statusCode: 403}
+	api, _ := newFakeAPIClient("/api/v1/volumes/test", fakeResponse)
+
+	ctx := context.Background()
+	err := api.Volumes.Delete(ctx, "test")
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Expected APIError, got %T", err)
+	}
+
+	if apiErr.StatusCode != http.StatusForbidden || apiErr.Message != "Quota exceeded" {
+		t.Errorf("Unexpected error %v", apiErr)
+	}
+}
@@ -18,24 +18,26 @@ package ah
 
 import (
 	"context"
+	"iter"
 )
 
 // Image object
 type Image struct {
-	ID           string `json:"id,omitempty"`
-	CreatedAt    string `json:"created_at,omitempty"`
-	UpdatedAt    string `json:"updated_at,omitempty"`
-	Name         string `json:"name,omitempty"`
-	Distribution string `json:"distribution,omitempty"`
-	Version      string `json:"version,omitempty"`
-	Architecture string `json:"architecture,omitempty"`
-	Slug         string `json:"slug,omitempty"`
-	Public       bool   `json:"public,omitempty"`
+	ID           string    `json:"id,omitempty"`
+	CreatedAt    Timestamp `json:"created_at,omitempty"`
+	UpdatedAt    Timestamp `json:"updated_at,omitempty"`
+	Name         string    `json:"name,omitempty"`
+	Distribution string    `json:"distribution,omitempty"`
+	Version      string    `json:"version,omitempty"`
+	Architecture string    `json:"architecture,omitempty"`
+	Slug         string    `json:"slug,omitempty"`
+	Public       bool      `json:"public,omitempty"`
 }
 
 // ImagesAPI is an interface for images.
 type ImagesAPI interface {
-	List(context.Context, *ListOptions) ([]Image, *Meta, error)
+	List(context.Context, *ListOptions, ...RequestOption) ([]Image, *Meta, error)
+	All(context.Context, *ListOptions, ...RequestOption) iter.Seq2[Image, error]
 }
 
 // ImagesService implements ImagesAPI interface.
@@ -49,14 +51,23 @@ type imagesRoot struct {
 }
 
 // List returns all available images
-func (is *ImagesService) List(ctx context.Context, options *ListOptions) ([]Image, *Meta, error) {
+func (is *ImagesService) List(ctx context.Context, options *ListOptions, opts ...RequestOption) ([]Image, *Meta, error) {
+	return is.list(withOperation(ctx, "Images.List"), options, opts...)
+}
 
+// list is List without the operation name, so that wrappers keep their own
+func (is *ImagesService) list(ctx context.Context, options *ListOptions, opts ...RequestOption) ([]Image, *Meta, error) {
 	path := "api/v1/images"
 
 	var iRoot imagesRoot
 
-	if err := is.client.list(ctx, path, options, &iRoot); err != nil {
+	if err := is.client.list(ctx, path, options, &iRoot, opts...); err != nil {
 		return nil, nil, err
 	}
 	return iRoot.Images, iRoot.Meta, nil
 }
+
+// All returns an iterator over all images fetching pages lazily
+func (is *ImagesService) All(ctx context.Context, options *ListOptions, opts ...RequestOption) iter.Seq2[Image, error] {
+	return paginate(withOperation(ctx, "Images.All"), options, is.list, opts...)
+}
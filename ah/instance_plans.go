@@ -18,6 +18,7 @@ package ah
 
 import (
 	"context"
+	"iter"
 )
 
 type InstancePlanAttributes struct {
@@ -42,7 +43,9 @@ type InstancePlan struct {
 
 // InstancePlansAPI is an interface for instance plans.
 type InstancePlansAPI interface {
-	List(context.Context) ([]InstancePlan, error)
+	List(context.Context, ...RequestOption) ([]InstancePlan, error)
+	ListPage(context.Context, *ListOptions, ...RequestOption) ([]InstancePlan, *Meta, error)
+	All(context.Context, *ListOptions, ...RequestOption) iter.Seq2[InstancePlan, error]
 }
 
 // InstancePlansService implements InstancePlansAPI interface.
@@ -51,18 +54,37 @@ type InstancePlansService struct {
 }
 
 type instancePlansRoot struct {
+	Meta  *Meta          `json:"meta"`
 	Plans []InstancePlan `json:"data"`
 }
 
 // List returns all available instance plans
-func (ips *InstancePlansService) List(ctx context.Context) ([]InstancePlan, error) {
+//
+// Deprecated: Please use ListPage instead.
+func (ips *InstancePlansService) List(ctx context.Context, opts ...RequestOption) ([]InstancePlan, error) {
+	ctx = withOperation(ctx, "InstancePlans.List")
+	plans, _, err := ips.listPage(ctx, nil, opts...)
+	return plans, err
+}
+
+// ListPage returns a page of instance plans with pagination metadata
+func (ips *InstancePlansService) ListPage(ctx context.Context, options *ListOptions, opts ...RequestOption) ([]InstancePlan, *Meta, error) {
+	return ips.listPage(withOperation(ctx, "InstancePlans.ListPage"), options, opts...)
+}
 
+// listPage is ListPage without the operation name, so that wrappers keep their own
+func (ips *InstancePlansService) listPage(ctx context.Context, options *ListOptions, opts ...RequestOption) ([]InstancePlan, *Meta, error) {
 	path := "api/v1/plans/public?type=vps"
 
-	var ipRoot instancePlansRoot
+	var pRoot instancePlansRoot
 
-	if err := ips.client.list(ctx, path, nil, &ipRoot); err != nil {
-		return nil, err
+	if err := ips.client.list(ctx, path, options, &pRoot, opts...); err != nil {
+		return nil, nil, err
 	}
-	return ipRoot.Plans, nil
+	return pRoot.Plans, pRoot.Meta, nil
+}
+
+// All returns an iterator over all instance plans fetching pages lazily
+func (ips *InstancePlansService) All(ctx context.Context, options *ListOptions, opts ...RequestOption) iter.Seq2[InstancePlan, error] {
+	return paginate(withOperation(ctx, "InstancePlans.All"), options, ips.listPage, opts...)
 }
@@ -20,6 +20,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"reflect"
 	"testing"
 )
@@ -105,3 +108,26 @@ func TestInstancePlans_List(t *testing.T) {
 	}
 
 }
+
+func TestInstancePlans_ListPage(t *testing.T) {
+	var query url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		query = req.URL.Query()
+		_, _ = rw.Write([]byte(instancePlansListResponse))
+	}))
+	api, _ := NewAPIClient(newFakeClientOptions(server))
+
+	ctx := context.Background()
+	options := &ListOptions{Meta: &ListMetaOptions{PerPage: 50}}
+	instancePlans, meta, err := api.InstancePlans.ListPage(ctx, options)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if query.Get("type") != "vps" || query.Get("per_page") != "50" {
+		t.Errorf("Unexpected query %v", query)
+	}
+	if len(instancePlans) != 1 || meta != nil {
+		t.Errorf("unexpected result %v, meta %v", instancePlans, meta)
+	}
+}
@@ -30,11 +30,11 @@ type InstancePrivateNetworkInfo struct {
 		Name    string `json:"name"`
 		Number  string `json:"number,omitempty"`
 	} `json:"instance,omitempty"`
-	ID          string `json:"id,omitempty"`
-	IP          string `json:"ip"`
-	MACAddress  string `json:"mac_address"`
-	State       string `json:"state,omitempty"`
-	ConnectedAt string `json:"connected_at,omitempty"`
+	ID          string    `json:"id,omitempty"`
+	IP          string    `json:"ip"`
+	MACAddress  string    `json:"mac_address"`
+	State       string    `json:"state,omitempty"`
+	ConnectedAt Timestamp `json:"connected_at,omitempty"`
 }
 
 // InstancePrivateNetwork object
@@ -45,10 +45,10 @@ type InstancePrivateNetwork struct {
 
 // InstancePrivateNetworksAPI is an interface for instance connection to private network.
 type InstancePrivateNetworksAPI interface {
-	Create(context.Context, *InstancePrivateNetworkCreateRequest) (*InstancePrivateNetwork, error)
-	Get(context.Context, string) (*InstancePrivateNetwork, error)
-	Update(context.Context, string, *InstancePrivateNetworkUpdateRequest) (*InstancePrivateNetwork, error)
-	Delete(context.Context, string) (*InstancePrivateNetwork, error)
+	Create(context.Context, *InstancePrivateNetworkCreateRequest, ...RequestOption) (*InstancePrivateNetwork, error)
+	Get(context.Context, string, ...RequestOption) (*InstancePrivateNetwork, error)
+	Update(context.Context, string, *InstancePrivateNetworkUpdateRequest, ...RequestOption) (*InstancePrivateNetwork, error)
+	Delete(context.Context, string, ...RequestOption) (*InstancePrivateNetwork, error)
 }
 
 // InstancePrivateNetworksService implements InstancePrivateNetworkConnectionsAPI interface.
@@ -68,11 +68,12 @@ type instancePrivateNetworkInfoRoot struct {
 }
 
 // Get instance private network info
-func (ipns *InstancePrivateNetworksService) Get(ctx context.Context, instancePrivateNetworkID string) (*InstancePrivateNetwork, error) {
+func (ipns *InstancePrivateNetworksService) Get(ctx context.Context, instancePrivateNetworkID string, opts ...RequestOption) (*InstancePrivateNetwork, error) {
+	ctx = withOperation(ctx, "InstancePrivateNetworks.Get")
 
 	path := fmt.Sprintf("api/v1/instance_private_networks/%s", instancePrivateNetworkID)
 
-	req, err := ipns.client.newRequest(http.MethodGet, path, nil)
+	req, err := ipns.client.newRequest(http.MethodGet, path, nil, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -88,12 +89,13 @@ func (ipns *InstancePrivateNetworksService) Get(ctx context.Context, instancePri
 // Create instance connection to the private network
 func (ipns *InstancePrivateNetworksService) Create(
 	ctx context.Context,
-	addRequest *InstancePrivateNetworkCreateRequest) (*InstancePrivateNetwork, error) {
+	addRequest *InstancePrivateNetworkCreateRequest, opts ...RequestOption) (*InstancePrivateNetwork, error) {
+	ctx = withOperation(ctx, "InstancePrivateNetworks.Create")
 
 	type request struct {
 		PrivateNetwork *InstancePrivateNetworkCreateRequest `json:"instance_private_network"`
 	}
-	req, err := ipns.client.newRequest(http.MethodPost, "api/v1/instance_private_networks", &request{addRequest})
+	req, err := ipns.client.newRequest(http.MethodPost, "api/v1/instance_private_networks", &request{addRequest}, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -115,14 +117,15 @@ type InstancePrivateNetworkUpdateRequest struct {
 func (ipns *InstancePrivateNetworksService) Update(
 	ctx context.Context,
 	instancePrivateNetworkID string,
-	updateRequest *InstancePrivateNetworkUpdateRequest) (*InstancePrivateNetwork, error) {
+	updateRequest *InstancePrivateNetworkUpdateRequest, opts ...RequestOption) (*InstancePrivateNetwork, error) {
+	ctx = withOperation(ctx, "InstancePrivateNetworks.Update")
 
 	type request struct {
 		InstancePrivateNetwork *InstancePrivateNetworkUpdateRequest `json:"instance_private_network"`
 	}
 
 	path := fmt.Sprintf("api/v1/instance_private_networks/%s", instancePrivateNetworkID)
-	req, err := ipns.client.newRequest(http.MethodPatch, path, &request{updateRequest})
+	req, err := ipns.client.newRequest(http.MethodPatch, path, &request{updateRequest}, opts...)
 
 	if err != nil {
 		return nil, err
@@ -137,9 +140,10 @@ func (ipns *InstancePrivateNetworksService) Update(
 }
 
 // Delete disconnects instance from the private network
-func (ipns *InstancePrivateNetworksService) Delete(ctx context.Context, instancePrivateNetworkID string) (*InstancePrivateNetwork, error) {
+func (ipns *InstancePrivateNetworksService) Delete(ctx context.Context, instancePrivateNetworkID string, opts ...RequestOption) (*InstancePrivateNetwork, error) {
+	ctx = withOperation(ctx, "InstancePrivateNetworks.Delete")
 	path := fmt.Sprintf("api/v1/instance_private_networks/%s", instancePrivateNetworkID)
-	req, err := ipns.client.newRequest(http.MethodDelete, path, nil)
+	req, err := ipns.client.newRequest(http.MethodDelete, path, nil, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -33,8 +33,8 @@ type InstanceProduct struct {
 		Name string `json:"name,omitempty"`
 	} `json:"category,omitempty"`
 	ID               string                  `json:"id,omitempty"`
-	CreatedAt        string                  `json:"created_at,omitempty"`
-	UpdatedAt        string                  `json:"updated_at,omitempty"`
+	CreatedAt        Timestamp               `json:"created_at,omitempty"`
+	UpdatedAt        Timestamp               `json:"updated_at,omitempty"`
 	Name             string                  `json:"name,omitempty"`
 	Type             string                  `json:"type,omitempty"`
 	Price            string                  `json:"price,omitempty"`
@@ -51,7 +51,7 @@ type InstanceProduct struct {
 
 // InstanceProductsAPI is an interface for instance products.
 type InstanceProductsAPI interface {
-	List(context.Context, *ListOptions) ([]InstanceProduct, *Meta, error)
+	List(context.Context, *ListOptions, ...RequestOption) ([]InstanceProduct, *Meta, error)
 }
 
 // InstanceProductsService implements InstanceProductsAPI interface.
@@ -65,13 +65,14 @@ type instanceProductsRoot struct {
 }
 
 // List returns all available volume products
-func (ips *InstanceProductsService) List(ctx context.Context, options *ListOptions) ([]InstanceProduct, *Meta, error) {
+func (ips *InstanceProductsService) List(ctx context.Context, options *ListOptions, opts ...RequestOption) ([]InstanceProduct, *Meta, error) {
+	ctx = withOperation(ctx, "InstanceProducts.List")
 
 	path := "api/v1/products/instances"
 
 	var ipRoot instanceProductsRoot
 
-	if err := ips.client.list(ctx, path, options, &ipRoot); err != nil {
+	if err := ips.client.list(ctx, path, options, &ipRoot, opts...); err != nil {
 		return nil, nil, err
 	}
 	return ipRoot.Products, ipRoot.Meta, nil
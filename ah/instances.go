@@ -20,16 +20,67 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"iter"
 	"net/http"
+	"slices"
+	"strconv"
 )
 
 var (
 	// ErrPrimaryIPNotFound is returned when primary is not found
 	ErrPrimaryIPNotFound = errors.New("primary ip is not found")
+	// ErrInstanceLocked is returned by Teardown when the instance is locked
+	ErrInstanceLocked = errors.New("instance is locked")
+)
+
+// InstanceState is a state of the instance
+type InstanceState string
+
+// Instance states
+const (
+	InstanceStateCreating InstanceState = "creating"
+	InstanceStateRunning  InstanceState = "running"
+	InstanceStateStopped  InstanceState = "stopped"
+	InstanceStateError    InstanceState = "error"
 )
 
 // InstanceShutDownStatus represents instance's shutdown statuse
-const InstanceShutDownStatus = "stopped"
+//
+// Deprecated: Please use InstanceStateStopped instead.
+const InstanceShutDownStatus = InstanceStateStopped
+
+// Valid reports whether the state is known
+func (s InstanceState) Valid() bool {
+	switch s {
+	case InstanceStateCreating, InstanceStateRunning, InstanceStateStopped, InstanceStateError:
+		return true
+	}
+	return false
+}
+
+// IsError reports whether the instance is broken
+func (s InstanceState) IsError() bool {
+	return s == InstanceStateError
+}
+
+// IsTerminal reports whether the instance isn't transitioning to another state
+func (s InstanceState) IsTerminal() bool {
+	return s == InstanceStateRunning || s == InstanceStateStopped || s.IsError()
+}
+
+// BackupsStrategy defines what happens to the backups of the destroyed instance
+type BackupsStrategy string
+
+// Backups strategies
+const (
+	BackupsStrategyDestroy BackupsStrategy = "destroy"
+	BackupsStrategyKeep    BackupsStrategy = "keep"
+)
+
+// Valid reports whether the strategy is known
+func (s BackupsStrategy) Valid() bool {
+	return s == BackupsStrategyDestroy || s == BackupsStrategyKeep
+}
 
 // InstanceRegion object
 type InstanceRegion struct {
@@ -66,11 +117,11 @@ type InstanceImage struct {
 
 // InstanceSSHKey object
 type InstanceSSHKey struct {
-	CreatedAt   string `json:"created_at,omitempty"`
-	Name        string `json:"name,omitempty"`
-	ID          string `json:"id,omitempty"`
-	Fingerprint string `json:"fingerprint,omitempty"`
-	PublicKey   string `json:"public_key,omitempty"`
+	CreatedAt   Timestamp `json:"created_at,omitempty"`
+	Name        string    `json:"name,omitempty"`
+	ID          string    `json:"id,omitempty"`
+	Fingerprint string    `json:"fingerprint,omitempty"`
+	PublicKey   string    `json:"public_key,omitempty"`
 }
 
 // Instance object
@@ -82,11 +133,11 @@ type Instance struct {
 	LastAction                 *InstanceAction          `json:"last_action,omitempty"`
 	Image                      *InstanceImage           `json:"image,omitempty"`
 	ID                         string                   `json:"id,omitempty"`
-	CreatedAt                  string                   `json:"created_at,omitempty"`
-	UpdatedAt                  string                   `json:"updated_at,omitempty"`
+	CreatedAt                  Timestamp                `json:"created_at,omitempty"`
+	UpdatedAt                  Timestamp                `json:"updated_at,omitempty"`
 	Number                     string                   `json:"number,omitempty"`
 	Name                       string                   `json:"name,omitempty"`
-	State                      string                   `json:"state,omitempty"`
+	State                      InstanceState            `json:"state,omitempty"`
 	StateDescription           string                   `json:"state_description,omitempty"`
 	ProductID                  string                   `json:"product_id,omitempty"`
 	PrimaryInstanceIPAddressID string                   `json:"primary_instance_ip_address_id,omitempty"`
@@ -130,12 +181,12 @@ func (i *Instance) PrimaryIPAddr() (*InstanceIPAddress, error) {
 
 // InstanceIPAddress object
 type InstanceIPAddress struct {
-	ID          string `json:"id,omitempty"`
-	InstanceID  string `json:"instance_id,omitempty"`
-	CreatedAt   string `json:"created_at,omitempty"`
-	UpdatedAt   string `json:"updated_at,omitempty"`
-	IPAddressID string `json:"ip_address_id,omitempty"`
-	Address     string `json:"address,omitempty"`
+	ID          string    `json:"id,omitempty"`
+	InstanceID  string    `json:"instance_id,omitempty"`
+	CreatedAt   Timestamp `json:"created_at,omitempty"`
+	UpdatedAt   Timestamp `json:"updated_at,omitempty"`
+	IPAddressID string    `json:"ip_address_id,omitempty"`
+	Address     string    `json:"address,omitempty"`
 }
 
 type instancesRoot struct {
@@ -183,27 +234,40 @@ type InstanceRenameRequest struct {
 
 // InstanceActionRequest represents an action request.
 type InstanceActionRequest struct {
-	ID   string `json:"id"`
-	Type string `json:"type"`
+	ID   string     `json:"id"`
+	Type ActionType `json:"type"`
 }
 
 // InstancesAPI is an interface for instances.
 type InstancesAPI interface {
-	List(context.Context, *ListOptions) ([]Instance, *Meta, error)
-	Get(context.Context, string) (*Instance, error)
-	Create(context.Context, *InstanceCreateRequest) (*Instance, error)
-	Rename(context.Context, string, string) (*Instance, error)
-	Upgrade(context.Context, string, *InstanceUpgradeRequest) error
-	Shutdown(context.Context, string) error
-	PowerOff(context.Context, string) error
-	Destroy(context.Context, string) error
-	SetPrimaryIP(context.Context, string, string) (*Action, error)
-	AttachVolume(context.Context, string, string) (*Action, error)
-	DetachVolume(context.Context, string, string) (*Action, error)
-	ActionInfo(context.Context, string, string) (*InstanceAction, error)
-	Actions(context.Context, string) ([]InstanceAction, error)
-	AvailableVolumes(context.Context, string, *ListOptions) ([]Volume, *Meta, error)
-	CreateBackup(context.Context, string, string) (*InstanceAction, error)
+	List(context.Context, *ListOptions, ...RequestOption) ([]Instance, *Meta, error)
+	All(context.Context, *ListOptions, ...RequestOption) iter.Seq2[Instance, error]
+	Get(context.Context, string, ...RequestOption) (*Instance, error)
+	Create(context.Context, *InstanceCreateRequest, ...RequestOption) (*Instance, error)
+	Rename(context.Context, string, string, ...RequestOption) (*Instance, error)
+	Update(context.Context, string, *InstanceUpdateRequest, ...RequestOption) (*Instance, error)
+	AddTags(context.Context, string, []string, ...RequestOption) (*Instance, error)
+	RemoveTags(context.Context, string, []string, ...RequestOption) (*Instance, error)
+	Upgrade(context.Context, string, *InstanceUpgradeRequest, ...RequestOption) error
+	Shutdown(context.Context, string, ...RequestOption) error
+	PowerOff(context.Context, string, ...RequestOption) error
+	PowerOn(context.Context, string, ...RequestOption) (*InstanceAction, error)
+	Reboot(context.Context, string, ...RequestOption) (*InstanceAction, error)
+	HardReboot(context.Context, string, ...RequestOption) (*InstanceAction, error)
+	Reset(context.Context, string, ...RequestOption) (*InstanceAction, error)
+	Rebuild(context.Context, string, *InstanceRebuildRequest, ...RequestOption) (*InstanceAction, error)
+	Destroy(context.Context, string, ...RequestOption) error
+	DestroyWithOptions(context.Context, string, *InstanceDestroyOptions, ...RequestOption) error
+	Teardown(context.Context, string, *InstanceTeardownOptions, ...RequestOption) error
+	SetPrimaryIP(context.Context, string, string, ...RequestOption) (*Action, error)
+	AttachVolume(context.Context, string, string, ...RequestOption) (*Action, error)
+	DetachVolume(context.Context, string, string, ...RequestOption) (*Action, error)
+	ActionInfo(context.Context, string, string, ...RequestOption) (*InstanceAction, error)
+	Actions(context.Context, string, ...RequestOption) ([]InstanceAction, error)
+	AvailableVolumes(context.Context, string, *ListOptions, ...RequestOption) ([]Volume, *Meta, error)
+	CreateBackup(context.Context, string, string, ...RequestOption) (*InstanceAction, error)
+	RestoreFromBackup(context.Context, string, string, ...RequestOption) (*InstanceAction, error)
+	CreateFromBackup(context.Context, string, *InstanceCreateRequest, ...RequestOption) (*Instance, error)
 }
 
 // InstancesService implements InstancesApi interface.
@@ -214,12 +278,13 @@ type InstancesService struct {
 var _ InstancesAPI = &InstancesService{}
 
 // Create new instance.
-func (is *InstancesService) Create(ctx context.Context, createRequest *InstanceCreateRequest) (*Instance, error) {
+func (is *InstancesService) Create(ctx context.Context, createRequest *InstanceCreateRequest, opts ...RequestOption) (*Instance, error) {
+	ctx = withOperation(ctx, "Instances.Create")
 
 	type request struct {
 		Instance *InstanceCreateRequest `json:"instance"`
 	}
-	req, err := is.client.newRequest(http.MethodPost, "api/v1/instances", &request{createRequest})
+	req, err := is.client.newRequest(http.MethodPost, "api/v1/instances", &request{createRequest}, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -238,12 +303,13 @@ func (is *InstancesService) Create(ctx context.Context, createRequest *InstanceC
 }
 
 // Rename instance.
-func (is *InstancesService) Rename(ctx context.Context, instanceID, name string) (*Instance, error) {
+func (is *InstancesService) Rename(ctx context.Context, instanceID, name string, opts ...RequestOption) (*Instance, error) {
+	ctx = withOperation(ctx, "Instances.Rename")
 	createRequest := &InstanceRenameRequest{
 		Name: name,
 	}
 	path := fmt.Sprintf("api/v1/instances/%s", instanceID)
-	req, err := is.client.newRequest(http.MethodPatch, path, createRequest)
+	req, err := is.client.newRequest(http.MethodPatch, path, createRequest, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -260,6 +326,71 @@ func (is *InstancesService) Rename(ctx context.Context, instanceID, name string)
 	return instanceRoot.Instance, err
 }
 
+// InstanceUpdateRequest represents a request to update the instance. Only non-nil fields are updated.
+type InstanceUpdateRequest struct {
+	Name               *string   `json:"name,omitempty"`
+	Tags               *[]string `json:"tags,omitempty"`
+	SnapshotBySchedule *bool     `json:"snapshot_by_schedule,omitempty"`
+	SnapshotPeriod     *string   `json:"snapshot_period,omitempty"`
+}
+
+// Update instance
+func (is *InstancesService) Update(ctx context.Context, instanceID string, updateRequest *InstanceUpdateRequest, opts ...RequestOption) (*Instance, error) {
+	ctx = withOperation(ctx, "Instances.Update")
+	path := fmt.Sprintf("api/v1/instances/%s", instanceID)
+	req, err := is.client.newRequest(http.MethodPatch, path, updateRequest, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var instanceRoot instanceRoot
+	if _, err := is.client.Do(ctx, req, &instanceRoot); err != nil {
+		return nil, err
+	}
+	return instanceRoot.Instance, nil
+}
+
+// AddTags adds the tags to the instance. Tags are read and written by separate requests,
+// so concurrent changes of the tags may be lost. Request options are applied to both requests.
+func (is *InstancesService) AddTags(ctx context.Context, instanceID string, tags []string, opts ...RequestOption) (*Instance, error) {
+	ctx = withOperation(ctx, "Instances.AddTags")
+	return is.updateTags(ctx, instanceID, func(current []string) []string {
+		for _, tag := range tags {
+			if !slices.Contains(current, tag) {
+				current = append(current, tag)
+			}
+		}
+		return current
+	}, opts...)
+}
+
+// RemoveTags removes the tags from the instance. Tags are read and written by separate requests,
+// so concurrent changes of the tags may be lost. Request options are applied to both requests.
+func (is *InstancesService) RemoveTags(ctx context.Context, instanceID string, tags []string, opts ...RequestOption) (*Instance, error) {
+	ctx = withOperation(ctx, "Instances.RemoveTags")
+	return is.updateTags(ctx, instanceID, func(current []string) []string {
+		return slices.DeleteFunc(current, func(tag string) bool {
+			return slices.Contains(tags, tag)
+		})
+	}, opts...)
+}
+
+// updateTags reads the tags of the instance and writes the modified ones if they have changed
+func (is *InstancesService) updateTags(ctx context.Context, instanceID string, modify func([]string) []string, opts ...RequestOption) (*Instance, error) {
+	instance, err := is.Get(ctx, instanceID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	tags := modify(slices.Clone(instance.Tags))
+	if slices.Equal(tags, instance.Tags) {
+		return instance, nil
+	}
+	if tags == nil {
+		tags = []string{}
+	}
+	return is.Update(ctx, instanceID, &InstanceUpdateRequest{Tags: &tags}, opts...)
+}
+
 // InstanceUpgradeRequest represents a request to upgrade the instance.
 type InstanceUpgradeRequest struct {
 	// Deprecated: Please use PlanID instead.
@@ -271,8 +402,8 @@ type InstanceUpgradeRequest struct {
 }
 
 type instanceUpgradeRequest struct {
-	ID   string `json:"id"`
-	Type string `json:"type"`
+	ID   string     `json:"id"`
+	Type ActionType `json:"type"`
 	// Deprecated: Please use PlanID instead.
 	ProductID string `json:"product_id,omitempty"`
 	// Deprecated: Please use PlanSlug instead.
@@ -282,10 +413,11 @@ type instanceUpgradeRequest struct {
 }
 
 // Upgrade instance.
-func (is *InstancesService) Upgrade(ctx context.Context, instanceID string, request *InstanceUpgradeRequest) error {
+func (is *InstancesService) Upgrade(ctx context.Context, instanceID string, request *InstanceUpgradeRequest, opts ...RequestOption) error {
+	ctx = withOperation(ctx, "Instances.Upgrade")
 	upgradeRequest := &instanceUpgradeRequest{
 		ID:   instanceID,
-		Type: "upgrade",
+		Type: ActionTypeUpgrade,
 	}
 
 	if request.PlanSlug != "" {
@@ -303,7 +435,7 @@ func (is *InstancesService) Upgrade(ctx context.Context, instanceID string, requ
 	}
 
 	path := fmt.Sprintf("api/v1/instances/%s/actions", instanceID)
-	req, err := is.client.newRequest(http.MethodPost, path, upgradeRequest)
+	req, err := is.client.newRequest(http.MethodPost, path, upgradeRequest, opts...)
 	if err != nil {
 		return err
 	}
@@ -320,13 +452,14 @@ func (is *InstancesService) Upgrade(ctx context.Context, instanceID string, requ
 }
 
 // Shutdown instance.
-func (is *InstancesService) Shutdown(ctx context.Context, instanceID string) error {
+func (is *InstancesService) Shutdown(ctx context.Context, instanceID string, opts ...RequestOption) error {
+	ctx = withOperation(ctx, "Instances.Shutdown")
 	actionRequest := &InstanceActionRequest{
 		ID:   instanceID,
-		Type: "shutdown",
+		Type: ActionTypeShutdown,
 	}
 	path := fmt.Sprintf("api/v1/instances/%s/actions", instanceID)
-	req, err := is.client.newRequest(http.MethodPost, path, actionRequest)
+	req, err := is.client.newRequest(http.MethodPost, path, actionRequest, opts...)
 	if err != nil {
 		return err
 	}
@@ -343,13 +476,14 @@ func (is *InstancesService) Shutdown(ctx context.Context, instanceID string) err
 }
 
 // PowerOff instance.
-func (is *InstancesService) PowerOff(ctx context.Context, instanceID string) error {
+func (is *InstancesService) PowerOff(ctx context.Context, instanceID string, opts ...RequestOption) error {
+	ctx = withOperation(ctx, "Instances.PowerOff")
 	actionRequest := &InstanceActionRequest{
 		ID:   instanceID,
-		Type: "power_off",
+		Type: ActionTypePowerOff,
 	}
 	path := fmt.Sprintf("api/v1/instances/%s/actions", instanceID)
-	req, err := is.client.newRequest(http.MethodPost, path, actionRequest)
+	req, err := is.client.newRequest(http.MethodPost, path, actionRequest, opts...)
 	if err != nil {
 		return err
 	}
@@ -365,18 +499,121 @@ func (is *InstancesService) PowerOff(ctx context.Context, instanceID string) err
 	return err
 }
 
+// action posts the action request and returns the started action
+func (is *InstancesService) action(ctx context.Context, instanceID string, request interface{}, opts ...RequestOption) (*InstanceAction, error) {
+	path := fmt.Sprintf("api/v1/instances/%s/actions", instanceID)
+	req, err := is.client.newRequest(http.MethodPost, path, request, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var aRoot instanceActionRoot
+	if _, err = is.client.Do(ctx, req, &aRoot); err != nil {
+		return nil, err
+	}
+	return aRoot.Action, nil
+}
+
+// PowerOn starts the stopped instance
+func (is *InstancesService) PowerOn(ctx context.Context, instanceID string, opts ...RequestOption) (*InstanceAction, error) {
+	ctx = withOperation(ctx, "Instances.PowerOn")
+	return is.action(ctx, instanceID, &InstanceActionRequest{ID: instanceID, Type: ActionTypePowerOn}, opts...)
+}
+
+// Reboot gracefully restarts the instance's operating system
+func (is *InstancesService) Reboot(ctx context.Context, instanceID string, opts ...RequestOption) (*InstanceAction, error) {
+	ctx = withOperation(ctx, "Instances.Reboot")
+	return is.action(ctx, instanceID, &InstanceActionRequest{ID: instanceID, Type: ActionTypeReboot}, opts...)
+}
+
+// HardReboot powers the instance off and on without waiting for the operating system
+func (is *InstancesService) HardReboot(ctx context.Context, instanceID string, opts ...RequestOption) (*InstanceAction, error) {
+	ctx = withOperation(ctx, "Instances.HardReboot")
+	return is.action(ctx, instanceID, &InstanceActionRequest{ID: instanceID, Type: ActionTypeHardReboot}, opts...)
+}
+
+// Reset resets the instance like the reset button of a physical server
+func (is *InstancesService) Reset(ctx context.Context, instanceID string, opts ...RequestOption) (*InstanceAction, error) {
+	ctx = withOperation(ctx, "Instances.Reset")
+	return is.action(ctx, instanceID, &InstanceActionRequest{ID: instanceID, Type: ActionTypeReset}, opts...)
+}
+
+// InstanceRebuildRequest represents a request to rebuild the instance from the image.
+type InstanceRebuildRequest struct {
+	ImageID   string
+	ImageSlug string
+}
+
+type instanceRebuildRequest struct {
+	ID        string     `json:"id"`
+	Type      ActionType `json:"type"`
+	ImageID   string     `json:"image_id,omitempty"`
+	ImageSlug string     `json:"image_slug,omitempty"`
+}
+
+// Rebuild reinstalls the instance from the image. Data on the instance's disk is lost.
+func (is *InstancesService) Rebuild(ctx context.Context, instanceID string, request *InstanceRebuildRequest, opts ...RequestOption) (*InstanceAction, error) {
+	ctx = withOperation(ctx, "Instances.Rebuild")
+	if request == nil || (request.ImageID == "" && request.ImageSlug == "") {
+		return nil, fmt.Errorf("rebuild instance %s: image is required", instanceID)
+	}
+	rebuildRequest := &instanceRebuildRequest{
+		ID:        instanceID,
+		Type:      ActionTypeRebuild,
+		ImageID:   request.ImageID,
+		ImageSlug: request.ImageSlug,
+	}
+	return is.action(ctx, instanceID, rebuildRequest, opts...)
+}
+
 // InstanceDestroyRequest represents a request to destroy the instance.
 type InstanceDestroyRequest struct {
-	BackupsStrategy string `json:"backups_strategy"`
+	BackupsStrategy BackupsStrategy `json:"backups_strategy"`
+}
+
+// InstanceDestroyOptions configures destroying of the instance
+type InstanceDestroyOptions struct {
+	// BackupsStrategy defines what happens to the backups. Defaults to BackupsStrategyKeep.
+	BackupsStrategy BackupsStrategy
 }
 
-// Destroy isntance.
-func (is *InstancesService) Destroy(ctx context.Context, instanceID string) error {
+// InstanceTeardownOptions configures Teardown of the instance
+type InstanceTeardownOptions struct {
+	// WaitOptions configures waiting for volumes to be detached and IP addresses to be unassigned
+	WaitOptions *WaitOptions
+	// BackupsStrategy defines what happens to the backups. Defaults to BackupsStrategyKeep.
+	BackupsStrategy BackupsStrategy
+	// DetachVolumes detaches volumes first, so they aren't destroyed with the instance
+	DetachVolumes bool
+	// UnassignIPAddresses unassigns IP addresses first, so they're kept in the account
+	UnassignIPAddresses bool
+	// ReleaseIPAddresses unassigns and deletes IP addresses of the instance
+	ReleaseIPAddresses bool
+}
+
+// Destroy isntance. Backups of the instance are destroyed too.
+func (is *InstancesService) Destroy(ctx context.Context, instanceID string, opts ...RequestOption) error {
+	options := &InstanceDestroyOptions{BackupsStrategy: BackupsStrategyDestroy}
+	return is.destroy(withOperation(ctx, "Instances.Destroy"), instanceID, options, opts...)
+}
+
+// DestroyWithOptions destroys the instance with the backups strategy of the options.
+// Backups are kept if the options are nil or the strategy isn't set, unlike with Destroy.
+// Use Teardown to detach volumes and unassign IP addresses first.
+func (is *InstancesService) DestroyWithOptions(ctx context.Context, instanceID string, options *InstanceDestroyOptions, opts ...RequestOption) error {
+	return is.destroy(withOperation(ctx, "Instances.DestroyWithOptions"), instanceID, options, opts...)
+}
+
+// destroy is DestroyWithOptions without the operation name, so that Destroy keeps its own
+func (is *InstancesService) destroy(ctx context.Context, instanceID string, options *InstanceDestroyOptions, opts ...RequestOption) error {
 	destroyRequest := &InstanceDestroyRequest{
-		BackupsStrategy: "destroy",
+		BackupsStrategy: BackupsStrategyKeep,
+	}
+	if options != nil && options.BackupsStrategy != "" {
+		destroyRequest.BackupsStrategy = options.BackupsStrategy
 	}
 	path := fmt.Sprintf("api/v1/instances/%s", instanceID)
-	req, err := is.client.newRequest(http.MethodDelete, path, destroyRequest)
+	req, err := is.client.newRequest(http.MethodDelete, path, destroyRequest, opts...)
 	if err != nil {
 		return err
 	}
@@ -387,18 +624,94 @@ func (is *InstancesService) Destroy(ctx context.Context, instanceID string) erro
 	}
 
 	if resp.StatusCode != 202 {
-		return fmt.Errorf("Error shutdown instance: %v", resp.StatusCode)
+		return fmt.Errorf("Error destroy instance: %v", resp.StatusCode)
 	}
 	return err
 }
 
+// Teardown safely destroys the instance. Depending on the options it detaches volumes, unassigns
+// or releases IP addresses, including the primary one, waiting until volumes are detached
+// and addresses are unassigned, and then destroys the instance. ErrInstanceLocked is returned
+// if the instance is locked. Backups are kept unless the options set BackupsStrategyDestroy.
+// Request options are applied to the destroy request only.
+func (is *InstancesService) Teardown(ctx context.Context, instanceID string, options *InstanceTeardownOptions, opts ...RequestOption) error {
+	ctx = withOperation(ctx, "Instances.Teardown")
+	if options == nil {
+		options = &InstanceTeardownOptions{}
+	}
+	instance, err := is.Get(ctx, instanceID)
+	if err != nil {
+		return err
+	}
+	if instance.Locked {
+		return fmt.Errorf("teardown instance %s: %w", instanceID, ErrInstanceLocked)
+	}
+
+	if options.DetachVolumes {
+		var actions []*Action
+		for _, volume := range instance.Volumes {
+			action, err := is.DetachVolume(ctx, instanceID, volume.ID)
+			if err != nil {
+				return fmt.Errorf("detach volume %s: %w", volume.ID, err)
+			}
+			actions = append(actions, action)
+		}
+		for _, action := range actions {
+			if _, err := is.client.WaitForAction(ctx, action, options.WaitOptions); err != nil {
+				return err
+			}
+		}
+	}
+
+	if options.UnassignIPAddresses || options.ReleaseIPAddresses {
+		for _, ip := range instance.IPAddresses {
+			if err := is.client.IPAddressAssignments.Delete(ctx, ip.ID); err != nil {
+				return fmt.Errorf("unassign ip address %s: %w", ip.IPAddressID, err)
+			}
+		}
+		for _, ip := range instance.IPAddresses {
+			if err := is.waitForUnassignment(ctx, instanceID, ip.IPAddressID, options.WaitOptions); err != nil {
+				return err
+			}
+			if !options.ReleaseIPAddresses {
+				continue
+			}
+			if err := is.client.IPAddresses.Delete(ctx, ip.IPAddressID); err != nil {
+				return fmt.Errorf("release ip address %s: %w", ip.IPAddressID, err)
+			}
+		}
+	}
+
+	return is.DestroyWithOptions(ctx, instanceID, &InstanceDestroyOptions{BackupsStrategy: options.BackupsStrategy}, opts...)
+}
+
+// waitForUnassignment polls the IP address until it isn't assigned to the instance
+func (is *InstancesService) waitForUnassignment(ctx context.Context, instanceID, ipAddressID string, options *WaitOptions) error {
+	err := poll(ctx, options, func(ctx context.Context) (bool, error) {
+		ip, err := is.client.IPAddresses.Get(ctx, ipAddressID)
+		if err != nil {
+			return false, err
+		}
+		return !slices.Contains(ip.InstanceIDs, instanceID), nil
+	})
+	if err != nil {
+		return fmt.Errorf("waiting for ip address %s to be unassigned: %w", ipAddressID, err)
+	}
+	return nil
+}
+
 // List returns all available instances
-func (is *InstancesService) List(ctx context.Context, options *ListOptions) ([]Instance, *Meta, error) {
+func (is *InstancesService) List(ctx context.Context, options *ListOptions, opts ...RequestOption) ([]Instance, *Meta, error) {
+	return is.list(withOperation(ctx, "Instances.List"), options, opts...)
+}
+
+// list is List without the operation name, so that wrappers keep their own
+func (is *InstancesService) list(ctx context.Context, options *ListOptions, opts ...RequestOption) ([]Instance, *Meta, error) {
 	path := "api/v1/instances"
 
 	var instRoot instancesRoot
 
-	if err := is.client.list(ctx, path, options, &instRoot); err != nil {
+	if err := is.client.list(ctx, path, options, &instRoot, opts...); err != nil {
 		return nil, nil, err
 	}
 
@@ -406,10 +719,16 @@ func (is *InstancesService) List(ctx context.Context, options *ListOptions) ([]I
 
 }
 
+// All returns an iterator over all instances fetching pages lazily
+func (is *InstancesService) All(ctx context.Context, options *ListOptions, opts ...RequestOption) iter.Seq2[Instance, error] {
+	return paginate(withOperation(ctx, "Instances.All"), options, is.list, opts...)
+}
+
 // Get returns all instance by instanceID
-func (is *InstancesService) Get(ctx context.Context, instanceID string) (*Instance, error) {
+func (is *InstancesService) Get(ctx context.Context, instanceID string, opts ...RequestOption) (*Instance, error) {
+	ctx = withOperation(ctx, "Instances.Get")
 	path := fmt.Sprintf("api/v1/instances/%s", instanceID)
-	req, err := is.client.newRequest(http.MethodGet, path, nil)
+	req, err := is.client.newRequest(http.MethodGet, path, nil, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -426,18 +745,19 @@ func (is *InstancesService) Get(ctx context.Context, instanceID string) (*Instan
 }
 
 type instanceSetPrimaryIPRequest struct {
-	Type                string `json:"type"`
-	InstanceIPAddressID string `json:"instance_ip_address_id"`
+	Type                ActionType `json:"type"`
+	InstanceIPAddressID string     `json:"instance_ip_address_id"`
 }
 
 // SetPrimaryIP makes ip primary for instance
-func (is *InstancesService) SetPrimaryIP(ctx context.Context, instanceID, ipAssignmentID string) (*Action, error) {
+func (is *InstancesService) SetPrimaryIP(ctx context.Context, instanceID, ipAssignmentID string, opts ...RequestOption) (*Action, error) {
+	ctx = withOperation(ctx, "Instances.SetPrimaryIP")
 	request := &instanceSetPrimaryIPRequest{
 		InstanceIPAddressID: ipAssignmentID,
-		Type:                "set_primary_ip",
+		Type:                ActionTypeSetPrimaryIP,
 	}
 	path := fmt.Sprintf("api/v1/instances/%s/actions", instanceID)
-	req, err := is.client.newRequest(http.MethodPost, path, request)
+	req, err := is.client.newRequest(http.MethodPost, path, request, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -460,9 +780,10 @@ type instanceActionsRoot struct {
 }
 
 // ActionInfo returns instance's action info by action ID
-func (is *InstancesService) ActionInfo(ctx context.Context, instanceID, actionID string) (*InstanceAction, error) {
+func (is *InstancesService) ActionInfo(ctx context.Context, instanceID, actionID string, opts ...RequestOption) (*InstanceAction, error) {
+	ctx = withOperation(ctx, "Instances.ActionInfo")
 	path := fmt.Sprintf("api/v1/instances/%s/actions/%s", instanceID, actionID)
-	req, err := is.client.newRequest(http.MethodGet, path, nil)
+	req, err := is.client.newRequest(http.MethodGet, path, nil, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -481,9 +802,10 @@ func (is *InstancesService) ActionInfo(ctx context.Context, instanceID, actionID
 }
 
 // Actions returns instance's actions list
-func (is *InstancesService) Actions(ctx context.Context, instanceID string) ([]InstanceAction, error) {
+func (is *InstancesService) Actions(ctx context.Context, instanceID string, opts ...RequestOption) ([]InstanceAction, error) {
+	ctx = withOperation(ctx, "Instances.Actions")
 	path := fmt.Sprintf("api/v1/instances/%s/actions", instanceID)
-	req, err := is.client.newRequest(http.MethodGet, path, nil)
+	req, err := is.client.newRequest(http.MethodGet, path, nil, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -497,18 +819,19 @@ func (is *InstancesService) Actions(ctx context.Context, instanceID string) ([]I
 }
 
 type instanceAttachVolumeRequest struct {
-	VolumeID string `json:"volume_id"`
-	Type     string `json:"type"`
+	VolumeID string     `json:"volume_id"`
+	Type     ActionType `json:"type"`
 }
 
 // AttachVolume connects volume to the instance
-func (is *InstancesService) AttachVolume(ctx context.Context, instanceID, volumeID string) (*Action, error) {
+func (is *InstancesService) AttachVolume(ctx context.Context, instanceID, volumeID string, opts ...RequestOption) (*Action, error) {
+	ctx = withOperation(ctx, "Instances.AttachVolume")
 	request := &instanceAttachVolumeRequest{
 		VolumeID: volumeID,
-		Type:     "attach_volume",
+		Type:     ActionTypeAttachVolume,
 	}
 	path := fmt.Sprintf("api/v1/instances/%s/actions", instanceID)
-	req, err := is.client.newRequest(http.MethodPost, path, request)
+	req, err := is.client.newRequest(http.MethodPost, path, request, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -523,18 +846,19 @@ func (is *InstancesService) AttachVolume(ctx context.Context, instanceID, volume
 }
 
 type instanceDetachVolumeRequest struct {
-	VolumeID string `json:"volume_id"`
-	Type     string `json:"type"`
+	VolumeID string     `json:"volume_id"`
+	Type     ActionType `json:"type"`
 }
 
 // DetachVolume disconnects volume to the instance
-func (is *InstancesService) DetachVolume(ctx context.Context, instanceID, volumeID string) (*Action, error) {
+func (is *InstancesService) DetachVolume(ctx context.Context, instanceID, volumeID string, opts ...RequestOption) (*Action, error) {
+	ctx = withOperation(ctx, "Instances.DetachVolume")
 	request := &instanceDetachVolumeRequest{
 		VolumeID: volumeID,
-		Type:     "detach_volume",
+		Type:     ActionTypeDetachVolume,
 	}
 	path := fmt.Sprintf("api/v1/instances/%s/actions", instanceID)
-	req, err := is.client.newRequest(http.MethodPost, path, request)
+	req, err := is.client.newRequest(http.MethodPost, path, request, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -549,26 +873,28 @@ func (is *InstancesService) DetachVolume(ctx context.Context, instanceID, volume
 }
 
 // AvailableVolumes return all attached volumes to the instance.
-func (is *InstancesService) AvailableVolumes(ctx context.Context, instanceID string, options *ListOptions) ([]Volume, *Meta, error) {
+func (is *InstancesService) AvailableVolumes(ctx context.Context, instanceID string, options *ListOptions, opts ...RequestOption) ([]Volume, *Meta, error) {
+	ctx = withOperation(ctx, "Instances.AvailableVolumes")
 	path := fmt.Sprintf("api/v1/instances/%s/available_volumes", instanceID)
 
 	var vsRoot volumesRoot
 
-	if err := is.client.list(ctx, path, options, &vsRoot); err != nil {
+	if err := is.client.list(ctx, path, options, &vsRoot, opts...); err != nil {
 		return nil, nil, err
 	}
 	return vsRoot.Volumes, vsRoot.Meta, nil
 }
 
 // CreateBackup creates instance's backups
-func (is *InstancesService) CreateBackup(ctx context.Context, instanceID, note string) (*InstanceAction, error) {
+func (is *InstancesService) CreateBackup(ctx context.Context, instanceID, note string, opts ...RequestOption) (*InstanceAction, error) {
+	ctx = withOperation(ctx, "Instances.CreateBackup")
 
 	var request = &struct {
 		Note string `json:"note"`
 	}{note}
 
 	path := fmt.Sprintf("api/v1/instances/%s/backups", instanceID)
-	req, err := is.client.newRequest(http.MethodPost, path, request)
+	req, err := is.client.newRequest(http.MethodPost, path, request, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -581,3 +907,105 @@ func (is *InstancesService) CreateBackup(ctx context.Context, instanceID, note s
 
 	return aRoot.Action, nil
 }
+
+type instanceRestoreRequest struct {
+	ID       string     `json:"id"`
+	Type     ActionType `json:"type"`
+	BackupID string     `json:"backup_id"`
+}
+
+// RestoreFromBackup restores the instance from the backup. Data on the instance's disk is lost.
+// ErrDiskTooSmall is returned if the backup doesn't fit the instance's disk.
+func (is *InstancesService) RestoreFromBackup(ctx context.Context, instanceID, backupID string, opts ...RequestOption) (*InstanceAction, error) {
+	ctx = withOperation(ctx, "Instances.RestoreFromBackup")
+	backup, err := is.client.Backups.Get(ctx, backupID)
+	if err != nil {
+		return nil, err
+	}
+	instance, err := is.Get(ctx, instanceID)
+	if err != nil {
+		return nil, err
+	}
+	if err := backup.checkDisk(instance.Disk); err != nil {
+		return nil, err
+	}
+
+	request := &instanceRestoreRequest{
+		ID:       instanceID,
+		Type:     ActionTypeRestore,
+		BackupID: backupID,
+	}
+	return is.action(ctx, instanceID, request, opts...)
+}
+
+// CreateFromBackup creates a new instance from the backup. The image of the request is replaced
+// with the backup. ErrDiskTooSmall is returned if the backup doesn't fit the disk of the plan,
+// or the custom disk size of the request. The disk isn't checked if the plan doesn't report its size,
+// the API validates it then. Deprecated ProductID and ProductSlug are used as the plan if PlanID
+// and PlanSlug aren't set.
+func (is *InstancesService) CreateFromBackup(ctx context.Context, backupID string, createRequest *InstanceCreateRequest, opts ...RequestOption) (*Instance, error) {
+	ctx = withOperation(ctx, "Instances.CreateFromBackup")
+	if createRequest == nil {
+		return nil, fmt.Errorf("create instance from backup %s: create request is required", backupID)
+	}
+	backup, err := is.client.Backups.Get(ctx, backupID)
+	if err != nil {
+		return nil, err
+	}
+
+	diskSize := createRequest.Disk
+	if diskSize == 0 {
+		plan, err := is.findPlan(ctx, createRequest)
+		if err != nil {
+			return nil, err
+		}
+		if plan.CustomAttributes != nil {
+			diskSize = plan.CustomAttributes.Disk
+		}
+	}
+	if diskSize != 0 {
+		if err := backup.checkDisk(diskSize); err != nil {
+			return nil, err
+		}
+	}
+
+	request := *createRequest
+	request.ImageID = backup.ID
+	request.ImageSlug = ""
+	return is.Create(ctx, &request, opts...)
+}
+
+// findPlan returns the instance plan of the create request. Products are the former name of plans,
+// so deprecated ProductID and ProductSlug are used if PlanID and PlanSlug aren't set.
+func (is *InstancesService) findPlan(ctx context.Context, createRequest *InstanceCreateRequest) (*InstancePlan, error) {
+	planID, planSlug := createRequest.PlanID, createRequest.PlanSlug
+	if planID == 0 && planSlug == "" {
+		switch {
+		case createRequest.ProductID != "":
+			id, err := strconv.Atoi(createRequest.ProductID)
+			if err != nil {
+				return nil, fmt.Errorf("invalid product id %q: %w", createRequest.ProductID, err)
+			}
+			planID = id
+		case createRequest.ProductSlug != "":
+			planSlug = createRequest.ProductSlug
+		default:
+			return nil, errors.New("plan id or plan slug is required")
+		}
+	}
+	for plan, err := range is.client.InstancePlans.All(ctx, nil) {
+		if err != nil {
+			return nil, err
+		}
+		if planID != 0 && plan.ID == planID {
+			return &plan, nil
+		}
+		if planID == 0 && plan.CustomAttributes != nil && plan.CustomAttributes.Slug == planSlug {
+			return &plan, nil
+		}
+	}
+	if planID != 0 {
+		return nil, fmt.Errorf("instance plan %d: %w", planID, ErrResourceNotFound)
+	}
+	return nil, fmt.Errorf("instance plan %s: %w", planSlug, ErrResourceNotFound)
+}
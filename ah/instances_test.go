@@ -19,9 +19,15 @@ package ah
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 )
 
 const instanceResponse = `{
@@ -276,7 +282,7 @@ func TestInstance_GetNonExisted(t *testing.T) {
 		t.Errorf("Unexpected instance %v", instance)
 	}
 
-	if err != ErrResourceNotFound {
+	if !errors.Is(err, ErrResourceNotFound) {
 		t.Errorf("Unexpected error %s", err)
 	}
 
@@ -844,3 +850,350 @@ func TestInstance_CreateBackup(t *testing.T) {
 		t.Errorf("unexpected snapshot id, expected a66efd38-177f-4eb9-9a99-f4d3bce6b4f4. got: %s", action.ResultParams.SnapshotID)
 	}
 }
+
+func TestInstanceState(t *testing.T) {
+	for _, state := range []InstanceState{InstanceStateRunning, InstanceStateStopped, InstanceStateError} {
+		if !state.Valid() || !state.IsTerminal() {
+			t.Errorf("Unexpected helpers of %q state", state)
+		}
+	}
+	if !InstanceStateCreating.Valid() || InstanceStateCreating.IsTerminal() || InstanceStateCreating.IsError() {
+		t.Errorf("Unexpected helpers of creating state")
+	}
+	if !InstanceStateError.IsError() || InstanceStateRunning.IsError() {
+		t.Errorf("Unexpected error states")
+	}
+
+	var instance Instance
+	if err := json.Unmarshal([]byte(`{"state": "migrating"}`), &instance); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if instance.State != "migrating" || instance.State.Valid() || instance.State.IsTerminal() {
+		t.Errorf("Unexpected state %q", instance.State)
+	}
+}
+
+func TestBackupsStrategy_Valid(t *testing.T) {
+	if !BackupsStrategyDestroy.Valid() || !BackupsStrategyKeep.Valid() || BackupsStrategy("archive").Valid() {
+		t.Errorf("Unexpected validation of backups strategies")
+	}
+}
+
+func TestInstance_PowerActions(t *testing.T) {
+	var requests []map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost || req.URL.Path != "/api/v1/instances/instance-id/actions" {
+			t.Errorf("Unexpected request %s %s", req.Method, req.URL.Path)
+		}
+		var request map[string]string
+		_ = json.NewDecoder(req.Body).Decode(&request)
+		requests = append(requests, request)
+		rw.WriteHeader(http.StatusAccepted)
+		_, _ = rw.Write([]byte(actionGetResponse))
+	}))
+	defer server.Close()
+	api, _ := NewAPIClient(newFakeClientOptions(server))
+
+	ctx := context.Background()
+	actions := []func(context.Context, string, ...RequestOption) (*InstanceAction, error){
+		api.Instances.PowerOn, api.Instances.Reboot, api.Instances.HardReboot, api.Instances.Reset,
+	}
+	for _, action := range actions {
+		result, err := action(ctx, "instance-id")
+		if err != nil || result == nil || result.ID == "" {
+			t.Errorf("Unexpected result %v, %v", result, err)
+		}
+	}
+	if _, err := api.Instances.Rebuild(ctx, "instance-id", &InstanceRebuildRequest{ImageSlug: "ubuntu-22.04-x64"}); err != nil {
+		t.Errorf("Unexpected error %v", err)
+	}
+
+	expected := []map[string]string{
+		{"id": "instance-id", "type": "power_on"},
+		{"id": "instance-id", "type": "reboot"},
+		{"id": "instance-id", "type": "hard_reboot"},
+		{"id": "instance-id", "type": "reset"},
+		{"id": "instance-id", "type": "rebuild", "image_slug": "ubuntu-22.04-x64"},
+	}
+	if !reflect.DeepEqual(expected, requests) {
+		t.Errorf("Unexpected requests %v", requests)
+	}
+}
+
+func TestInstance_RebuildWithoutImage(t *testing.T) {
+	api, _ := newFakeAPIClient("/api/v1/instances/instance-id/actions", &fakeServerResponse{responseBody: actionGetResponse})
+	if _, err := api.Instances.Rebuild(context.Background(), "instance-id", &InstanceRebuildRequest{}); err == nil {
+		t.Errorf("Expected error")
+	}
+}
+
+func newFakeBackupsServer(t *testing.T, requests *[]string) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/backups/backup-id", func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(backupGetResponse))
+	})
+	mux.HandleFunc("GET /api/v1/instances/instance-id", func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(getResponse))
+	})
+	mux.HandleFunc("GET /api/v1/plans/public", func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(instancePlansListResponse))
+	})
+	handler := func(rw http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		*requests = append(*requests, strings.TrimSpace(string(body)))
+		rw.WriteHeader(http.StatusAccepted)
+		if strings.HasSuffix(req.URL.Path, "/actions") {
+			_, _ = rw.Write([]byte(actionGetResponse))
+			return
+		}
+		_, _ = rw.Write([]byte(getResponse))
+	}
+	mux.HandleFunc("POST /api/v1/instances/instance-id/actions", handler)
+	mux.HandleFunc("POST /api/v1/instances", handler)
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestInstance_RestoreFromBackup(t *testing.T) {
+	var requests []string
+	api, _ := NewAPIClient(newFakeClientOptions(newFakeBackupsServer(t, &requests)))
+
+	action, err := api.Instances.RestoreFromBackup(context.Background(), "instance-id", "backup-id")
+	if err != nil || action == nil {
+		t.Fatalf("Unexpected result %v, %v", action, err)
+	}
+	expected := []string{`{"id":"instance-id","type":"restore","backup_id":"backup-id"}`}
+	if !reflect.DeepEqual(expected, requests) {
+		t.Errorf("Unexpected requests %v", requests)
+	}
+}
+
+func TestInstance_CreateFromBackup(t *testing.T) {
+	var requests []string
+	api, _ := NewAPIClient(newFakeClientOptions(newFakeBackupsServer(t, &requests)))
+	ctx := context.Background()
+
+	_, err := api.Instances.CreateFromBackup(ctx, "backup-id", &InstanceCreateRequest{Name: "web", PlanID: 380171663})
+	if !errors.Is(err, ErrDiskTooSmall) {
+		t.Errorf("Unexpected error %v", err)
+	}
+	_, err = api.Instances.CreateFromBackup(ctx, "backup-id", &InstanceCreateRequest{Name: "web", ProductID: "380171663"})
+	if !errors.Is(err, ErrDiskTooSmall) {
+		t.Errorf("Unexpected error of product id %v", err)
+	}
+	_, err = api.Instances.CreateFromBackup(ctx, "backup-id", &InstanceCreateRequest{Name: "web", PlanSlug: "unknown"})
+	if !errors.Is(err, ErrResourceNotFound) {
+		t.Errorf("Unexpected error %v", err)
+	}
+	if _, err = api.Instances.CreateFromBackup(ctx, "backup-id", nil); err == nil {
+		t.Errorf("Expected error of nil request")
+	}
+	if len(requests) != 0 {
+		t.Fatalf("Instance must not be created: %v", requests)
+	}
+
+	request := &InstanceCreateRequest{Name: "web", ImageSlug: "ubuntu", PlanSlug: "dc-1-2", Disk: 40}
+	if _, err := api.Instances.CreateFromBackup(ctx, "backup-id", request); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if len(requests) != 1 || !strings.Contains(requests[0], `"image_id":"437696c6-6b56-466d-92b2-6f5231124fbb"`) || strings.Contains(requests[0], "image_slug") {
+		t.Errorf("Unexpected requests %v", requests)
+	}
+	if request.ImageSlug != "ubuntu" {
+		t.Errorf("Request must not be modified")
+	}
+}
+
+func TestInstance_CreateFromBackupUnknownPlanDisk(t *testing.T) {
+	var requests []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/backups/backup-id", func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(backupGetResponse))
+	})
+	mux.HandleFunc("GET /api/v1/plans/public", func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(`{"data": [{"id": 380171663, "name": "legacy", "type": "VPS"}]}`))
+	})
+	mux.HandleFunc("POST /api/v1/instances", func(rw http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		requests = append(requests, strings.TrimSpace(string(body)))
+		rw.WriteHeader(http.StatusAccepted)
+		_, _ = rw.Write([]byte(getResponse))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	api, _ := NewAPIClient(newFakeClientOptions(server))
+
+	request := &InstanceCreateRequest{Name: "web", PlanID: 380171663}
+	if _, err := api.Instances.CreateFromBackup(context.Background(), "backup-id", request); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if len(requests) != 1 {
+		t.Errorf("Unexpected requests %v", requests)
+	}
+}
+
+func TestInstance_DestroyWithOptions(t *testing.T) {
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		bodies = append(bodies, strings.TrimSpace(string(body)))
+		rw.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+	api, _ := NewAPIClient(newFakeClientOptions(server))
+
+	ctx := context.Background()
+	if err := api.Instances.Destroy(ctx, "instance-id"); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if err := api.Instances.DestroyWithOptions(ctx, "instance-id", nil); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	if err := api.Instances.DestroyWithOptions(ctx, "instance-id", &InstanceDestroyOptions{BackupsStrategy: BackupsStrategyKeep}); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	expected := []string{`{"backups_strategy":"destroy"}`, `{"backups_strategy":"keep"}`, `{"backups_strategy":"keep"}`}
+	if !reflect.DeepEqual(expected, bodies) {
+		t.Errorf("Unexpected requests %v", bodies)
+	}
+}
+
+func TestInstance_TeardownLocked(t *testing.T) {
+	var destroyed bool
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/instances/instance-id", func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(`{"instance": {"id": "instance-id", "locked": true}}`))
+	})
+	mux.HandleFunc("DELETE /api/v1/instances/instance-id", func(rw http.ResponseWriter, req *http.Request) {
+		destroyed = true
+		rw.WriteHeader(http.StatusAccepted)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	api, _ := NewAPIClient(newFakeClientOptions(server))
+
+	err := api.Instances.Teardown(context.Background(), "instance-id", &InstanceTeardownOptions{DetachVolumes: true})
+	if !errors.Is(err, ErrInstanceLocked) || destroyed {
+		t.Errorf("Unexpected error %v", err)
+	}
+}
+
+func TestInstance_TeardownKeepsBackups(t *testing.T) {
+	var body string
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/instances/instance-id", func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(`{"instance": {"id": "instance-id"}}`))
+	})
+	mux.HandleFunc("DELETE /api/v1/instances/instance-id", func(rw http.ResponseWriter, req *http.Request) {
+		data, _ := io.ReadAll(req.Body)
+		body = strings.TrimSpace(string(data))
+		rw.WriteHeader(http.StatusAccepted)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	api, _ := NewAPIClient(newFakeClientOptions(server))
+
+	if err := api.Instances.Teardown(context.Background(), "instance-id", nil); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if body != `{"backups_strategy":"keep"}` {
+		t.Errorf("Unexpected destroy request %s", body)
+	}
+}
+
+func TestInstance_TeardownWaitsForUnassignment(t *testing.T) {
+	var requests []string
+	var polls int
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/instances/instance-id", func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(`{"instance": {"id": "instance-id", "instance_ip_addresses": [{"id": "assignment-id", "ip_address_id": "ip-id"}]}}`))
+	})
+	mux.HandleFunc("DELETE /api/v1/instance_ip_addresses/assignment-id", func(rw http.ResponseWriter, req *http.Request) {
+		requests = append(requests, "unassign")
+		rw.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("GET /api/v1/ip_addresses", func(rw http.ResponseWriter, req *http.Request) {
+		polls++
+		if polls == 1 {
+			_, _ = rw.Write([]byte(`{"ip_addresses": [{"id": "ip-id", "instance_ids": ["instance-id"]}]}`))
+			return
+		}
+		_, _ = rw.Write([]byte(`{"ip_addresses": [{"id": "ip-id"}]}`))
+	})
+	mux.HandleFunc("DELETE /api/v1/ip_addresses/ip-id", func(rw http.ResponseWriter, req *http.Request) {
+		requests = append(requests, fmt.Sprintf("release after %d polls", polls))
+		rw.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("DELETE /api/v1/instances/instance-id", func(rw http.ResponseWriter, req *http.Request) {
+		requests = append(requests, "destroy")
+		rw.WriteHeader(http.StatusAccepted)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	api, _ := NewAPIClient(newFakeClientOptions(server))
+
+	options := &InstanceTeardownOptions{ReleaseIPAddresses: true, WaitOptions: &WaitOptions{PollInterval: time.Millisecond}}
+	if err := api.Instances.Teardown(context.Background(), "instance-id", options); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	expected := []string{"unassign", "release after 2 polls", "destroy"}
+	if !reflect.DeepEqual(expected, requests) {
+		t.Errorf("Unexpected requests %v", requests)
+	}
+}
+
+func TestInstance_AddTags(t *testing.T) {
+	var requests []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/instances/instance-id", func(rw http.ResponseWriter, req *http.Request) {
+		requests = append(requests, req.Method+" "+req.Header.Get("X-Request-Source"))
+		_, _ = rw.Write([]byte(`{"instance": {"id": "instance-id", "tags": ["web"]}}`))
+	})
+	mux.HandleFunc("PATCH /api/v1/instances/instance-id", func(rw http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		requests = append(requests, req.Method+" "+req.Header.Get("X-Request-Source")+" "+strings.TrimSpace(string(body)))
+		_, _ = rw.Write([]byte(`{"instance": {"id": "instance-id", "tags": ["web", "prod"]}}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	api, _ := NewAPIClient(newFakeClientOptions(server))
+
+	instance, err := api.Instances.AddTags(context.Background(), "instance-id", []string{"web", "prod"}, WithHeader("X-Request-Source", "test"))
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	expected := []string{"GET test", `PATCH test {"tags":["web","prod"]}`}
+	if !reflect.DeepEqual(expected, requests) {
+		t.Errorf("Unexpected requests %v", requests)
+	}
+	if !reflect.DeepEqual(instance.Tags, []string{"web", "prod"}) {
+		t.Errorf("Unexpected tags %v", instance.Tags)
+	}
+}
+
+func TestInstance_Update(t *testing.T) {
+	var body string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPatch || req.URL.Path != "/api/v1/instances/instance-id" {
+			t.Errorf("Unexpected request %s %s", req.Method, req.URL.Path)
+		}
+		data, _ := io.ReadAll(req.Body)
+		body = strings.TrimSpace(string(data))
+		_, _ = rw.Write([]byte(getResponse))
+	}))
+	defer server.Close()
+	api, _ := NewAPIClient(newFakeClientOptions(server))
+
+	request := &InstanceUpdateRequest{Tags: &[]string{}, SnapshotBySchedule: Ptr(false)}
+	instance, err := api.Instances.Update(context.Background(), "instance-id", request)
+	if err != nil || instance == nil {
+		t.Fatalf("Unexpected result %v, %v", instance, err)
+	}
+	if body != `{"tags":[],"snapshot_by_schedule":false}` {
+		t.Errorf("Unexpected body %s", body)
+	}
+}
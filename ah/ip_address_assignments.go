@@ -19,25 +19,28 @@ package ah
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 )
 
 // IPAddressAssignment object
 type IPAddressAssignment struct {
-	ID          string `json:"id,omitempty"`
-	InstanceID  string `json:"instance_id,omitempty"`
-	IPAddressID string `json:"ip_address_id,omitempty"`
-	State       string `json:"state,omitempty"`
-	CreatedAt   string `json:"created_at,omitempty"`
-	UpdatedAt   string `json:"updated_at,omitempty"`
+	ID          string    `json:"id,omitempty"`
+	InstanceID  string    `json:"instance_id,omitempty"`
+	IPAddressID string    `json:"ip_address_id,omitempty"`
+	State       string    `json:"state,omitempty"`
+	CreatedAt   Timestamp `json:"created_at,omitempty"`
+	UpdatedAt   Timestamp `json:"updated_at,omitempty"`
 }
 
 // IPAddressAssignmentsAPI is an interface for ip address assignments.
 type IPAddressAssignmentsAPI interface {
-	Create(context.Context, *IPAddressAssignmentCreateRequest) (*IPAddressAssignment, error)
-	Get(context.Context, string) (*IPAddressAssignment, error)
-	List(context.Context, *ListOptions) ([]IPAddressAssignment, error)
-	Delete(context.Context, string) error
+	Create(context.Context, *IPAddressAssignmentCreateRequest, ...RequestOption) (*IPAddressAssignment, error)
+	Get(context.Context, string, ...RequestOption) (*IPAddressAssignment, error)
+	List(context.Context, *ListOptions, ...RequestOption) ([]IPAddressAssignment, error)
+	ListPage(context.Context, *ListOptions, ...RequestOption) ([]IPAddressAssignment, *Meta, error)
+	All(context.Context, *ListOptions, ...RequestOption) iter.Seq2[IPAddressAssignment, error]
+	Delete(context.Context, string, ...RequestOption) error
 }
 
 // IPAddressAssignmentsService implements IPAddressAssignmentsAPI interface.
@@ -46,21 +49,40 @@ type IPAddressAssignmentsService struct {
 }
 
 type ipAddressAssignmentsRoot struct {
+	Meta                *Meta                 `json:"meta"`
 	InstanceIPAddresses []IPAddressAssignment `json:"instance_ip_addresses"`
 }
 
 // List returns all available ip address assignments
-func (ips *IPAddressAssignmentsService) List(ctx context.Context, options *ListOptions) ([]IPAddressAssignment, error) {
+//
+// Deprecated: Please use ListPage instead.
+func (ips *IPAddressAssignmentsService) List(ctx context.Context, options *ListOptions, opts ...RequestOption) ([]IPAddressAssignment, error) {
+	ctx = withOperation(ctx, "IPAddressAssignments.List")
+	assignments, _, err := ips.listPage(ctx, options, opts...)
+	return assignments, err
+}
+
+// ListPage returns a page of ip address assignments with pagination metadata
+func (ips *IPAddressAssignmentsService) ListPage(ctx context.Context, options *ListOptions, opts ...RequestOption) ([]IPAddressAssignment, *Meta, error) {
+	return ips.listPage(withOperation(ctx, "IPAddressAssignments.ListPage"), options, opts...)
+}
+
+// listPage is ListPage without the operation name, so that wrappers keep their own
+func (ips *IPAddressAssignmentsService) listPage(ctx context.Context, options *ListOptions, opts ...RequestOption) ([]IPAddressAssignment, *Meta, error) {
 	path := "api/v1/instance_ip_addresses"
 
 	var ipsRoot ipAddressAssignmentsRoot
 
-	if err := ips.client.list(ctx, path, options, &ipsRoot); err != nil {
-		return nil, err
+	if err := ips.client.list(ctx, path, options, &ipsRoot, opts...); err != nil {
+		return nil, nil, err
 	}
 
-	return ipsRoot.InstanceIPAddresses, nil
+	return ipsRoot.InstanceIPAddresses, ipsRoot.Meta, nil
+}
 
+// All returns an iterator over all ip address assignments fetching pages lazily
+func (ips *IPAddressAssignmentsService) All(ctx context.Context, options *ListOptions, opts ...RequestOption) iter.Seq2[IPAddressAssignment, error] {
+	return paginate(withOperation(ctx, "IPAddressAssignments.All"), options, ips.listPage, opts...)
 }
 
 // IPAddressAssignmentCreateRequest represents a request to assign an ip address to isntance.
@@ -74,14 +96,15 @@ type ipAddressAssignmentRoot struct {
 }
 
 // Create ip address assignment
-func (ips *IPAddressAssignmentsService) Create(ctx context.Context, createRequest *IPAddressAssignmentCreateRequest) (*IPAddressAssignment, error) {
+func (ips *IPAddressAssignmentsService) Create(ctx context.Context, createRequest *IPAddressAssignmentCreateRequest, opts ...RequestOption) (*IPAddressAssignment, error) {
+	ctx = withOperation(ctx, "IPAddressAssignments.Create")
 
 	type request struct {
 		InstanceIPAddress *IPAddressAssignmentCreateRequest `json:"instance_ip_address"`
 	}
 
 	path := "api/v1/instance_ip_addresses"
-	req, err := ips.client.newRequest(http.MethodPost, path, &request{createRequest})
+	req, err := ips.client.newRequest(http.MethodPost, path, &request{createRequest}, opts...)
 
 	if err != nil {
 		return nil, err
@@ -96,9 +119,10 @@ func (ips *IPAddressAssignmentsService) Create(ctx context.Context, createReques
 }
 
 // Get an ip address assignment
-func (ips *IPAddressAssignmentsService) Get(ctx context.Context, IPAddressAssignmentID string) (*IPAddressAssignment, error) {
+func (ips *IPAddressAssignmentsService) Get(ctx context.Context, IPAddressAssignmentID string, opts ...RequestOption) (*IPAddressAssignment, error) {
+	ctx = withOperation(ctx, "IPAddressAssignments.Get")
 	path := fmt.Sprintf("api/v1/instance_ip_addresses/%s", IPAddressAssignmentID)
-	req, err := ips.client.newRequest(http.MethodGet, path, nil)
+	req, err := ips.client.newRequest(http.MethodGet, path, nil, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -114,9 +138,10 @@ func (ips *IPAddressAssignmentsService) Get(ctx context.Context, IPAddressAssign
 }
 
 // Delete assignment
-func (ips *IPAddressAssignmentsService) Delete(ctx context.Context, isntanceIPAssignmentID string) error {
+func (ips *IPAddressAssignmentsService) Delete(ctx context.Context, isntanceIPAssignmentID string, opts ...RequestOption) error {
+	ctx = withOperation(ctx, "IPAddressAssignments.Delete")
 	path := fmt.Sprintf("api/v1/instance_ip_addresses/%s", isntanceIPAssignmentID)
-	req, err := ips.client.newRequest(http.MethodDelete, path, nil)
+	req, err := ips.client.newRequest(http.MethodDelete, path, nil, opts...)
 
 	if err != nil {
 		return err
@@ -19,24 +19,26 @@ package ah
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 )
 
 // IPAddress object
 type IPAddress struct {
-	Address                      string   `json:"address,omitempty"`
-	Type                         string   `json:"address_type,omitempty"`
-	CreatedAt                    string   `json:"created_at,omitempty"`
-	DatacenterFullName           string   `json:"datacenter_full_name,omitempty"`
-	ID                           string   `json:"id,omitempty"`
-	ReverseDNS                   string   `json:"reverse_dns,omitempty"`
-	UpdatedAt                    string   `json:"updated_at,omitempty"`
-	InstanceIDs                  []string `json:"instance_ids,omitempty"`
-	DeleteProtection             bool     `json:"delete_protection,omitempty"`
-	NetworkUsedForPrivateCluster bool     `json:"network_used_for_private_cluster,omitempty"`
+	Address                      string    `json:"address,omitempty"`
+	Type                         string    `json:"address_type,omitempty"`
+	CreatedAt                    Timestamp `json:"created_at,omitempty"`
+	DatacenterFullName           string    `json:"datacenter_full_name,omitempty"`
+	ID                           string    `json:"id,omitempty"`
+	ReverseDNS                   string    `json:"reverse_dns,omitempty"`
+	UpdatedAt                    Timestamp `json:"updated_at,omitempty"`
+	InstanceIDs                  []string  `json:"instance_ids,omitempty"`
+	DeleteProtection             bool      `json:"delete_protection,omitempty"`
+	NetworkUsedForPrivateCluster bool      `json:"network_used_for_private_cluster,omitempty"`
 }
 
 type ipAddressesRoot struct {
+	Meta        *Meta       `json:"meta"`
 	IPAddresses []IPAddress `json:"ip_addresses"`
 }
 
@@ -46,11 +48,13 @@ type ipAddressRoot struct {
 
 // IPAddressesAPI is an interface for ip addresses.
 type IPAddressesAPI interface {
-	List(context.Context, *ListOptions) ([]IPAddress, error)
-	Create(context.Context, *IPAddressCreateRequest) (*IPAddress, error)
-	Get(context.Context, string) (*IPAddress, error)
-	Delete(context.Context, string) error
-	Update(context.Context, string, *IPAddressUpdateRequest) (*IPAddress, error)
+	List(context.Context, *ListOptions, ...RequestOption) ([]IPAddress, error)
+	ListPage(context.Context, *ListOptions, ...RequestOption) ([]IPAddress, *Meta, error)
+	All(context.Context, *ListOptions, ...RequestOption) iter.Seq2[IPAddress, error]
+	Create(context.Context, *IPAddressCreateRequest, ...RequestOption) (*IPAddress, error)
+	Get(context.Context, string, ...RequestOption) (*IPAddress, error)
+	Delete(context.Context, string, ...RequestOption) error
+	Update(context.Context, string, *IPAddressUpdateRequest, ...RequestOption) (*IPAddress, error)
 }
 
 // IPAddressesService implements IPAddressesAPI interface.
@@ -59,14 +63,33 @@ type IPAddressesService struct {
 }
 
 // List returns all available ip addresses
-func (ips *IPAddressesService) List(ctx context.Context, options *ListOptions) ([]IPAddress, error) {
+//
+// Deprecated: Please use ListPage instead.
+func (ips *IPAddressesService) List(ctx context.Context, options *ListOptions, opts ...RequestOption) ([]IPAddress, error) {
+	ctx = withOperation(ctx, "IPAddresses.List")
+	ipAddresses, _, err := ips.listPage(ctx, options, opts...)
+	return ipAddresses, err
+}
+
+// ListPage returns a page of ip addresses with pagination metadata
+func (ips *IPAddressesService) ListPage(ctx context.Context, options *ListOptions, opts ...RequestOption) ([]IPAddress, *Meta, error) {
+	return ips.listPage(withOperation(ctx, "IPAddresses.ListPage"), options, opts...)
+}
+
+// listPage is ListPage without the operation name, so that wrappers keep their own
+func (ips *IPAddressesService) listPage(ctx context.Context, options *ListOptions, opts ...RequestOption) ([]IPAddress, *Meta, error) {
 	path := "api/v1/ip_addresses"
 	var ipsRoot ipAddressesRoot
 
-	if err := ips.client.list(ctx, path, options, &ipsRoot); err != nil {
-		return nil, err
+	if err := ips.client.list(ctx, path, options, &ipsRoot, opts...); err != nil {
+		return nil, nil, err
 	}
-	return ipsRoot.IPAddresses, nil
+	return ipsRoot.IPAddresses, ipsRoot.Meta, nil
+}
+
+// All returns an iterator over all ip addresses fetching pages lazily
+func (ips *IPAddressesService) All(ctx context.Context, options *ListOptions, opts ...RequestOption) iter.Seq2[IPAddress, error] {
+	return paginate(withOperation(ctx, "IPAddresses.All"), options, ips.listPage, opts...)
 }
 
 // IPAddressCreateRequest represents a request to create an ip address.
@@ -80,12 +103,13 @@ type IPAddressCreateRequest struct {
 }
 
 // Create ip address
-func (ips *IPAddressesService) Create(ctx context.Context, createRequest *IPAddressCreateRequest) (*IPAddress, error) {
+func (ips *IPAddressesService) Create(ctx context.Context, createRequest *IPAddressCreateRequest, opts ...RequestOption) (*IPAddress, error) {
+	ctx = withOperation(ctx, "IPAddresses.Create")
 
 	type request struct {
 		IPAddress *IPAddressCreateRequest `json:"ip_address"`
 	}
-	req, err := ips.client.newRequest(http.MethodPost, "api/v1/ip_addresses", &request{createRequest})
+	req, err := ips.client.newRequest(http.MethodPost, "api/v1/ip_addresses", &request{createRequest}, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -100,7 +124,8 @@ func (ips *IPAddressesService) Create(ctx context.Context, createRequest *IPAddr
 }
 
 // Get ip address
-func (ips *IPAddressesService) Get(ctx context.Context, ipAddressID string) (*IPAddress, error) {
+func (ips *IPAddressesService) Get(ctx context.Context, ipAddressID string, opts ...RequestOption) (*IPAddress, error) {
+	ctx = withOperation(ctx, "IPAddresses.Get")
 	options := &ListOptions{
 		Filters: []FilterInterface{
 			&EqFilter{
@@ -110,7 +135,7 @@ func (ips *IPAddressesService) Get(ctx context.Context, ipAddressID string) (*IP
 		},
 	}
 
-	ipAddresses, err := ips.List(ctx, options)
+	ipAddresses, _, err := ips.listPage(ctx, options, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -129,9 +154,10 @@ type IPAddressUpdateRequest struct {
 }
 
 // Update ip address resource
-func (ips *IPAddressesService) Update(ctx context.Context, ipAddressID string, request *IPAddressUpdateRequest) (*IPAddress, error) {
+func (ips *IPAddressesService) Update(ctx context.Context, ipAddressID string, request *IPAddressUpdateRequest, opts ...RequestOption) (*IPAddress, error) {
+	ctx = withOperation(ctx, "IPAddresses.Update")
 	path := fmt.Sprintf("api/v1/ip_addresses/%s", ipAddressID)
-	req, err := ips.client.newRequest(http.MethodPatch, path, request)
+	req, err := ips.client.newRequest(http.MethodPatch, path, request, opts...)
 
 	if err != nil {
 		return nil, err
@@ -146,9 +172,10 @@ func (ips *IPAddressesService) Update(ctx context.Context, ipAddressID string, r
 }
 
 // Delete ip address
-func (ips *IPAddressesService) Delete(ctx context.Context, ipAddressID string) error {
+func (ips *IPAddressesService) Delete(ctx context.Context, ipAddressID string, opts ...RequestOption) error {
+	ctx = withOperation(ctx, "IPAddresses.Delete")
 	path := fmt.Sprintf("api/v1/ip_addresses/%s", ipAddressID)
-	req, err := ips.client.newRequest(http.MethodDelete, path, nil)
+	req, err := ips.client.newRequest(http.MethodDelete, path, nil, opts...)
 	if err != nil {
 		return err
 	}
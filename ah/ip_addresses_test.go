@@ -237,3 +237,23 @@ func TestIPAddresses_Delete(t *testing.T) {
 		t.Errorf("Unexpected error: %v", err)
 	}
 }
+
+func TestIPAddresses_ListPage(t *testing.T) {
+	response := fmt.Sprintf(`{"ip_addresses": [%s], "meta": {"page": 2, "per_page": 1, "total": 3}}`, ipAddressResponse)
+	api, _ := newFakeAPIClient("/api/v1/ip_addresses", &fakeServerResponse{responseBody: response})
+
+	ctx := context.Background()
+	options := &ListOptions{Meta: &ListMetaOptions{Page: 2, PerPage: 1}}
+	ipAddresses, meta, err := api.IPAddresses.ListPage(ctx, options)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(ipAddresses) != 1 {
+		t.Errorf("Expected 1 ip address, got %v", ipAddresses)
+	}
+	expectedMeta := &Meta{Page: 2, PerPage: 1, Total: 3}
+	if !reflect.DeepEqual(expectedMeta, meta) {
+		t.Errorf("unexpected meta, expected %v. got: %v", expectedMeta, meta)
+	}
+}
@@ -19,9 +19,18 @@ package ah
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 )
 
+// Kubernetes cluster states
+const (
+	// KubernetesClusterStateActive is the state of a ready to use kubernetes cluster
+	KubernetesClusterStateActive = "active"
+	// KubernetesClusterStateError is the state of a broken kubernetes cluster
+	KubernetesClusterStateError = "error"
+)
+
 // KubernetesCluster object
 type KubernetesCluster struct {
 	ID                 string                 `json:"id,omitempty"`
@@ -31,7 +40,7 @@ type KubernetesCluster struct {
 	DatacenterSlug     string                 `json:"datacenter_slug,omitempty"`
 	State              string                 `json:"state,omitempty"`
 	Number             string                 `json:"number"`
-	CreatedAt          string                 `json:"created_at"`
+	CreatedAt          Timestamp              `json:"created_at"`
 	AccountID          string                 `json:"account_id"`
 	PrivateNetworkID   string                 `json:"private_network_id"`
 	PrivateNetworkName string                 `json:"private_network_name,omitempty"`
@@ -46,19 +55,23 @@ type KubernetesClusterConfig struct {
 
 // KubernetesClustersAPI is an interface for cluster API.
 type KubernetesClustersAPI interface {
-	Get(context.Context, string) (*KubernetesCluster, error)
-	List(context.Context, *ListOptions) ([]KubernetesCluster, error)
-	Create(context.Context, *KubernetesClusterCreateRequest) (*KubernetesCluster, error)
-	Update(context.Context, string, *KubernetesClusterUpdateRequest) error
-	GetConfig(context.Context, string) (string, error)
-	Delete(context.Context, string) error
-	GetKubernetesClustersVersions(context.Context) ([]string, error)
-	GetWorkerPool(context.Context, string, string) (*KubernetesWorkerPool, error)
-	ListWorkerPools(context.Context, *ListOptions, string) ([]KubernetesWorkerPool, error)
-	CreateWorkerPool(context.Context, string, *CreateKubernetesWorkerPoolRequest) (*KubernetesWorkerPool, error)
-	UpdateWorkerPool(context.Context, string, string, *UpdateKubernetesWorkerPoolRequest) error
-	DeleteWorkerPool(context.Context, string, string, bool) error
-	DeleteWorker(context.Context, string, string, string, *ClusterDeleteWorkerRequest) error
+	Get(context.Context, string, ...RequestOption) (*KubernetesCluster, error)
+	List(context.Context, *ListOptions, ...RequestOption) ([]KubernetesCluster, error)
+	ListPage(context.Context, *ListOptions, ...RequestOption) ([]KubernetesCluster, *Meta, error)
+	All(context.Context, *ListOptions, ...RequestOption) iter.Seq2[KubernetesCluster, error]
+	Create(context.Context, *KubernetesClusterCreateRequest, ...RequestOption) (*KubernetesCluster, error)
+	Update(context.Context, string, *KubernetesClusterUpdateRequest, ...RequestOption) error
+	GetConfig(context.Context, string, ...RequestOption) (string, error)
+	Delete(context.Context, string, ...RequestOption) error
+	GetKubernetesClustersVersions(context.Context, ...RequestOption) ([]string, error)
+	GetWorkerPool(context.Context, string, string, ...RequestOption) (*KubernetesWorkerPool, error)
+	ListWorkerPools(context.Context, *ListOptions, string, ...RequestOption) ([]KubernetesWorkerPool, error)
+	ListWorkerPoolsPage(context.Context, *ListOptions, string, ...RequestOption) ([]KubernetesWorkerPool, *Meta, error)
+	AllWorkerPools(context.Context, *ListOptions, string, ...RequestOption) iter.Seq2[KubernetesWorkerPool, error]
+	CreateWorkerPool(context.Context, string, *CreateKubernetesWorkerPoolRequest, ...RequestOption) (*KubernetesWorkerPool, error)
+	UpdateWorkerPool(context.Context, string, string, *UpdateKubernetesWorkerPoolRequest, ...RequestOption) error
+	DeleteWorkerPool(context.Context, string, string, bool, ...RequestOption) error
+	DeleteWorker(context.Context, string, string, string, *ClusterDeleteWorkerRequest, ...RequestOption) error
 }
 
 // KubernetesClustersService implements ClustersAPI interface.
@@ -71,6 +84,7 @@ type KubernetesClusterRoot struct {
 }
 
 type KubernetesClustersRoot struct {
+	Meta               *Meta               `json:"meta,omitempty"`
 	KubernetesClusters []KubernetesCluster `json:"clusters,omitempty"`
 }
 
@@ -88,8 +102,9 @@ type KubernetesClusterUpdateRequest struct {
 }
 
 // Create kubernetes cluster
-func (kcs *KubernetesClustersService) Create(ctx context.Context, createRequest *KubernetesClusterCreateRequest) (*KubernetesCluster, error) {
-	req, err := kcs.client.newRequest(http.MethodPost, "api/v2/kubernetes/clusters", createRequest)
+func (kcs *KubernetesClustersService) Create(ctx context.Context, createRequest *KubernetesClusterCreateRequest, opts ...RequestOption) (*KubernetesCluster, error) {
+	ctx = withOperation(ctx, "KubernetesClusters.Create")
+	req, err := kcs.client.newRequest(http.MethodPost, "api/v2/kubernetes/clusters", createRequest, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -103,10 +118,11 @@ func (kcs *KubernetesClustersService) Create(ctx context.Context, createRequest
 }
 
 // Get kubernetes cluster
-func (kcs *KubernetesClustersService) Get(ctx context.Context, clusterID string) (*KubernetesCluster, error) {
+func (kcs *KubernetesClustersService) Get(ctx context.Context, clusterID string, opts ...RequestOption) (*KubernetesCluster, error) {
+	ctx = withOperation(ctx, "KubernetesClusters.Get")
 	path := fmt.Sprintf("api/v2/kubernetes/clusters/%s", clusterID)
 
-	req, err := kcs.client.newRequest(http.MethodGet, path, nil)
+	req, err := kcs.client.newRequest(http.MethodGet, path, nil, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -121,22 +137,43 @@ func (kcs *KubernetesClustersService) Get(ctx context.Context, clusterID string)
 }
 
 // List returns list of kubernetes clusters
-func (kcs *KubernetesClustersService) List(ctx context.Context, options *ListOptions) ([]KubernetesCluster, error) {
+//
+// Deprecated: Please use ListPage instead.
+func (kcs *KubernetesClustersService) List(ctx context.Context, options *ListOptions, opts ...RequestOption) ([]KubernetesCluster, error) {
+	ctx = withOperation(ctx, "KubernetesClusters.List")
+	clusters, _, err := kcs.listPage(ctx, options, opts...)
+	return clusters, err
+}
+
+// ListPage returns a page of kubernetes clusters with pagination metadata
+func (kcs *KubernetesClustersService) ListPage(ctx context.Context, options *ListOptions, opts ...RequestOption) ([]KubernetesCluster, *Meta, error) {
+	return kcs.listPage(withOperation(ctx, "KubernetesClusters.ListPage"), options, opts...)
+}
+
+// listPage is ListPage without the operation name, so that wrappers keep their own
+func (kcs *KubernetesClustersService) listPage(ctx context.Context, options *ListOptions, opts ...RequestOption) ([]KubernetesCluster, *Meta, error) {
 	path := "/api/v2/kubernetes/clusters"
 
 	var kubernetesClustersRoot KubernetesClustersRoot
-	if err := kcs.client.list(ctx, path, options, &kubernetesClustersRoot); err != nil {
-		return nil, err
+	if err := kcs.client.list(ctx, path, options, &kubernetesClustersRoot, opts...); err != nil {
+		return nil, nil, err
 	}
 
-	return kubernetesClustersRoot.KubernetesClusters, nil
+	clusters := kubernetesClustersRoot.KubernetesClusters
+	return clusters, kubernetesClustersRoot.Meta, nil
+}
+
+// All returns an iterator over all kubernetes clusters fetching pages lazily
+func (kcs *KubernetesClustersService) All(ctx context.Context, options *ListOptions, opts ...RequestOption) iter.Seq2[KubernetesCluster, error] {
+	return paginate(withOperation(ctx, "KubernetesClusters.All"), options, kcs.listPage, opts...)
 }
 
 // Update kubernetes cluster. Returns error
-func (kcs *KubernetesClustersService) Update(ctx context.Context, clusterId string, request *KubernetesClusterUpdateRequest) error {
+func (kcs *KubernetesClustersService) Update(ctx context.Context, clusterId string, request *KubernetesClusterUpdateRequest, opts ...RequestOption) error {
+	ctx = withOperation(ctx, "KubernetesClusters.Update")
 	path := fmt.Sprintf("api/v2/kubernetes/clusters/%s", clusterId)
 
-	req, err := kcs.client.newRequest(http.MethodPatch, path, request)
+	req, err := kcs.client.newRequest(http.MethodPatch, path, request, opts...)
 	if err != nil {
 		return err
 	}
@@ -150,10 +187,11 @@ func (kcs *KubernetesClustersService) Update(ctx context.Context, clusterId stri
 }
 
 // Delete kubernetes cluster. Returns error
-func (kcs *KubernetesClustersService) Delete(ctx context.Context, clusterId string) error {
+func (kcs *KubernetesClustersService) Delete(ctx context.Context, clusterId string, opts ...RequestOption) error {
+	ctx = withOperation(ctx, "KubernetesClusters.Delete")
 	path := fmt.Sprintf("api/v2/kubernetes/clusters/%s", clusterId)
 
-	req, err := kcs.client.newRequest(http.MethodDelete, path, nil)
+	req, err := kcs.client.newRequest(http.MethodDelete, path, nil, opts...)
 	if err != nil {
 		return err
 	}
@@ -167,10 +205,11 @@ func (kcs *KubernetesClustersService) Delete(ctx context.Context, clusterId stri
 }
 
 // GetKubernetesClustersVersions returns kubernetes version
-func (kcs *KubernetesClustersService) GetKubernetesClustersVersions(ctx context.Context) ([]string, error) {
+func (kcs *KubernetesClustersService) GetKubernetesClustersVersions(ctx context.Context, opts ...RequestOption) ([]string, error) {
+	ctx = withOperation(ctx, "KubernetesClusters.GetKubernetesClustersVersions")
 	path := "/api/v2/kubernetes/clusters/versions"
 
-	req, err := kcs.client.newRequest(http.MethodGet, path, nil)
+	req, err := kcs.client.newRequest(http.MethodGet, path, nil, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -186,10 +225,11 @@ func (kcs *KubernetesClustersService) GetKubernetesClustersVersions(ctx context.
 }
 
 // GetConfig returns kubernetes cluster config
-func (kcs KubernetesClustersService) GetConfig(ctx context.Context, clusterId string) (string, error) {
+func (kcs KubernetesClustersService) GetConfig(ctx context.Context, clusterId string, opts ...RequestOption) (string, error) {
+	ctx = withOperation(ctx, "KubernetesClusters.GetConfig")
 	path := fmt.Sprintf("/api/v2/kubernetes/clusters/%s/kubeconfig", clusterId)
 
-	req, err := kcs.client.newRequest(http.MethodGet, path, nil)
+	req, err := kcs.client.newRequest(http.MethodGet, path, nil, opts...)
 	if err != nil {
 		return "", err
 	}
@@ -13,7 +13,7 @@ type KubernetesWorker struct {
 	Name             string            `json:"name,omitempty"`
 	State            string            `json:"state,omitempty"`
 	Type             string            `json:"type,omitempty"`
-	CreatedAt        string            `json:"created_at,omitempty"`
+	CreatedAt        Timestamp         `json:"created_at,omitempty"`
 	ExternalIpID     string            `json:"external_ip_id,omitempty"`
 	PrivateNetworkID string            `json:"private_network_id,omitempty"`
 	CloudServerID    string            `json:"cloud_server_id,omitempty"`
@@ -24,9 +24,10 @@ type ClusterDeleteWorkerRequest struct {
 }
 
 // DeleteWorker deletes worker pool
-func (kcs *KubernetesClustersService) DeleteWorker(ctx context.Context, clusterID, workerPoolID, workerID string, request *ClusterDeleteWorkerRequest) error {
+func (kcs *KubernetesClustersService) DeleteWorker(ctx context.Context, clusterID, workerPoolID, workerID string, request *ClusterDeleteWorkerRequest, opts ...RequestOption) error {
+	ctx = withOperation(ctx, "KubernetesClusters.DeleteWorker")
 	path := fmt.Sprintf("api/v2/kubernetes/clusters/%s/worker_pools/%s/workers/%s", clusterID, workerPoolID, workerID)
-	req, err := kcs.client.newRequest(http.MethodDelete, path, nil)
+	req, err := kcs.client.newRequest(http.MethodDelete, path, nil, opts...)
 	if err != nil {
 		return err
 	}
@@ -41,7 +42,6 @@ func (kcs *KubernetesClustersService) DeleteWorker(ctx context.Context, clusterI
 		req.URL.RawQuery = q.Encode()
 
 	}
-	fmt.Println(req.URL.String())
 
 	if _, err := kcs.client.Do(ctx, req, nil); err != nil {
 		return err
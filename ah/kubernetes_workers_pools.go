@@ -3,6 +3,7 @@ package ah
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 )
 
@@ -30,7 +31,7 @@ type KubernetesWorkerPool struct {
 	ID                string             `json:"id,omitempty"`
 	Name              string             `json:"name,omitempty"`
 	Type              string             `json:"type"`
-	CreatedAt         string             `json:"created_at,omitempty"`
+	CreatedAt         Timestamp          `json:"created_at,omitempty"`
 	Workers           []KubernetesWorker `json:"workers,omitempty"`
 	PrivateProperties PrivateProperties  `json:"private_properties,omitempty"`
 	PublicProperties  PublicProperties   `json:"public_properties,omitempty"`
@@ -45,6 +46,7 @@ type KubernetesWorkerPoolRoot struct {
 }
 
 type KubernetesWorkerPoolsRoot struct {
+	Meta                  *Meta                  `json:"meta,omitempty"`
 	KubernetesWorkerPools []KubernetesWorkerPool `json:"worker_pools,omitempty"`
 }
 
@@ -70,9 +72,10 @@ type UpdateKubernetesWorkerPoolRequest struct {
 }
 
 // GetWorkerPool returns worker pool
-func (kcs *KubernetesClustersService) GetWorkerPool(ctx context.Context, clusterId, workerPoolId string) (*KubernetesWorkerPool, error) {
+func (kcs *KubernetesClustersService) GetWorkerPool(ctx context.Context, clusterId, workerPoolId string, opts ...RequestOption) (*KubernetesWorkerPool, error) {
+	ctx = withOperation(ctx, "KubernetesClusters.GetWorkerPool")
 	path := fmt.Sprintf("api/v2/kubernetes/clusters/%s/worker_pools/%s", clusterId, workerPoolId)
-	req, err := kcs.client.newRequest(http.MethodGet, path, nil)
+	req, err := kcs.client.newRequest(http.MethodGet, path, nil, opts...)
 
 	if err != nil {
 		return nil, err
@@ -88,22 +91,44 @@ func (kcs *KubernetesClustersService) GetWorkerPool(ctx context.Context, cluster
 }
 
 // ListWorkerPools returns list of worker pools
-func (kcs *KubernetesClustersService) ListWorkerPools(ctx context.Context, options *ListOptions, clusterId string) ([]KubernetesWorkerPool, error) {
+//
+// Deprecated: Please use ListWorkerPoolsPage instead.
+func (kcs *KubernetesClustersService) ListWorkerPools(ctx context.Context, options *ListOptions, clusterId string, opts ...RequestOption) ([]KubernetesWorkerPool, error) {
+	ctx = withOperation(ctx, "KubernetesClusters.ListWorkerPools")
+	workerPools, _, err := kcs.listWorkerPoolsPage(ctx, options, clusterId, opts...)
+	return workerPools, err
+}
+
+// ListWorkerPoolsPage returns a page of cluster's worker pools with pagination metadata
+func (kcs *KubernetesClustersService) ListWorkerPoolsPage(ctx context.Context, options *ListOptions, clusterId string, opts ...RequestOption) ([]KubernetesWorkerPool, *Meta, error) {
+	return kcs.listWorkerPoolsPage(withOperation(ctx, "KubernetesClusters.ListWorkerPoolsPage"), options, clusterId, opts...)
+}
+
+// listWorkerPoolsPage is ListWorkerPoolsPage without the operation name, so that wrappers keep their own
+func (kcs *KubernetesClustersService) listWorkerPoolsPage(ctx context.Context, options *ListOptions, clusterId string, opts ...RequestOption) ([]KubernetesWorkerPool, *Meta, error) {
 	path := fmt.Sprintf("api/v2/kubernetes/clusters/%s/worker_pools", clusterId)
 
 	var WorkerPoolsRoot KubernetesWorkerPoolsRoot
 
-	if err := kcs.client.list(ctx, path, options, &WorkerPoolsRoot); err != nil {
-		return nil, err
+	if err := kcs.client.list(ctx, path, options, &WorkerPoolsRoot, opts...); err != nil {
+		return nil, nil, err
 	}
 
-	return WorkerPoolsRoot.KubernetesWorkerPools, nil
+	return WorkerPoolsRoot.KubernetesWorkerPools, WorkerPoolsRoot.Meta, nil
+}
+
+// AllWorkerPools returns an iterator over all cluster's worker pools fetching pages lazily
+func (kcs *KubernetesClustersService) AllWorkerPools(ctx context.Context, options *ListOptions, clusterId string, opts ...RequestOption) iter.Seq2[KubernetesWorkerPool, error] {
+	return paginate(withOperation(ctx, "KubernetesClusters.AllWorkerPools"), options, func(ctx context.Context, options *ListOptions, opts ...RequestOption) ([]KubernetesWorkerPool, *Meta, error) {
+		return kcs.listWorkerPoolsPage(ctx, options, clusterId, opts...)
+	}, opts...)
 }
 
 // CreateWorkerPool creates worker pool
-func (kcs *KubernetesClustersService) CreateWorkerPool(ctx context.Context, clusterId string, request *CreateKubernetesWorkerPoolRequest) (*KubernetesWorkerPool, error) {
+func (kcs *KubernetesClustersService) CreateWorkerPool(ctx context.Context, clusterId string, request *CreateKubernetesWorkerPoolRequest, opts ...RequestOption) (*KubernetesWorkerPool, error) {
+	ctx = withOperation(ctx, "KubernetesClusters.CreateWorkerPool")
 	path := fmt.Sprintf("api/v2/kubernetes/clusters/%s/worker_pools", clusterId)
-	req, err := kcs.client.newRequest(http.MethodPost, path, request)
+	req, err := kcs.client.newRequest(http.MethodPost, path, request, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -117,9 +142,10 @@ func (kcs *KubernetesClustersService) CreateWorkerPool(ctx context.Context, clus
 }
 
 // UpdateWorkerPool updates worker pool
-func (kcs *KubernetesClustersService) UpdateWorkerPool(ctx context.Context, clusterId, workerPoolId string, request *UpdateKubernetesWorkerPoolRequest) error {
+func (kcs *KubernetesClustersService) UpdateWorkerPool(ctx context.Context, clusterId, workerPoolId string, request *UpdateKubernetesWorkerPoolRequest, opts ...RequestOption) error {
+	ctx = withOperation(ctx, "KubernetesClusters.UpdateWorkerPool")
 	path := fmt.Sprintf("api/v2/kubernetes/clusters/%s/worker_pools/%s", clusterId, workerPoolId)
-	req, err := kcs.client.newRequest(http.MethodPatch, path, request)
+	req, err := kcs.client.newRequest(http.MethodPatch, path, request, opts...)
 	if err != nil {
 		return err
 	}
@@ -132,9 +158,10 @@ func (kcs *KubernetesClustersService) UpdateWorkerPool(ctx context.Context, clus
 }
 
 // DeleteWorkerPool deletes worker pool
-func (kcs *KubernetesClustersService) DeleteWorkerPool(ctx context.Context, clusterId string, workerPoolId string, replace bool) error {
+func (kcs *KubernetesClustersService) DeleteWorkerPool(ctx context.Context, clusterId string, workerPoolId string, replace bool, opts ...RequestOption) error {
+	ctx = withOperation(ctx, "KubernetesClusters.DeleteWorkerPool")
 	path := fmt.Sprintf("api/v2/kubernetes/clusters/%s/worker_pools/%s?replace=%v", clusterId, workerPoolId, replace)
-	req, err := kcs.client.newRequest(http.MethodDelete, path, nil)
+	req, err := kcs.client.newRequest(http.MethodDelete, path, nil, opts...)
 	if err != nil {
 		return err
 	}
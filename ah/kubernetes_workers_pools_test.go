@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"testing"
 )
@@ -263,3 +265,33 @@ func TestWorkerPoolDelete(t *testing.T) {
 		t.Errorf("Unexpected error: %v", err)
 	}
 }
+
+func TestWorkerPoolsAll(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		requests++
+		if req.URL.Query().Get("page") == "2" {
+			_, _ = rw.Write([]byte(`{"worker_pools": [{"id": "pool-3"}], "meta": {"page": 2, "per_page": 2, "total": 3}}`))
+			return
+		}
+		_, _ = rw.Write([]byte(`{"worker_pools": [{"id": "pool-1"}, {"id": "pool-2"}], "meta": {"page": 1, "per_page": 2, "total": 3}}`))
+	}))
+	api, _ := NewAPIClient(newFakeClientOptions(server))
+
+	ctx := context.Background()
+	workerPools, meta, err := api.KubernetesClusters.ListWorkerPoolsPage(ctx, nil, "cluster_id")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(workerPools) != 2 || meta == nil || meta.Total != 3 {
+		t.Errorf("Unexpected result %v, meta %v", workerPools, meta)
+	}
+
+	all, err := ListAll(api.KubernetesClusters.AllWorkerPools(ctx, nil, "cluster_id"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(all) != 3 || all[2].ID != "pool-3" || requests != 3 {
+		t.Errorf("Unexpected worker pools %v in %d requests", all, requests)
+	}
+}
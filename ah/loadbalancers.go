@@ -19,10 +19,75 @@ package ah
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
-	"strings"
+	"net/url"
 )
 
+// LBBalancingAlgorithm is an algorithm distributing requests between backend nodes
+type LBBalancingAlgorithm string
+
+// Balancing algorithms
+const (
+	LBBalancingAlgorithmRoundRobin       LBBalancingAlgorithm = "round_robin"
+	LBBalancingAlgorithmLeastConnections LBBalancingAlgorithm = "least_connections"
+)
+
+// Valid reports whether the algorithm is known
+func (a LBBalancingAlgorithm) Valid() bool {
+	return a == LBBalancingAlgorithmRoundRobin || a == LBBalancingAlgorithmLeastConnections
+}
+
+// LBProxyProtocol is a version of PROXY protocol sent to backend nodes, it's disabled if empty
+type LBProxyProtocol string
+
+// PROXY protocol versions
+const (
+	LBProxyProtocolV1 LBProxyProtocol = "v1"
+	LBProxyProtocolV2 LBProxyProtocol = "v2"
+)
+
+// Valid reports whether the version is known
+func (p LBProxyProtocol) Valid() bool {
+	return p == LBProxyProtocolV1 || p == LBProxyProtocolV2
+}
+
+// LBProtocol is a protocol of forwarding rules
+type LBProtocol string
+
+// Forwarding rules protocols
+const (
+	LBProtocolTCP   LBProtocol = "tcp"
+	LBProtocolHTTP  LBProtocol = "http"
+	LBProtocolHTTPS LBProtocol = "https"
+)
+
+// Valid reports whether the protocol is known
+func (p LBProtocol) Valid() bool {
+	switch p {
+	case LBProtocolTCP, LBProtocolHTTP, LBProtocolHTTPS:
+		return true
+	}
+	return false
+}
+
+// LBHealthCheckType is a type of health checks
+type LBHealthCheckType string
+
+// Health check types
+const (
+	LBHealthCheckTypeTCP  LBHealthCheckType = "tcp"
+	LBHealthCheckTypeHTTP LBHealthCheckType = "http"
+)
+
+// Valid reports whether the type is known
+func (t LBHealthCheckType) Valid() bool {
+	return t == LBHealthCheckTypeTCP || t == LBHealthCheckTypeHTTP
+}
+
+// LoadBalancerStateError is the state of a broken load balancer
+const LoadBalancerStateError = "error"
+
 // LoadBalancer object
 type LoadBalancer struct {
 	Meta               map[string]interface{} `json:"meta,omitempty"`
@@ -30,8 +95,8 @@ type LoadBalancer struct {
 	Name               string                 `json:"name,omitempty"`
 	DatacenterID       string                 `json:"datacenter_id,omitempty"`
 	State              string                 `json:"state,omitempty"`
-	BalancingAlgorithm string                 `json:"balancing_algorithm,omitempty"`
-	ProxyProtocol      string                 `json:"proxy_protocol,omitempty"`
+	BalancingAlgorithm LBBalancingAlgorithm   `json:"balancing_algorithm,omitempty"`
+	ProxyProtocol      LBProxyProtocol        `json:"proxy_protocol,omitempty"`
 	IPAddresses        []LBIPAddress          `json:"ip_addresses,omitempty"`
 	PrivateNetworks    []LBPrivateNetwork     `json:"private_networks,omitempty"`
 	ForwardingRules    []LBForwardingRule     `json:"forwarding_rules,omitempty"`
@@ -67,12 +132,12 @@ type LBPrivateNetwork struct {
 
 // LBForwardingRule object
 type LBForwardingRule struct {
-	ID                    string `json:"id,omitempty"`
-	State                 string `json:"state,omitempty"`
-	RequestProtocol       string `json:"request_protocol,omitempty"`
-	CommunicationProtocol string `json:"communication_protocol,omitempty"`
-	RequestPort           int    `json:"request_port,omitempty"`
-	CommunicationPort     int    `json:"communication_port,omitempty"`
+	ID                    string     `json:"id,omitempty"`
+	State                 string     `json:"state,omitempty"`
+	RequestProtocol       LBProtocol `json:"request_protocol,omitempty"`
+	CommunicationProtocol LBProtocol `json:"communication_protocol,omitempty"`
+	RequestPort           int        `json:"request_port,omitempty"`
+	CommunicationPort     int        `json:"communication_port,omitempty"`
 }
 
 // LBBackendNode object
@@ -84,50 +149,52 @@ type LBBackendNode struct {
 
 // LBHealthCheck object
 type LBHealthCheck struct {
-	ID                 string `json:"id,omitempty"`
-	State              string `json:"state,omitempty"`
-	Type               string `json:"type,omitempty"`
-	URL                string `json:"url,omitempty"`
-	Interval           int    `json:"interval,omitempty"`
-	Timeout            int    `json:"timeout,omitempty"`
-	UnhealthyThreshold int    `json:"unhealthy_threshold,omitempty"`
-	HealthyThreshold   int    `json:"Healthy_threshold,omitempty"`
-	Port               int    `json:"port,omitempty"`
+	ID                 string            `json:"id,omitempty"`
+	State              string            `json:"state,omitempty"`
+	Type               LBHealthCheckType `json:"type,omitempty"`
+	URL                string            `json:"url,omitempty"`
+	Interval           int               `json:"interval,omitempty"`
+	Timeout            int               `json:"timeout,omitempty"`
+	UnhealthyThreshold int               `json:"unhealthy_threshold,omitempty"`
+	HealthyThreshold   int               `json:"Healthy_threshold,omitempty"`
+	Port               int               `json:"port,omitempty"`
 }
 
 // LoadBalancersAPI is an interface for load balancers.
 type LoadBalancersAPI interface {
-	List(context.Context, map[string]string) ([]LoadBalancer, error)
-	Get(context.Context, string) (*LoadBalancer, error)
-	Create(context.Context, *LoadBalancerCreateRequest) (*LoadBalancer, error)
-	Update(context.Context, string, *LoadBalancerUpdateRequest) error
-	Delete(context.Context, string) error
-
-	ListForwardingRules(context.Context, string) ([]LBForwardingRule, error)
-	GetForwardingRule(context.Context, string, string) (*LBForwardingRule, error)
-	CreateForwardingRule(context.Context, string, *LBForwardingRuleCreateRequest) (*LBForwardingRule, error)
-	DeleteForwardingRule(context.Context, string, string) error
-
-	ListPrivateNetworks(context.Context, string) ([]LBPrivateNetwork, error)
-	GetPrivateNetwork(context.Context, string, string) (*LBPrivateNetwork, error)
-	ConnectPrivateNetworks(context.Context, string, []string) ([]LBPrivateNetwork, error)
-	DisconnectPrivateNetwork(context.Context, string, string) error
-
-	ListBackendNodes(context.Context, string) ([]LBBackendNode, error)
-	GetBackendNode(context.Context, string, string) (*LBBackendNode, error)
-	AddBackendNodes(context.Context, string, []string) ([]LBBackendNode, error)
-	DeleteBackendNode(context.Context, string, string) error
-
-	ListHealthChecks(context.Context, string) ([]LBHealthCheck, error)
-	GetHealthCheck(context.Context, string, string) (*LBHealthCheck, error)
-	CreateHealthCheck(context.Context, string, *LBHealthCheckCreateRequest) (*LBHealthCheck, error)
-	UpdateHealthCheck(context.Context, string, string, *LBHealthCheckUpdateRequest) error
-	DeleteHealthCheck(context.Context, string, string) error
-
-	ListIPAddresses(context.Context, string) ([]LBIPAddress, error)
-	GetIPAddress(context.Context, string, string) (*LBIPAddress, error)
-	AssignIPAddresses(context.Context, string, []string) ([]LBIPAddress, error)
-	ReleaseIPAddress(context.Context, string, string) error
+	List(context.Context, map[string]string, ...RequestOption) ([]LoadBalancer, error)
+	ListPage(context.Context, *ListOptions, ...RequestOption) ([]LoadBalancer, *Meta, error)
+	All(context.Context, *ListOptions, ...RequestOption) iter.Seq2[LoadBalancer, error]
+	Get(context.Context, string, ...RequestOption) (*LoadBalancer, error)
+	Create(context.Context, *LoadBalancerCreateRequest, ...RequestOption) (*LoadBalancer, error)
+	Update(context.Context, string, *LoadBalancerUpdateRequest, ...RequestOption) error
+	Delete(context.Context, string, ...RequestOption) error
+
+	ListForwardingRules(context.Context, string, ...RequestOption) ([]LBForwardingRule, error)
+	GetForwardingRule(context.Context, string, string, ...RequestOption) (*LBForwardingRule, error)
+	CreateForwardingRule(context.Context, string, *LBForwardingRuleCreateRequest, ...RequestOption) (*LBForwardingRule, error)
+	DeleteForwardingRule(context.Context, string, string, ...RequestOption) error
+
+	ListPrivateNetworks(context.Context, string, ...RequestOption) ([]LBPrivateNetwork, error)
+	GetPrivateNetwork(context.Context, string, string, ...RequestOption) (*LBPrivateNetwork, error)
+	ConnectPrivateNetworks(context.Context, string, []string, ...RequestOption) ([]LBPrivateNetwork, error)
+	DisconnectPrivateNetwork(context.Context, string, string, ...RequestOption) error
+
+	ListBackendNodes(context.Context, string, ...RequestOption) ([]LBBackendNode, error)
+	GetBackendNode(context.Context, string, string, ...RequestOption) (*LBBackendNode, error)
+	AddBackendNodes(context.Context, string, []string, ...RequestOption) ([]LBBackendNode, error)
+	DeleteBackendNode(context.Context, string, string, ...RequestOption) error
+
+	ListHealthChecks(context.Context, string, ...RequestOption) ([]LBHealthCheck, error)
+	GetHealthCheck(context.Context, string, string, ...RequestOption) (*LBHealthCheck, error)
+	CreateHealthCheck(context.Context, string, *LBHealthCheckCreateRequest, ...RequestOption) (*LBHealthCheck, error)
+	UpdateHealthCheck(context.Context, string, string, *LBHealthCheckUpdateRequest, ...RequestOption) error
+	DeleteHealthCheck(context.Context, string, string, ...RequestOption) error
+
+	ListIPAddresses(context.Context, string, ...RequestOption) ([]LBIPAddress, error)
+	GetIPAddress(context.Context, string, string, ...RequestOption) (*LBIPAddress, error)
+	AssignIPAddresses(context.Context, string, []string, ...RequestOption) ([]LBIPAddress, error)
+	ReleaseIPAddress(context.Context, string, string, ...RequestOption) error
 }
 
 // LoadBalancersService implements LoadBalancersAPI interface.
@@ -136,35 +203,62 @@ type LoadBalancersService struct {
 }
 
 type loadBalancersRoot struct {
+	Meta          *Meta          `json:"meta,omitempty"`
 	LoadBalancers []LoadBalancer `json:"load_balancers,omitempty"`
 }
 
-// List returns all available load balancers
-func (lb *LoadBalancersService) List(ctx context.Context, filters map[string]string) ([]LoadBalancer, error) {
+// List returns all available load balancers. Filters are raw query parameters.
+//
+// Deprecated: Please use ListPage instead.
+func (lb *LoadBalancersService) List(ctx context.Context, filters map[string]string, opts ...RequestOption) ([]LoadBalancer, error) {
+	ctx = withOperation(ctx, "LoadBalancers.List")
 	path := "api/v1/load_balancers"
 	if filters != nil {
-		var query []string
+		query := url.Values{}
 		for filterName, filterVal := range filters {
-			query = append(query, fmt.Sprintf("%s=%s", filterName, filterVal))
+			query.Set(filterName, filterVal)
 		}
-		params := strings.Join(query, "&")
-		path = fmt.Sprintf("%s?%s", path, params)
+		path = fmt.Sprintf("%s?%s", path, query.Encode())
 	}
 
 	var lbsRoot loadBalancersRoot
 
-	if err := lb.client.list(ctx, path, nil, &lbsRoot); err != nil {
+	if err := lb.client.list(ctx, path, nil, &lbsRoot, opts...); err != nil {
 		return nil, err
 	}
 
 	return lbsRoot.LoadBalancers, nil
 }
 
+// ListPage returns a page of load balancers with pagination metadata
+func (lb *LoadBalancersService) ListPage(ctx context.Context, options *ListOptions, opts ...RequestOption) ([]LoadBalancer, *Meta, error) {
+	return lb.listPage(withOperation(ctx, "LoadBalancers.ListPage"), options, opts...)
+}
+
+// listPage is ListPage without the operation name, so that wrappers keep their own
+func (lb *LoadBalancersService) listPage(ctx context.Context, options *ListOptions, opts ...RequestOption) ([]LoadBalancer, *Meta, error) {
+	path := "api/v1/load_balancers"
+
+	var lbsRoot loadBalancersRoot
+
+	if err := lb.client.list(ctx, path, options, &lbsRoot, opts...); err != nil {
+		return nil, nil, err
+	}
+
+	return lbsRoot.LoadBalancers, lbsRoot.Meta, nil
+}
+
+// All returns an iterator over all load balancers fetching pages lazily
+func (lb *LoadBalancersService) All(ctx context.Context, options *ListOptions, opts ...RequestOption) iter.Seq2[LoadBalancer, error] {
+	return paginate(withOperation(ctx, "LoadBalancers.All"), options, lb.listPage, opts...)
+}
+
 // Get load balancer
-func (lb *LoadBalancersService) Get(ctx context.Context, lbID string) (*LoadBalancer, error) {
+func (lb *LoadBalancersService) Get(ctx context.Context, lbID string, opts ...RequestOption) (*LoadBalancer, error) {
+	ctx = withOperation(ctx, "LoadBalancers.Get")
 	path := fmt.Sprintf("api/v1/load_balancers/%s", lbID)
 
-	req, err := lb.client.newRequest(http.MethodGet, path, nil)
+	req, err := lb.client.newRequest(http.MethodGet, path, nil, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -185,8 +279,8 @@ type LoadBalancerCreateRequest struct {
 	Meta                  map[string]interface{}          `json:"meta,omitempty"`
 	Name                  string                          `json:"name"`
 	DatacenterID          string                          `json:"datacenter_id"`
-	BalancingAlgorithm    string                          `json:"balancing_algorithm,omitempty"`
-	ProxyProtocol         string                          `json:"proxy_protocol,omitempty"`
+	BalancingAlgorithm    LBBalancingAlgorithm            `json:"balancing_algorithm,omitempty"`
+	ProxyProtocol         LBProxyProtocol                 `json:"proxy_protocol,omitempty"`
 	IPAddressIDs          []string                        `json:"ip_address_ids,omitempty"`
 	PrivateNetworkIDs     []string                        `json:"private_network_ids,omitempty"`
 	ForwardingRules       []LBForwardingRuleCreateRequest `json:"forwarding_rules,omitempty"`
@@ -200,21 +294,21 @@ type LoadBalancerCreateRequest struct {
 
 // LBForwardingRuleCreateRequest object
 type LBForwardingRuleCreateRequest struct {
-	RequestProtocol       string `json:"request_protocol"`
-	CommunicationProtocol string `json:"communication_protocol"`
-	RequestPort           int    `json:"request_port"`
-	CommunicationPort     int    `json:"communication_port"`
+	RequestProtocol       LBProtocol `json:"request_protocol"`
+	CommunicationProtocol LBProtocol `json:"communication_protocol"`
+	RequestPort           int        `json:"request_port"`
+	CommunicationPort     int        `json:"communication_port"`
 }
 
 // LBHealthCheckCreateRequest object
 type LBHealthCheckCreateRequest struct {
-	Type               string `json:"type"`
-	URL                string `json:"url,omitempty"`
-	Interval           int    `json:"interval,omitempty"`
-	Timeout            int    `json:"timeout,omitempty"`
-	UnhealthyThreshold int    `json:"unhealthy_threshold,omitempty"`
-	HealthyThreshold   int    `json:"Healthy_threshold,omitempty"`
-	Port               int    `json:"port"`
+	Type               LBHealthCheckType `json:"type"`
+	URL                string            `json:"url,omitempty"`
+	Interval           int               `json:"interval,omitempty"`
+	Timeout            int               `json:"timeout,omitempty"`
+	UnhealthyThreshold int               `json:"unhealthy_threshold,omitempty"`
+	HealthyThreshold   int               `json:"Healthy_threshold,omitempty"`
+	Port               int               `json:"port"`
 }
 
 // LBBackendNodeCreateRequest object
@@ -227,11 +321,12 @@ type loadBalancerRoot struct {
 }
 
 // Create a load balancer
-func (lb *LoadBalancersService) Create(ctx context.Context, createRequest *LoadBalancerCreateRequest) (*LoadBalancer, error) {
+func (lb *LoadBalancersService) Create(ctx context.Context, createRequest *LoadBalancerCreateRequest, opts ...RequestOption) (*LoadBalancer, error) {
+	ctx = withOperation(ctx, "LoadBalancers.Create")
 	type request struct {
 		LoadBalancer *LoadBalancerCreateRequest `json:"load_balancer"`
 	}
-	req, err := lb.client.newRequest(http.MethodPost, "api/v1/load_balancers", &request{createRequest})
+	req, err := lb.client.newRequest(http.MethodPost, "api/v1/load_balancers", &request{createRequest}, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -246,22 +341,23 @@ func (lb *LoadBalancersService) Create(ctx context.Context, createRequest *LoadB
 
 // LoadBalancerUpdateRequest represents a request to update a load balancer.
 type LoadBalancerUpdateRequest struct {
-	Name               string `json:"name,omitempty"`
-	BalancingAlgorithm string `json:"balancing_algorithm,omitempty"`
-	ProxyProtocol      string `json:"proxy_protocol,omitempty"`
-	HAOn               bool   `json:"ha_on,omitempty"`
-	CUCount            int    `json:"cu_count,omitempty"`
-	CUMax              int    `json:"cu_max,omitempty"`
-	InstanceCount      int    `json:"instance_count,omitempty"`
+	Name               string               `json:"name,omitempty"`
+	BalancingAlgorithm LBBalancingAlgorithm `json:"balancing_algorithm,omitempty"`
+	ProxyProtocol      LBProxyProtocol      `json:"proxy_protocol,omitempty"`
+	HAOn               bool                 `json:"ha_on,omitempty"`
+	CUCount            int                  `json:"cu_count,omitempty"`
+	CUMax              int                  `json:"cu_max,omitempty"`
+	InstanceCount      int                  `json:"instance_count,omitempty"`
 }
 
 // Update load balancer
-func (lb *LoadBalancersService) Update(ctx context.Context, lbID string, updateRequest *LoadBalancerUpdateRequest) error {
+func (lb *LoadBalancersService) Update(ctx context.Context, lbID string, updateRequest *LoadBalancerUpdateRequest, opts ...RequestOption) error {
+	ctx = withOperation(ctx, "LoadBalancers.Update")
 	type request struct {
 		LoadBalancer *LoadBalancerUpdateRequest `json:"load_balancer"`
 	}
 	path := fmt.Sprintf("api/v1/load_balancers/%s", lbID)
-	req, err := lb.client.newRequest(http.MethodPatch, path, &request{updateRequest})
+	req, err := lb.client.newRequest(http.MethodPatch, path, &request{updateRequest}, opts...)
 
 	if err != nil {
 		return err
@@ -273,9 +369,10 @@ func (lb *LoadBalancersService) Update(ctx context.Context, lbID string, updateR
 }
 
 // Delete load balancer
-func (lb *LoadBalancersService) Delete(ctx context.Context, lbID string) error {
+func (lb *LoadBalancersService) Delete(ctx context.Context, lbID string, opts ...RequestOption) error {
+	ctx = withOperation(ctx, "LoadBalancers.Delete")
 	path := fmt.Sprintf("api/v1/load_balancers/%s", lbID)
-	req, err := lb.client.newRequest(http.MethodDelete, path, nil)
+	req, err := lb.client.newRequest(http.MethodDelete, path, nil, opts...)
 
 	if err != nil {
 		return err
@@ -295,12 +392,13 @@ type lbForwardingRuleRoot struct {
 }
 
 // ListForwardingRules returns all available forwarding rules
-func (lb *LoadBalancersService) ListForwardingRules(ctx context.Context, lbID string) ([]LBForwardingRule, error) {
+func (lb *LoadBalancersService) ListForwardingRules(ctx context.Context, lbID string, opts ...RequestOption) ([]LBForwardingRule, error) {
+	ctx = withOperation(ctx, "LoadBalancers.ListForwardingRules")
 	path := fmt.Sprintf("api/v1/load_balancers/%s/forwarding_rules", lbID)
 
 	var frsRoot lbForwardingRulesRoot
 
-	if err := lb.client.list(ctx, path, nil, &frsRoot); err != nil {
+	if err := lb.client.list(ctx, path, nil, &frsRoot, opts...); err != nil {
 		return nil, err
 	}
 
@@ -308,10 +406,11 @@ func (lb *LoadBalancersService) ListForwardingRules(ctx context.Context, lbID st
 }
 
 // GetForwardingRule returns forwarding rule info
-func (lb *LoadBalancersService) GetForwardingRule(ctx context.Context, lbID, frID string) (*LBForwardingRule, error) {
+func (lb *LoadBalancersService) GetForwardingRule(ctx context.Context, lbID, frID string, opts ...RequestOption) (*LBForwardingRule, error) {
+	ctx = withOperation(ctx, "LoadBalancers.GetForwardingRule")
 	path := fmt.Sprintf("api/v1/load_balancers/%s/forwarding_rules/%s", lbID, frID)
 
-	req, err := lb.client.newRequest(http.MethodGet, path, nil)
+	req, err := lb.client.newRequest(http.MethodGet, path, nil, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -327,10 +426,11 @@ func (lb *LoadBalancersService) GetForwardingRule(ctx context.Context, lbID, frI
 }
 
 // CreateForwardingRule creates a forwarding rule
-func (lb *LoadBalancersService) CreateForwardingRule(ctx context.Context, lbID string, request *LBForwardingRuleCreateRequest) (*LBForwardingRule, error) {
+func (lb *LoadBalancersService) CreateForwardingRule(ctx context.Context, lbID string, request *LBForwardingRuleCreateRequest, opts ...RequestOption) (*LBForwardingRule, error) {
+	ctx = withOperation(ctx, "LoadBalancers.CreateForwardingRule")
 	path := fmt.Sprintf("api/v1/load_balancers/%s/forwarding_rules", lbID)
 
-	req, err := lb.client.newRequest(http.MethodPost, path, request)
+	req, err := lb.client.newRequest(http.MethodPost, path, request, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -346,10 +446,11 @@ func (lb *LoadBalancersService) CreateForwardingRule(ctx context.Context, lbID s
 }
 
 // DeleteForwardingRule remove forwarding rule
-func (lb *LoadBalancersService) DeleteForwardingRule(ctx context.Context, lbID, frID string) error {
+func (lb *LoadBalancersService) DeleteForwardingRule(ctx context.Context, lbID, frID string, opts ...RequestOption) error {
+	ctx = withOperation(ctx, "LoadBalancers.DeleteForwardingRule")
 	path := fmt.Sprintf("api/v1/load_balancers/%s/forwarding_rules/%s", lbID, frID)
 
-	req, err := lb.client.newRequest(http.MethodDelete, path, nil)
+	req, err := lb.client.newRequest(http.MethodDelete, path, nil, opts...)
 	if err != nil {
 		return err
 	}
@@ -372,12 +473,13 @@ type lbPrivateNetworkRoot struct {
 }
 
 // ListPrivateNetworks returns all connected private networks
-func (lb *LoadBalancersService) ListPrivateNetworks(ctx context.Context, lbID string) ([]LBPrivateNetwork, error) {
+func (lb *LoadBalancersService) ListPrivateNetworks(ctx context.Context, lbID string, opts ...RequestOption) ([]LBPrivateNetwork, error) {
+	ctx = withOperation(ctx, "LoadBalancers.ListPrivateNetworks")
 	path := fmt.Sprintf("api/v1/load_balancers/%s/private_networks", lbID)
 
 	var pnRoot lbPrivateNetworksRoot
 
-	if err := lb.client.list(ctx, path, nil, &pnRoot); err != nil {
+	if err := lb.client.list(ctx, path, nil, &pnRoot, opts...); err != nil {
 		return nil, err
 	}
 
@@ -385,10 +487,11 @@ func (lb *LoadBalancersService) ListPrivateNetworks(ctx context.Context, lbID st
 }
 
 // GetPrivateNetwork returns private network info
-func (lb *LoadBalancersService) GetPrivateNetwork(ctx context.Context, lbID, pnID string) (*LBPrivateNetwork, error) {
+func (lb *LoadBalancersService) GetPrivateNetwork(ctx context.Context, lbID, pnID string, opts ...RequestOption) (*LBPrivateNetwork, error) {
+	ctx = withOperation(ctx, "LoadBalancers.GetPrivateNetwork")
 	path := fmt.Sprintf("api/v1/load_balancers/%s/private_networks/%s", lbID, pnID)
 
-	req, err := lb.client.newRequest(http.MethodGet, path, nil)
+	req, err := lb.client.newRequest(http.MethodGet, path, nil, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -408,10 +511,11 @@ type connectLBPrivateNetworksRequest struct {
 }
 
 // ConnectPrivateNetworks connects LB to a private networks
-func (lb *LoadBalancersService) ConnectPrivateNetworks(ctx context.Context, lbID string, pnIDs []string) ([]LBPrivateNetwork, error) {
+func (lb *LoadBalancersService) ConnectPrivateNetworks(ctx context.Context, lbID string, pnIDs []string, opts ...RequestOption) ([]LBPrivateNetwork, error) {
+	ctx = withOperation(ctx, "LoadBalancers.ConnectPrivateNetworks")
 	path := fmt.Sprintf("api/v1/load_balancers/%s/private_networks", lbID)
 
-	req, err := lb.client.newRequest(http.MethodPost, path, &connectLBPrivateNetworksRequest{PrivateNetworkIDs: pnIDs})
+	req, err := lb.client.newRequest(http.MethodPost, path, &connectLBPrivateNetworksRequest{PrivateNetworkIDs: pnIDs}, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -427,10 +531,11 @@ func (lb *LoadBalancersService) ConnectPrivateNetworks(ctx context.Context, lbID
 }
 
 // DisconnectPrivateNetwork removes lb from the private network
-func (lb *LoadBalancersService) DisconnectPrivateNetwork(ctx context.Context, lbID, pnID string) error {
+func (lb *LoadBalancersService) DisconnectPrivateNetwork(ctx context.Context, lbID, pnID string, opts ...RequestOption) error {
+	ctx = withOperation(ctx, "LoadBalancers.DisconnectPrivateNetwork")
 	path := fmt.Sprintf("api/v1/load_balancers/%s/private_networks/%s", lbID, pnID)
 
-	req, err := lb.client.newRequest(http.MethodDelete, path, nil)
+	req, err := lb.client.newRequest(http.MethodDelete, path, nil, opts...)
 	if err != nil {
 		return err
 	}
@@ -453,12 +558,13 @@ type lbBackendNodeRoot struct {
 }
 
 // ListBackendNodes returns all connected backend nodes
-func (lb *LoadBalancersService) ListBackendNodes(ctx context.Context, lbID string) ([]LBBackendNode, error) {
+func (lb *LoadBalancersService) ListBackendNodes(ctx context.Context, lbID string, opts ...RequestOption) ([]LBBackendNode, error) {
+	ctx = withOperation(ctx, "LoadBalancers.ListBackendNodes")
 	path := fmt.Sprintf("api/v1/load_balancers/%s/backend_nodes", lbID)
 
 	var bnRoot lbBackendNodesRoot
 
-	if err := lb.client.list(ctx, path, nil, &bnRoot); err != nil {
+	if err := lb.client.list(ctx, path, nil, &bnRoot, opts...); err != nil {
 		return nil, err
 	}
 
@@ -466,10 +572,11 @@ func (lb *LoadBalancersService) ListBackendNodes(ctx context.Context, lbID strin
 }
 
 // GetBackendNode returns backend node info
-func (lb *LoadBalancersService) GetBackendNode(ctx context.Context, lbID, bnID string) (*LBBackendNode, error) {
+func (lb *LoadBalancersService) GetBackendNode(ctx context.Context, lbID, bnID string, opts ...RequestOption) (*LBBackendNode, error) {
+	ctx = withOperation(ctx, "LoadBalancers.GetBackendNode")
 	path := fmt.Sprintf("api/v1/load_balancers/%s/backend_nodes/%s", lbID, bnID)
 
-	req, err := lb.client.newRequest(http.MethodGet, path, nil)
+	req, err := lb.client.newRequest(http.MethodGet, path, nil, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -485,7 +592,8 @@ func (lb *LoadBalancersService) GetBackendNode(ctx context.Context, lbID, bnID s
 }
 
 // AddBackendNodes connects backend nodes to the LB
-func (lb *LoadBalancersService) AddBackendNodes(ctx context.Context, lbID string, bnIDs []string) ([]LBBackendNode, error) {
+func (lb *LoadBalancersService) AddBackendNodes(ctx context.Context, lbID string, bnIDs []string, opts ...RequestOption) ([]LBBackendNode, error) {
+	ctx = withOperation(ctx, "LoadBalancers.AddBackendNodes")
 	path := fmt.Sprintf("api/v1/load_balancers/%s/backend_nodes", lbID)
 
 	var lbCloudServers []LBBackendNodeCreateRequest
@@ -494,7 +602,7 @@ func (lb *LoadBalancersService) AddBackendNodes(ctx context.Context, lbID string
 		lbCloudServers = append(lbCloudServers, LBBackendNodeCreateRequest{CloudServerID: bnID})
 	}
 
-	req, err := lb.client.newRequest(http.MethodPost, path, lbCloudServers)
+	req, err := lb.client.newRequest(http.MethodPost, path, lbCloudServers, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -510,10 +618,11 @@ func (lb *LoadBalancersService) AddBackendNodes(ctx context.Context, lbID string
 }
 
 // DeleteBackendNode removes backend node from the LB
-func (lb *LoadBalancersService) DeleteBackendNode(ctx context.Context, lbID, bnID string) error {
+func (lb *LoadBalancersService) DeleteBackendNode(ctx context.Context, lbID, bnID string, opts ...RequestOption) error {
+	ctx = withOperation(ctx, "LoadBalancers.DeleteBackendNode")
 	path := fmt.Sprintf("api/v1/load_balancers/%s/backend_nodes/%s", lbID, bnID)
 
-	req, err := lb.client.newRequest(http.MethodDelete, path, nil)
+	req, err := lb.client.newRequest(http.MethodDelete, path, nil, opts...)
 	if err != nil {
 		return err
 	}
@@ -536,12 +645,13 @@ type lbHealthCheckRoot struct {
 }
 
 // ListHealthChecks returns all health checks
-func (lb *LoadBalancersService) ListHealthChecks(ctx context.Context, lbID string) ([]LBHealthCheck, error) {
+func (lb *LoadBalancersService) ListHealthChecks(ctx context.Context, lbID string, opts ...RequestOption) ([]LBHealthCheck, error) {
+	ctx = withOperation(ctx, "LoadBalancers.ListHealthChecks")
 	path := fmt.Sprintf("api/v1/load_balancers/%s/health_checks", lbID)
 
 	var hcRoot lbHealthChecksRoot
 
-	if err := lb.client.list(ctx, path, nil, &hcRoot); err != nil {
+	if err := lb.client.list(ctx, path, nil, &hcRoot, opts...); err != nil {
 		return nil, err
 	}
 
@@ -549,10 +659,11 @@ func (lb *LoadBalancersService) ListHealthChecks(ctx context.Context, lbID strin
 }
 
 // GetHealthCheck returns health check info
-func (lb *LoadBalancersService) GetHealthCheck(ctx context.Context, lbID, hcID string) (*LBHealthCheck, error) {
+func (lb *LoadBalancersService) GetHealthCheck(ctx context.Context, lbID, hcID string, opts ...RequestOption) (*LBHealthCheck, error) {
+	ctx = withOperation(ctx, "LoadBalancers.GetHealthCheck")
 	path := fmt.Sprintf("api/v1/load_balancers/%s/health_checks/%s", lbID, hcID)
 
-	req, err := lb.client.newRequest(http.MethodGet, path, nil)
+	req, err := lb.client.newRequest(http.MethodGet, path, nil, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -568,10 +679,11 @@ func (lb *LoadBalancersService) GetHealthCheck(ctx context.Context, lbID, hcID s
 }
 
 // CreateHealthCheck creates new health check
-func (lb *LoadBalancersService) CreateHealthCheck(ctx context.Context, lbID string, request *LBHealthCheckCreateRequest) (*LBHealthCheck, error) {
+func (lb *LoadBalancersService) CreateHealthCheck(ctx context.Context, lbID string, request *LBHealthCheckCreateRequest, opts ...RequestOption) (*LBHealthCheck, error) {
+	ctx = withOperation(ctx, "LoadBalancers.CreateHealthCheck")
 	path := fmt.Sprintf("api/v1/load_balancers/%s/health_checks", lbID)
 
-	req, err := lb.client.newRequest(http.MethodPost, path, request)
+	req, err := lb.client.newRequest(http.MethodPost, path, request, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -588,20 +700,21 @@ func (lb *LoadBalancersService) CreateHealthCheck(ctx context.Context, lbID stri
 
 // LBHealthCheckUpdateRequest object
 type LBHealthCheckUpdateRequest struct {
-	Type               string `json:"type,omitempty"`
-	URL                string `json:"url,omitempty"`
-	Interval           int    `json:"interval,omitempty"`
-	Timeout            int    `json:"timeout,omitempty"`
-	UnhealthyThreshold int    `json:"unhealthy_threshold,omitempty"`
-	HealthyThreshold   int    `json:"Healthy_threshold,omitempty"`
-	Port               int    `json:"port,omitempty"`
+	Type               LBHealthCheckType `json:"type,omitempty"`
+	URL                string            `json:"url,omitempty"`
+	Interval           int               `json:"interval,omitempty"`
+	Timeout            int               `json:"timeout,omitempty"`
+	UnhealthyThreshold int               `json:"unhealthy_threshold,omitempty"`
+	HealthyThreshold   int               `json:"Healthy_threshold,omitempty"`
+	Port               int               `json:"port,omitempty"`
 }
 
 // UpdateHealthCheck updates the health check
-func (lb *LoadBalancersService) UpdateHealthCheck(ctx context.Context, lbID, hcID string, request *LBHealthCheckUpdateRequest) error {
+func (lb *LoadBalancersService) UpdateHealthCheck(ctx context.Context, lbID, hcID string, request *LBHealthCheckUpdateRequest, opts ...RequestOption) error {
+	ctx = withOperation(ctx, "LoadBalancers.UpdateHealthCheck")
 	path := fmt.Sprintf("api/v1/load_balancers/%s/health_checks/%s", lbID, hcID)
 
-	req, err := lb.client.newRequest(http.MethodPatch, path, request)
+	req, err := lb.client.newRequest(http.MethodPatch, path, request, opts...)
 	if err != nil {
 		return err
 	}
@@ -616,10 +729,11 @@ func (lb *LoadBalancersService) UpdateHealthCheck(ctx context.Context, lbID, hcI
 }
 
 // DeleteHealthCheck removes health check from the LB
-func (lb *LoadBalancersService) DeleteHealthCheck(ctx context.Context, lbID, hcID string) error {
+func (lb *LoadBalancersService) DeleteHealthCheck(ctx context.Context, lbID, hcID string, opts ...RequestOption) error {
+	ctx = withOperation(ctx, "LoadBalancers.DeleteHealthCheck")
 	path := fmt.Sprintf("api/v1/load_balancers/%s/health_checks/%s", lbID, hcID)
 
-	req, err := lb.client.newRequest(http.MethodDelete, path, nil)
+	req, err := lb.client.newRequest(http.MethodDelete, path, nil, opts...)
 	if err != nil {
 		return err
 	}
@@ -642,12 +756,13 @@ type lbIPAddressRoot struct {
 }
 
 // ListIPAddresses returns all ip addresses
-func (lb *LoadBalancersService) ListIPAddresses(ctx context.Context, lbID string) ([]LBIPAddress, error) {
+func (lb *LoadBalancersService) ListIPAddresses(ctx context.Context, lbID string, opts ...RequestOption) ([]LBIPAddress, error) {
+	ctx = withOperation(ctx, "LoadBalancers.ListIPAddresses")
 	path := fmt.Sprintf("api/v1/load_balancers/%s/ip_addresses", lbID)
 
 	var ipRoot lbIPAddressesRoot
 
-	if err := lb.client.list(ctx, path, nil, &ipRoot); err != nil {
+	if err := lb.client.list(ctx, path, nil, &ipRoot, opts...); err != nil {
 		return nil, err
 	}
 
@@ -655,10 +770,11 @@ func (lb *LoadBalancersService) ListIPAddresses(ctx context.Context, lbID string
 }
 
 // GetIPAddress returns ip address info
-func (lb *LoadBalancersService) GetIPAddress(ctx context.Context, lbID, ipID string) (*LBIPAddress, error) {
+func (lb *LoadBalancersService) GetIPAddress(ctx context.Context, lbID, ipID string, opts ...RequestOption) (*LBIPAddress, error) {
+	ctx = withOperation(ctx, "LoadBalancers.GetIPAddress")
 	path := fmt.Sprintf("api/v1/load_balancers/%s/ip_addresses/%s", lbID, ipID)
 
-	req, err := lb.client.newRequest(http.MethodGet, path, nil)
+	req, err := lb.client.newRequest(http.MethodGet, path, nil, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -678,12 +794,13 @@ type assignIPAddressRequest struct {
 }
 
 // AssignIPAddresses assigns ip addresses to the LB
-func (lb *LoadBalancersService) AssignIPAddresses(ctx context.Context, lbID string, ipIDs []string) ([]LBIPAddress, error) {
+func (lb *LoadBalancersService) AssignIPAddresses(ctx context.Context, lbID string, ipIDs []string, opts ...RequestOption) ([]LBIPAddress, error) {
+	ctx = withOperation(ctx, "LoadBalancers.AssignIPAddresses")
 	path := fmt.Sprintf("api/v1/load_balancers/%s/ip_addresses", lbID)
 
 	request := &assignIPAddressRequest{IPAddressesIDS: ipIDs}
 
-	req, err := lb.client.newRequest(http.MethodPost, path, request)
+	req, err := lb.client.newRequest(http.MethodPost, path, request, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -699,10 +816,11 @@ func (lb *LoadBalancersService) AssignIPAddresses(ctx context.Context, lbID stri
 }
 
 // ReleaseIPAddress removes ip address from the LB
-func (lb *LoadBalancersService) ReleaseIPAddress(ctx context.Context, lbID, ipID string) error {
+func (lb *LoadBalancersService) ReleaseIPAddress(ctx context.Context, lbID, ipID string, opts ...RequestOption) error {
+	ctx = withOperation(ctx, "LoadBalancers.ReleaseIPAddress")
 	path := fmt.Sprintf("api/v1/load_balancers/%s/ip_addresses/%s", lbID, ipID)
 
-	req, err := lb.client.newRequest(http.MethodDelete, path, nil)
+	req, err := lb.client.newRequest(http.MethodDelete, path, nil, opts...)
 	if err != nil {
 		return err
 	}
@@ -20,6 +20,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"reflect"
 	"testing"
 )
@@ -983,3 +986,61 @@ func TestLoadBalancers_ReleaseIPAddress(t *testing.T) {
 	}
 
 }
+
+func TestLoadBalancers_ListPage(t *testing.T) {
+	var query url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		query = req.URL.Query()
+		_, _ = rw.Write([]byte(loadBalancerListResponse))
+	}))
+	api, _ := NewAPIClient(newFakeClientOptions(server))
+
+	ctx := context.Background()
+	options := &ListOptions{
+		Meta:    &ListMetaOptions{Page: 1, PerPage: 10},
+		Filters: []FilterInterface{&EqFilter{Keys: []string{"name"}, Value: "lb & co"}},
+	}
+	loadBalancers, meta, err := api.LoadBalancers.ListPage(ctx, options)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if query.Get("q[name_eq]") != "lb & co" || query.Get("per_page") != "10" {
+		t.Errorf("Unexpected query %v", query)
+	}
+	if len(loadBalancers) != 1 || meta != nil {
+		t.Errorf("unexpected result %v, meta %v", loadBalancers, meta)
+	}
+}
+
+func TestLoadBalancer_Enums(t *testing.T) {
+	var lb LoadBalancer
+	if err := json.Unmarshal([]byte(loadBalancerResponse), &lb); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if lb.BalancingAlgorithm != LBBalancingAlgorithmRoundRobin || !lb.BalancingAlgorithm.Valid() {
+		t.Errorf("Unexpected balancing algorithm %q", lb.BalancingAlgorithm)
+	}
+	rule := lb.ForwardingRules[0]
+	if rule.RequestProtocol != LBProtocolTCP || !rule.CommunicationProtocol.Valid() {
+		t.Errorf("Unexpected forwarding rule %+v", rule)
+	}
+	if lb.HealthCheck.Type != LBHealthCheckTypeTCP || !lb.HealthCheck.Type.Valid() {
+		t.Errorf("Unexpected health check %+v", lb.HealthCheck)
+	}
+
+	data := `{"balancing_algorithm":"source_ip","proxy_protocol":"v3","health_check":{"type":"grpc"}}`
+	lb = LoadBalancer{}
+	if err := json.Unmarshal([]byte(data), &lb); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if lb.BalancingAlgorithm.Valid() || lb.ProxyProtocol.Valid() || lb.HealthCheck.Type.Valid() || LBProtocol("udp").Valid() {
+		t.Errorf("Unknown values must be invalid: %+v", lb)
+	}
+	if lb.BalancingAlgorithm != "source_ip" || lb.ProxyProtocol != "v3" || lb.HealthCheck.Type != "grpc" {
+		t.Errorf("Unknown values must be kept: %+v", lb)
+	}
+	if !LBProxyProtocolV1.Valid() || !LBProxyProtocolV2.Valid() {
+		t.Errorf("Unexpected validation of proxy protocols")
+	}
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2023 Advanced Hosting
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ah
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/advancedhosting/advancedhosting-api-go/internal/redact"
+)
+
+// roundTrip sends a single HTTP request logging it at debug level if the logger is set
+func (c *APIClient) roundTrip(req *http.Request, attempt int) (*http.Response, error) {
+	ctx := req.Context()
+	if c.logger == nil || !c.logger.Enabled(ctx, slog.LevelDebug) {
+		return c.client.Do(req)
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", req.Method),
+		slog.String("path", req.URL.Path),
+		slog.Int("attempt", attempt),
+	}
+	if c.logBodies && req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			data, _ := io.ReadAll(body)
+			attrs = append(attrs, slog.String("request_body", redactBody(data)))
+		}
+	}
+
+	start := time.Now()
+	resp, err := c.client.Do(req)
+	attrs = append(attrs, slog.Duration("latency", time.Since(start)))
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+		c.logger.LogAttrs(ctx, slog.LevelDebug, "AH API request failed", attrs...)
+		return nil, err
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		attrs = append(attrs, slog.Int("status", resp.StatusCode), slog.String("error", err.Error()))
+		c.logger.LogAttrs(ctx, slog.LevelDebug, "AH API request failed", attrs...)
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+
+	attrs = append(attrs,
+		slog.Int("status", resp.StatusCode),
+		slog.Int("size", len(data)),
+	)
+	if requestID := resp.Header.Get(requestIDHeader); requestID != "" {
+		attrs = append(attrs, slog.String("request_id", requestID))
+	}
+	if c.logBodies {
+		attrs = append(attrs, slog.String("response_body", redactBody(data)))
+	}
+	c.logger.LogAttrs(ctx, slog.LevelDebug, "AH API request", attrs...)
+	return resp, nil
+}
+
+// redactBody returns JSON body with sensitive values replaced. Non-JSON bodies are omitted.
+func redactBody(data []byte) string {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return ""
+	}
+	var body interface{}
+	if err := json.Unmarshal(data, &body); err != nil {
+		return "[NON-JSON BODY OMITTED]"
+	}
+	redacted, err := json.Marshal(redactValue(body))
+	if err != nil {
+		return "[NON-JSON BODY OMITTED]"
+	}
+	return string(redacted)
+}
+
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			if redact.IsSensitiveKey(key) {
+				v[key] = redact.Value
+				continue
+			}
+			v[key] = redactValue(item)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactValue(item)
+		}
+	}
+	return value
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2023 Advanced Hosting
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ah
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/advancedhosting/advancedhosting-api-go/internal/redact"
+)
+
+func newFakeLoggedAPIClient(url string, response *fakeServerResponse, buf *bytes.Buffer, logBodies bool) *APIClient {
+	server := newFakeServer(url, response)
+	options := newFakeClientOptions(server)
+	options.Logger = slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	options.LogBodies = logBodies
+	api, _ := NewAPIClient(options)
+	return api
+}
+
+func TestLogging_Request(t *testing.T) {
+	var buf bytes.Buffer
+	fakeResponse := &fakeServerResponse{responseBody: getResponse}
+	api := newFakeLoggedAPIClient("/api/v1/instances/test", fakeResponse, &buf, false)
+
+	ctx := context.Background()
+	instance, err := api.Instances.Get(ctx, "test")
+	if err != nil || instance == nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	log := buf.String()
+	for _, expected := range []string{`"method":"GET"`, `"path":"/api/v1/instances/test"`, `"status":200`, `"latency"`, `"size"`} {
+		if !strings.Contains(log, expected) {
+			t.Errorf("Log %s doesn't contain %s", log, expected)
+		}
+	}
+
+	if strings.Contains(log, "response_body") {
+		t.Errorf("Bodies shouldn't be logged by default")
+	}
+}
+
+func TestLogging_RedactToken(t *testing.T) {
+	var buf bytes.Buffer
+	fakeResponse := &fakeServerResponse{responseBody: tokenResponse, statusCode: 201}
+	api := newFakeLoggedAPIClient("/id/api/v1/access_tokens", fakeResponse, &buf, true)
+
+	ctx := context.Background()
+	token, err := api.Tokens.Create(ctx, &TokenCreateRequest{Name: "test"})
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	log := buf.String()
+	if token.Token == "" || strings.Contains(log, token.Token) {
+		t.Errorf("Token isn't redacted: %s", log)
+	}
+
+	if strings.Contains(log, "test_token") {
+		t.Errorf("Bearer token is logged: %s", log)
+	}
+
+	if !strings.Contains(log, redact.Value) {
+		t.Errorf("Log doesn't contain redacted body: %s", log)
+	}
+}
+
+func TestLogging_RedactKubeconfig(t *testing.T) {
+	var buf bytes.Buffer
+	fakeResponse := &fakeServerResponse{responseBody: `{"config": "apiVersion: v1\nclient-key-data: secret"}`}
+	api := newFakeLoggedAPIClient("/api/v2/kubernetes/clusters/test/kubeconfig", fakeResponse, &buf, true)
+
+	ctx := context.Background()
+	if _, err := api.KubernetesClusters.GetConfig(ctx, "test"); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	if strings.Contains(buf.String(), "client-key-data") {
+		t.Errorf("Kubeconfig isn't redacted: %s", buf.String())
+	}
+}
@@ -16,7 +16,7 @@ limitations under the License.
 
 package ah
 
-// Meta object
+// Meta object. ListPage methods return nil Meta if the response doesn't contain it.
 type Meta struct {
 	Page    int `json:"page,omitempty"`
 	PerPage int `json:"per_page,omitempty"`
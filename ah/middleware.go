@@ -0,0 +1,50 @@
+/*
+Copyright 2023 Advanced Hosting
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ah
+
+import (
+	"net/http"
+)
+
+// RequestHandler sends an API request and returns its response.
+// The request context is available with req.Context().
+type RequestHandler func(req *http.Request) (*http.Response, error)
+
+// Middleware wraps request sending. It can modify the request before calling next,
+// observe the response or return a response without calling next.
+// The response body is closed by the client.
+type Middleware func(next RequestHandler) RequestHandler
+
+// chainMiddlewares wraps the handler with middlewares. The first middleware is the outermost one.
+func chainMiddlewares(handler RequestHandler, middlewares []Middleware) RequestHandler {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		if middlewares[i] != nil {
+			handler = middlewares[i](handler)
+		}
+	}
+	return handler
+}
+
+// HeaderMiddleware returns a middleware setting the header on every request
+func HeaderMiddleware(key, value string) Middleware {
+	return func(next RequestHandler) RequestHandler {
+		return func(req *http.Request) (*http.Response, error) {
+			req.Header.Set(key, value)
+			return next(req)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2023 Advanced Hosting
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ah
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMiddleware_Order(t *testing.T) {
+	var correlationID string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		correlationID = req.Header.Get("X-Correlation-Id")
+		_, _ = rw.Write([]byte(getResponse))
+	}))
+
+	var calls []string
+	trace := func(name string) Middleware {
+		return func(next RequestHandler) RequestHandler {
+			return func(req *http.Request) (*http.Response, error) {
+				calls = append(calls, name+":before")
+				resp, err := next(req)
+				calls = append(calls, name+":after")
+				return resp, err
+			}
+		}
+	}
+
+	options := newFakeClientOptions(server)
+	options.Middlewares = []Middleware{
+		trace("first"),
+		HeaderMiddleware("X-Correlation-Id", "test-correlation-id"),
+		trace("second"),
+	}
+	api, _ := NewAPIClient(options)
+
+	ctx := context.Background()
+	if _, err := api.Instances.Get(ctx, "test"); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	expectedCalls := []string{"first:before", "second:before", "second:after", "first:after"}
+	if !reflect.DeepEqual(expectedCalls, calls) {
+		t.Errorf("unexpected calls, expected %v. got: %v", expectedCalls, calls)
+	}
+
+	if correlationID != "test-correlation-id" {
+		t.Errorf("Unexpected correlation id %s", correlationID)
+	}
+}
+
+func TestMiddleware_ShortCircuit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		t.Errorf("Request shouldn't be sent")
+	}))
+
+	cached := func(next RequestHandler) RequestHandler {
+		return func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{},
+				Body:       io.NopCloser(strings.NewReader(getResponse)),
+			}, nil
+		}
+	}
+
+	options := newFakeClientOptions(server)
+	options.Middlewares = []Middleware{cached}
+	api, _ := NewAPIClient(options)
+
+	ctx := context.Background()
+	instance, err := api.Instances.Get(ctx, "test")
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	if instance == nil || instance.ID != "2a758843-b82c-435d-b2b2-65581361345b" {
+		t.Errorf("Unexpected instance %v", instance)
+	}
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2023 Advanced Hosting
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ah
+
+import (
+	"context"
+	"iter"
+)
+
+type listPageFunc[T any] func(context.Context, *ListOptions, ...RequestOption) ([]T, *Meta, error)
+
+// paginate returns an iterator fetching pages lazily starting from the page set in options
+func paginate[T any](ctx context.Context, options *ListOptions, list listPageFunc[T], opts ...RequestOption) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		pageOptions := &ListOptions{}
+		meta := &ListMetaOptions{Page: 1}
+		if options != nil {
+			*pageOptions = *options
+			if options.Meta != nil {
+				*meta = *options.Meta
+			}
+		}
+		if meta.Page < 1 {
+			meta.Page = 1
+		}
+		pageOptions.Meta = meta
+
+		for {
+			if err := ctx.Err(); err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+
+			items, pageMeta, err := list(ctx, pageOptions, opts...)
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+
+			if len(items) == 0 || isLastPage(pageMeta, meta.PerPage, len(items)) {
+				return
+			}
+			meta.Page++
+		}
+	}
+}
+
+// isLastPage reports whether the fetched page is the last one. Without the response metadata
+// only a page shorter than the requested page size is the last one, or any page if the size isn't set.
+func isLastPage(meta *Meta, perPage, count int) bool {
+	if meta != nil {
+		return meta.IsLastPage()
+	}
+	return perPage <= 0 || count < perPage
+}
+
+// ListAll collects all items of the iterator. It stops on the first error.
+func ListAll[T any](seq iter.Seq2[T, error]) ([]T, error) {
+	var result []T
+	for item, err := range seq {
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, item)
+	}
+	return result, nil
+}
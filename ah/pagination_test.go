@@ -0,0 +1,149 @@
+/*
+Copyright 2023 Advanced Hosting
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ah
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func newFakePagedServer(total, perPage int, requests *int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		*requests++
+		page := 1
+		fmt.Sscanf(req.URL.Query().Get("page"), "%d", &page)
+
+		var keys []string
+		for i := (page-1)*perPage + 1; i <= page*perPage && i <= total; i++ {
+			keys = append(keys, fmt.Sprintf(`{"id": "%d"}`, i))
+		}
+		body := fmt.Sprintf(`{"ssh_keys": [%s], "meta": {"page": %d, "per_page": %d, "total": %d}}`,
+			strings.Join(keys, ","), page, perPage, total)
+		_, _ = rw.Write([]byte(body))
+	}))
+}
+
+func TestPagination_All(t *testing.T) {
+	var requests int
+	server := newFakePagedServer(5, 2, &requests)
+	api, _ := NewAPIClient(newFakeClientOptions(server))
+
+	ctx := context.Background()
+	var ids []string
+	for key, err := range api.SSHKeys.All(ctx, nil) {
+		if err != nil {
+			t.Fatalf("Unexpected error %v", err)
+		}
+		ids = append(ids, key.ID)
+	}
+
+	if len(ids) != 5 || ids[0] != "1" || ids[4] != "5" {
+		t.Errorf("Unexpected result %v", ids)
+	}
+
+	if requests != 3 {
+		t.Errorf("Expected 3 requests, got %d", requests)
+	}
+}
+
+func TestPagination_Break(t *testing.T) {
+	var requests int
+	server := newFakePagedServer(10, 2, &requests)
+	api, _ := NewAPIClient(newFakeClientOptions(server))
+
+	ctx := context.Background()
+	for key, err := range api.SSHKeys.All(ctx, nil) {
+		if err != nil {
+			t.Fatalf("Unexpected error %v", err)
+		}
+		if key.ID == "3" {
+			break
+		}
+	}
+
+	if requests != 2 {
+		t.Errorf("Expected 2 requests, got %d", requests)
+	}
+}
+
+func TestPagination_ListAll(t *testing.T) {
+	var requests int
+	server := newFakePagedServer(5, 2, &requests)
+	api, _ := NewAPIClient(newFakeClientOptions(server))
+
+	ctx := context.Background()
+	options := &ListOptions{Meta: &ListMetaOptions{Page: 2}}
+	keys, err := ListAll(api.SSHKeys.All(ctx, options))
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	if len(keys) != 3 {
+		t.Errorf("Expected 3 keys, got %d", len(keys))
+	}
+
+	if options.Meta.Page != 2 {
+		t.Errorf("Options shouldn't be modified")
+	}
+}
+
+func TestPagination_Error(t *testing.T) {
+	fakeResponse := &fakeServerResponse{responseBody: "", statusCode: 500}
+	api, _ := newFakeAPIClient("/api/v1/instances", fakeResponse)
+
+	ctx := context.Background()
+	_, err := ListAll(api.Instances.All(ctx, nil))
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Errorf("Unexpected error %v", err)
+	}
+}
+
+func TestPagination_WithoutMeta(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		requests++
+		page := 1
+		fmt.Sscanf(req.URL.Query().Get("page"), "%d", &page)
+		if page < 3 {
+			_, _ = rw.Write([]byte(`{"ssh_keys": [{"id": "1"}, {"id": "2"}]}`))
+			return
+		}
+		_, _ = rw.Write([]byte(`{"ssh_keys": [{"id": "5"}]}`))
+	}))
+	api, _ := NewAPIClient(newFakeClientOptions(server))
+
+	ctx := context.Background()
+	keys, err := ListAll(api.SSHKeys.All(ctx, &ListOptions{Meta: &ListMetaOptions{PerPage: 2}}))
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if len(keys) != 5 || requests != 3 {
+		t.Errorf("Expected 5 keys in 3 requests, got %d in %d", len(keys), requests)
+	}
+
+	requests = 0
+	if _, err := ListAll(api.SSHKeys.All(ctx, nil)); err != nil || requests != 1 {
+		t.Errorf("Expected a single page without page size, got %d requests, %v", requests, err)
+	}
+}
@@ -19,18 +19,19 @@ package ah
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 )
 
 // PrivateNetwork object
 type PrivateNetwork struct {
-	ID             string `json:"id,omitempty"`
-	Number         string `json:"number,omitempty"`
-	CIDR           string `json:"cidr,omitempty"`
-	Name           string `json:"name,omitempty"`
-	State          string `json:"state,omitempty"`
-	CreatedAt      string `json:"created_at,omitempty"`
-	InstancesCount int    `json:"instances_count,omitempty"`
+	ID             string    `json:"id,omitempty"`
+	Number         string    `json:"number,omitempty"`
+	CIDR           string    `json:"cidr,omitempty"`
+	Name           string    `json:"name,omitempty"`
+	State          string    `json:"state,omitempty"`
+	CreatedAt      Timestamp `json:"created_at,omitempty"`
+	InstancesCount int       `json:"instances_count,omitempty"`
 }
 
 // PrivateNetworkInfo object
@@ -41,11 +42,13 @@ type PrivateNetworkInfo struct {
 
 // PrivateNetworksAPI is an interface for private networks.
 type PrivateNetworksAPI interface {
-	List(context.Context, *ListOptions) ([]PrivateNetwork, error)
-	Get(context.Context, string) (*PrivateNetworkInfo, error)
-	Create(context.Context, *PrivateNetworkCreateRequest) (*PrivateNetworkInfo, error)
-	Update(context.Context, string, *PrivateNetworkUpdateRequest) (*PrivateNetworkInfo, error)
-	Delete(context.Context, string) error
+	List(context.Context, *ListOptions, ...RequestOption) ([]PrivateNetwork, error)
+	ListPage(context.Context, *ListOptions, ...RequestOption) ([]PrivateNetwork, *Meta, error)
+	All(context.Context, *ListOptions, ...RequestOption) iter.Seq2[PrivateNetwork, error]
+	Get(context.Context, string, ...RequestOption) (*PrivateNetworkInfo, error)
+	Create(context.Context, *PrivateNetworkCreateRequest, ...RequestOption) (*PrivateNetworkInfo, error)
+	Update(context.Context, string, *PrivateNetworkUpdateRequest, ...RequestOption) (*PrivateNetworkInfo, error)
+	Delete(context.Context, string, ...RequestOption) error
 }
 
 // PrivateNetworksService implements PrivateNetworksAPI interface.
@@ -54,20 +57,40 @@ type PrivateNetworksService struct {
 }
 
 type privateNetworksRoot struct {
+	Meta            *Meta            `json:"meta,omitempty"`
 	PrivateNetworks []PrivateNetwork `json:"private_networks,omitempty"`
 }
 
 // List returns all available private networks
-func (pns *PrivateNetworksService) List(ctx context.Context, options *ListOptions) ([]PrivateNetwork, error) {
+//
+// Deprecated: Please use ListPage instead.
+func (pns *PrivateNetworksService) List(ctx context.Context, options *ListOptions, opts ...RequestOption) ([]PrivateNetwork, error) {
+	ctx = withOperation(ctx, "PrivateNetworks.List")
+	privateNetworks, _, err := pns.listPage(ctx, options, opts...)
+	return privateNetworks, err
+}
+
+// ListPage returns a page of private networks with pagination metadata
+func (pns *PrivateNetworksService) ListPage(ctx context.Context, options *ListOptions, opts ...RequestOption) ([]PrivateNetwork, *Meta, error) {
+	return pns.listPage(withOperation(ctx, "PrivateNetworks.ListPage"), options, opts...)
+}
+
+// listPage is ListPage without the operation name, so that wrappers keep their own
+func (pns *PrivateNetworksService) listPage(ctx context.Context, options *ListOptions, opts ...RequestOption) ([]PrivateNetwork, *Meta, error) {
 	path := "api/v1/private_networks"
 
 	var pnsRoot privateNetworksRoot
 
-	if err := pns.client.list(ctx, path, options, &pnsRoot); err != nil {
-		return nil, err
+	if err := pns.client.list(ctx, path, options, &pnsRoot, opts...); err != nil {
+		return nil, nil, err
 	}
 
-	return pnsRoot.PrivateNetworks, nil
+	return pnsRoot.PrivateNetworks, pnsRoot.Meta, nil
+}
+
+// All returns an iterator over all private networks fetching pages lazily
+func (pns *PrivateNetworksService) All(ctx context.Context, options *ListOptions, opts ...RequestOption) iter.Seq2[PrivateNetwork, error] {
+	return paginate(withOperation(ctx, "PrivateNetworks.All"), options, pns.listPage, opts...)
 }
 
 type privateNetworkInfoRoot struct {
@@ -75,9 +98,10 @@ type privateNetworkInfoRoot struct {
 }
 
 // Get private network info
-func (pns *PrivateNetworksService) Get(ctx context.Context, privateNetworkID string) (*PrivateNetworkInfo, error) {
+func (pns *PrivateNetworksService) Get(ctx context.Context, privateNetworkID string, opts ...RequestOption) (*PrivateNetworkInfo, error) {
+	ctx = withOperation(ctx, "PrivateNetworks.Get")
 	path := fmt.Sprintf("api/v1/private_networks/%s", privateNetworkID)
-	req, err := pns.client.newRequest(http.MethodGet, path, nil)
+	req, err := pns.client.newRequest(http.MethodGet, path, nil, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -106,12 +130,13 @@ type PrivateNetworkCreateRequest struct {
 }
 
 // Create private network
-func (pns *PrivateNetworksService) Create(ctx context.Context, createRequest *PrivateNetworkCreateRequest) (*PrivateNetworkInfo, error) {
+func (pns *PrivateNetworksService) Create(ctx context.Context, createRequest *PrivateNetworkCreateRequest, opts ...RequestOption) (*PrivateNetworkInfo, error) {
+	ctx = withOperation(ctx, "PrivateNetworks.Create")
 
 	type request struct {
 		PrivateNetwork *PrivateNetworkCreateRequest `json:"private_network"`
 	}
-	req, err := pns.client.newRequest(http.MethodPost, "api/v1/private_networks", &request{createRequest})
+	req, err := pns.client.newRequest(http.MethodPost, "api/v1/private_networks", &request{createRequest}, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -131,9 +156,10 @@ type PrivateNetworkUpdateRequest struct {
 }
 
 // Update private network
-func (pns *PrivateNetworksService) Update(ctx context.Context, privateNetworkID string, request *PrivateNetworkUpdateRequest) (*PrivateNetworkInfo, error) {
+func (pns *PrivateNetworksService) Update(ctx context.Context, privateNetworkID string, request *PrivateNetworkUpdateRequest, opts ...RequestOption) (*PrivateNetworkInfo, error) {
+	ctx = withOperation(ctx, "PrivateNetworks.Update")
 	path := fmt.Sprintf("api/v1/private_networks/%s", privateNetworkID)
-	req, err := pns.client.newRequest(http.MethodPut, path, request)
+	req, err := pns.client.newRequest(http.MethodPut, path, request, opts...)
 
 	if err != nil {
 		return nil, err
@@ -148,9 +174,10 @@ func (pns *PrivateNetworksService) Update(ctx context.Context, privateNetworkID
 }
 
 // Delete private network
-func (pns *PrivateNetworksService) Delete(ctx context.Context, privateNetworkID string) error {
+func (pns *PrivateNetworksService) Delete(ctx context.Context, privateNetworkID string, opts ...RequestOption) error {
+	ctx = withOperation(ctx, "PrivateNetworks.Delete")
 	path := fmt.Sprintf("api/v1/private_networks/%s", privateNetworkID)
-	req, err := pns.client.newRequest(http.MethodDelete, path, nil)
+	req, err := pns.client.newRequest(http.MethodDelete, path, nil, opts...)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,537 @@
+/*
+Copyright 2023 Advanced Hosting
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ah
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// ErrInvalidSortField is returned by Build of query builders sorting by a field the resource can't be sorted by
+var ErrInvalidSortField = errors.New("invalid sort field")
+
+// SortOrder is the direction of sorting
+type SortOrder string
+
+// Sort orders
+const (
+	Asc  SortOrder = "asc"
+	Desc SortOrder = "desc"
+)
+
+// queryBuilder builds ListOptions of a resource. Resource builders embed it
+// and add methods for the Ransack attributes valid for the resource.
+type queryBuilder[B any] struct {
+	self     *B
+	resource string
+	sortable []string
+	options  ListOptions
+	// err is the first error of the builder methods returned by Build
+	err error
+}
+
+func (q *queryBuilder[B]) init(self *B, resource string, sortable ...string) {
+	q.self = self
+	q.resource = resource
+	q.sortable = sortable
+}
+
+func (q *queryBuilder[B]) where(key string, predicate Predicate, values ...string) *B {
+	q.options.Filters = append(q.options.Filters, &Filter{Keys: []string{key}, Predicate: predicate, Values: values})
+	return q.self
+}
+
+func (q *queryBuilder[B]) whereTime(key string, predicate Predicate, t time.Time) *B {
+	return q.where(key, predicate, t.UTC().Format(time.RFC3339))
+}
+
+func (q *queryBuilder[B]) whereInt(key string, predicate Predicate, value int) *B {
+	return q.where(key, predicate, strconv.Itoa(value))
+}
+
+func (q *queryBuilder[B]) whereBool(key string, value bool) *B {
+	return q.where(key, PredicateEq, strconv.FormatBool(value))
+}
+
+// stringValues converts typed values, e.g. states, to strings
+func stringValues[S ~string](values []S) []string {
+	result := make([]string, len(values))
+	for i, value := range values {
+		result[i] = string(value)
+	}
+	return result
+}
+
+// SortBy adds sorting by the field, sortings are applied in the order they are added.
+// Build returns ErrInvalidSortField if the resource can't be sorted by the field.
+func (q *queryBuilder[B]) SortBy(field string, order SortOrder) *B {
+	if !slices.Contains(q.sortable, field) {
+		if q.err == nil {
+			q.err = fmt.Errorf("%w: %s can't be sorted by %q, sortable fields: %v", ErrInvalidSortField, q.resource, field, q.sortable)
+		}
+		return q.self
+	}
+	q.options.Sortings = append(q.options.Sortings, &Sorting{Key: field, Order: string(order)})
+	return q.self
+}
+
+// Page sets the page number
+func (q *queryBuilder[B]) Page(page int) *B {
+	q.meta().Page = page
+	return q.self
+}
+
+// PerPage sets the page size
+func (q *queryBuilder[B]) PerPage(perPage int) *B {
+	q.meta().PerPage = perPage
+	return q.self
+}
+
+func (q *queryBuilder[B]) meta() *ListMetaOptions {
+	if q.options.Meta == nil {
+		q.options.Meta = &ListMetaOptions{}
+	}
+	return q.options.Meta
+}
+
+// Build returns ListOptions or the first error of the builder methods.
+// The builder can be modified and built again.
+func (q *queryBuilder[B]) Build() (*ListOptions, error) {
+	if q.err != nil {
+		return nil, q.err
+	}
+	options := &ListOptions{
+		Filters:  slices.Clone(q.options.Filters),
+		Sortings: slices.Clone(q.options.Sortings),
+	}
+	if q.options.Meta != nil {
+		meta := *q.options.Meta
+		options.Meta = &meta
+	}
+	return options, nil
+}
+
+// InstanceQueryBuilder builds ListOptions of instances
+type InstanceQueryBuilder struct {
+	queryBuilder[InstanceQueryBuilder]
+}
+
+// InstanceQuery returns a builder of ListOptions for InstancesAPI.List
+func InstanceQuery() *InstanceQueryBuilder {
+	b := &InstanceQueryBuilder{}
+	b.init(b, "instance", "id", "number", "name", "state", "vcpu", "ram", "disk", "created_at", "updated_at")
+	return b
+}
+
+// IDIn filters instances by IDs
+func (b *InstanceQueryBuilder) IDIn(ids ...string) *InstanceQueryBuilder {
+	return b.where("id", PredicateIn, ids...)
+}
+
+// NameEq filters instances by name
+func (b *InstanceQueryBuilder) NameEq(name string) *InstanceQueryBuilder {
+	return b.where("name", PredicateEq, name)
+}
+
+// NameContains filters instances which name contains the value
+func (b *InstanceQueryBuilder) NameContains(value string) *InstanceQueryBuilder {
+	return b.where("name", PredicateCont, value)
+}
+
+// StateIn filters instances in any of the states
+func (b *InstanceQueryBuilder) StateIn(states ...InstanceState) *InstanceQueryBuilder {
+	return b.where("state", PredicateIn, stringValues(states)...)
+}
+
+// StateNotIn filters instances in none of the states
+func (b *InstanceQueryBuilder) StateNotIn(states ...InstanceState) *InstanceQueryBuilder {
+	return b.where("state", PredicateNotIn, stringValues(states)...)
+}
+
+// TagsAny filters instances having any of the tags
+func (b *InstanceQueryBuilder) TagsAny(tags ...string) *InstanceQueryBuilder {
+	return b.where("tags", PredicateIn, tags...)
+}
+
+// TagsAll filters instances having all of the tags
+func (b *InstanceQueryBuilder) TagsAll(tags ...string) *InstanceQueryBuilder {
+	return b.where("tags", PredicateEq.All(), tags...)
+}
+
+// VcpuGte filters instances with at least vcpu cores
+func (b *InstanceQueryBuilder) VcpuGte(vcpu int) *InstanceQueryBuilder {
+	return b.whereInt("vcpu", PredicateGteq, vcpu)
+}
+
+// RAMGte filters instances with at least ram MB of memory
+func (b *InstanceQueryBuilder) RAMGte(ram int) *InstanceQueryBuilder {
+	return b.whereInt("ram", PredicateGteq, ram)
+}
+
+// DiskGte filters instances with at least disk GB of disk
+func (b *InstanceQueryBuilder) DiskGte(disk int) *InstanceQueryBuilder {
+	return b.whereInt("disk", PredicateGteq, disk)
+}
+
+// Locked filters locked or unlocked instances
+func (b *InstanceQueryBuilder) Locked(locked bool) *InstanceQueryBuilder {
+	return b.whereBool("locked", locked)
+}
+
+// CreatedAfter filters instances created after t
+func (b *InstanceQueryBuilder) CreatedAfter(t time.Time) *InstanceQueryBuilder {
+	return b.whereTime("created_at", PredicateGt, t)
+}
+
+// CreatedBefore filters instances created before t
+func (b *InstanceQueryBuilder) CreatedBefore(t time.Time) *InstanceQueryBuilder {
+	return b.whereTime("created_at", PredicateLt, t)
+}
+
+// VolumeQueryBuilder builds ListOptions of volumes
+type VolumeQueryBuilder struct {
+	queryBuilder[VolumeQueryBuilder]
+}
+
+// VolumeQuery returns a builder of ListOptions for VolumesAPI.List
+func VolumeQuery() *VolumeQueryBuilder {
+	b := &VolumeQueryBuilder{}
+	b.init(b, "volume", "id", "number", "name", "state", "size", "created_at")
+	return b
+}
+
+// IDIn filters volumes by IDs
+func (b *VolumeQueryBuilder) IDIn(ids ...string) *VolumeQueryBuilder {
+	return b.where("id", PredicateIn, ids...)
+}
+
+// NameEq filters volumes by name
+func (b *VolumeQueryBuilder) NameEq(name string) *VolumeQueryBuilder {
+	return b.where("name", PredicateEq, name)
+}
+
+// NameContains filters volumes which name contains the value
+func (b *VolumeQueryBuilder) NameContains(value string) *VolumeQueryBuilder {
+	return b.where("name", PredicateCont, value)
+}
+
+// StateIn filters volumes in any of the states
+func (b *VolumeQueryBuilder) StateIn(states ...string) *VolumeQueryBuilder {
+	return b.where("state", PredicateIn, states...)
+}
+
+// StateNotIn filters volumes in none of the states
+func (b *VolumeQueryBuilder) StateNotIn(states ...string) *VolumeQueryBuilder {
+	return b.where("state", PredicateNotIn, states...)
+}
+
+// FileSystemEq filters volumes by file system
+func (b *VolumeQueryBuilder) FileSystemEq(fileSystem string) *VolumeQueryBuilder {
+	return b.where("file_system", PredicateEq, fileSystem)
+}
+
+// SizeGte filters volumes of at least size GB
+func (b *VolumeQueryBuilder) SizeGte(size int) *VolumeQueryBuilder {
+	return b.whereInt("size", PredicateGteq, size)
+}
+
+// SizeLte filters volumes of at most size GB
+func (b *VolumeQueryBuilder) SizeLte(size int) *VolumeQueryBuilder {
+	return b.whereInt("size", PredicateLteq, size)
+}
+
+// CreatedAfter filters volumes created after t
+func (b *VolumeQueryBuilder) CreatedAfter(t time.Time) *VolumeQueryBuilder {
+	return b.whereTime("created_at", PredicateGt, t)
+}
+
+// CreatedBefore filters volumes created before t
+func (b *VolumeQueryBuilder) CreatedBefore(t time.Time) *VolumeQueryBuilder {
+	return b.whereTime("created_at", PredicateLt, t)
+}
+
+// IPAddressQueryBuilder builds ListOptions of ip addresses
+type IPAddressQueryBuilder struct {
+	queryBuilder[IPAddressQueryBuilder]
+}
+
+// IPAddressQuery returns a builder of ListOptions for IPAddressesAPI.List
+func IPAddressQuery() *IPAddressQueryBuilder {
+	b := &IPAddressQueryBuilder{}
+	b.init(b, "ip address", "id", "address", "address_type", "created_at", "updated_at")
+	return b
+}
+
+// IDIn filters ip addresses by IDs
+func (b *IPAddressQueryBuilder) IDIn(ids ...string) *IPAddressQueryBuilder {
+	return b.where("id", PredicateIn, ids...)
+}
+
+// AddressIn filters ip addresses by addresses
+func (b *IPAddressQueryBuilder) AddressIn(addresses ...string) *IPAddressQueryBuilder {
+	return b.where("address", PredicateIn, addresses...)
+}
+
+// TypeEq filters ip addresses by type, e.g. "public" or "private"
+func (b *IPAddressQueryBuilder) TypeEq(addressType string) *IPAddressQueryBuilder {
+	return b.where("address_type", PredicateEq, addressType)
+}
+
+// ReverseDNSContains filters ip addresses which reverse DNS contains the value
+func (b *IPAddressQueryBuilder) ReverseDNSContains(value string) *IPAddressQueryBuilder {
+	return b.where("reverse_dns", PredicateCont, value)
+}
+
+// PrivateNetworkQueryBuilder builds ListOptions of private networks
+type PrivateNetworkQueryBuilder struct {
+	queryBuilder[PrivateNetworkQueryBuilder]
+}
+
+// PrivateNetworkQuery returns a builder of ListOptions for PrivateNetworksAPI.List
+func PrivateNetworkQuery() *PrivateNetworkQueryBuilder {
+	b := &PrivateNetworkQueryBuilder{}
+	b.init(b, "private network", "id", "number", "name", "cidr", "state", "created_at")
+	return b
+}
+
+// IDIn filters private networks by IDs
+func (b *PrivateNetworkQueryBuilder) IDIn(ids ...string) *PrivateNetworkQueryBuilder {
+	return b.where("id", PredicateIn, ids...)
+}
+
+// NameEq filters private networks by name
+func (b *PrivateNetworkQueryBuilder) NameEq(name string) *PrivateNetworkQueryBuilder {
+	return b.where("name", PredicateEq, name)
+}
+
+// NameContains filters private networks which name contains the value
+func (b *PrivateNetworkQueryBuilder) NameContains(value string) *PrivateNetworkQueryBuilder {
+	return b.where("name", PredicateCont, value)
+}
+
+// CIDREq filters private networks by CIDR
+func (b *PrivateNetworkQueryBuilder) CIDREq(cidr string) *PrivateNetworkQueryBuilder {
+	return b.where("cidr", PredicateEq, cidr)
+}
+
+// StateIn filters private networks in any of the states
+func (b *PrivateNetworkQueryBuilder) StateIn(states ...string) *PrivateNetworkQueryBuilder {
+	return b.where("state", PredicateIn, states...)
+}
+
+// SSHKeyQueryBuilder builds ListOptions of ssh keys
+type SSHKeyQueryBuilder struct {
+	queryBuilder[SSHKeyQueryBuilder]
+}
+
+// SSHKeyQuery returns a builder of ListOptions for SSHKeysAPI.List
+func SSHKeyQuery() *SSHKeyQueryBuilder {
+	b := &SSHKeyQueryBuilder{}
+	b.init(b, "ssh key", "id", "name", "fingerprint", "created_at")
+	return b
+}
+
+// IDIn filters ssh keys by IDs
+func (b *SSHKeyQueryBuilder) IDIn(ids ...string) *SSHKeyQueryBuilder {
+	return b.where("id", PredicateIn, ids...)
+}
+
+// NameEq filters ssh keys by name
+func (b *SSHKeyQueryBuilder) NameEq(name string) *SSHKeyQueryBuilder {
+	return b.where("name", PredicateEq, name)
+}
+
+// NameContains filters ssh keys which name contains the value
+func (b *SSHKeyQueryBuilder) NameContains(value string) *SSHKeyQueryBuilder {
+	return b.where("name", PredicateCont, value)
+}
+
+// FingerprintEq filters ssh keys by fingerprint
+func (b *SSHKeyQueryBuilder) FingerprintEq(fingerprint string) *SSHKeyQueryBuilder {
+	return b.where("fingerprint", PredicateEq, fingerprint)
+}
+
+// BackupQueryBuilder builds ListOptions of backups
+type BackupQueryBuilder struct {
+	queryBuilder[BackupQueryBuilder]
+}
+
+// BackupQuery returns a builder of ListOptions for BackupsAPI.List
+func BackupQuery() *BackupQueryBuilder {
+	b := &BackupQueryBuilder{}
+	b.init(b, "backup", "id", "name", "status", "size", "created_at", "updated_at")
+	return b
+}
+
+// IDIn filters backups by IDs
+func (b *BackupQueryBuilder) IDIn(ids ...string) *BackupQueryBuilder {
+	return b.where("id", PredicateIn, ids...)
+}
+
+// NameContains filters backups which name contains the value
+func (b *BackupQueryBuilder) NameContains(value string) *BackupQueryBuilder {
+	return b.where("name", PredicateCont, value)
+}
+
+// StatusIn filters backups in any of the statuses
+func (b *BackupQueryBuilder) StatusIn(statuses ...string) *BackupQueryBuilder {
+	return b.where("status", PredicateIn, statuses...)
+}
+
+// TypeEq filters backups by type
+func (b *BackupQueryBuilder) TypeEq(backupType string) *BackupQueryBuilder {
+	return b.where("type", PredicateEq, backupType)
+}
+
+// InstanceIDIn filters backups of the instances
+func (b *BackupQueryBuilder) InstanceIDIn(instanceIDs ...string) *BackupQueryBuilder {
+	return b.where("instance_id", PredicateIn, instanceIDs...)
+}
+
+// CreatedAfter filters backups created after t
+func (b *BackupQueryBuilder) CreatedAfter(t time.Time) *BackupQueryBuilder {
+	return b.whereTime("created_at", PredicateGt, t)
+}
+
+// CreatedBefore filters backups created before t
+func (b *BackupQueryBuilder) CreatedBefore(t time.Time) *BackupQueryBuilder {
+	return b.whereTime("created_at", PredicateLt, t)
+}
+
+// ImageQueryBuilder builds ListOptions of images
+type ImageQueryBuilder struct {
+	queryBuilder[ImageQueryBuilder]
+}
+
+// ImageQuery returns a builder of ListOptions for ImagesAPI.List
+func ImageQuery() *ImageQueryBuilder {
+	b := &ImageQueryBuilder{}
+	b.init(b, "image", "id", "name", "distribution", "version", "created_at")
+	return b
+}
+
+// NameContains filters images which name contains the value
+func (b *ImageQueryBuilder) NameContains(value string) *ImageQueryBuilder {
+	return b.where("name", PredicateCont, value)
+}
+
+// SlugEq filters images by slug
+func (b *ImageQueryBuilder) SlugEq(slug string) *ImageQueryBuilder {
+	return b.where("slug", PredicateEq, slug)
+}
+
+// DistributionEq filters images by distribution
+func (b *ImageQueryBuilder) DistributionEq(distribution string) *ImageQueryBuilder {
+	return b.where("distribution", PredicateEq, distribution)
+}
+
+// VersionEq filters images by distribution version
+func (b *ImageQueryBuilder) VersionEq(version string) *ImageQueryBuilder {
+	return b.where("version", PredicateEq, version)
+}
+
+// ArchitectureEq filters images by architecture
+func (b *ImageQueryBuilder) ArchitectureEq(architecture string) *ImageQueryBuilder {
+	return b.where("architecture", PredicateEq, architecture)
+}
+
+// KubernetesClusterQueryBuilder builds ListOptions of kubernetes clusters
+type KubernetesClusterQueryBuilder struct {
+	queryBuilder[KubernetesClusterQueryBuilder]
+}
+
+// KubernetesClusterQuery returns a builder of ListOptions for KubernetesClustersAPI.List
+func KubernetesClusterQuery() *KubernetesClusterQueryBuilder {
+	b := &KubernetesClusterQueryBuilder{}
+	b.init(b, "kubernetes cluster", "id", "number", "name", "state", "created_at")
+	return b
+}
+
+// IDIn filters kubernetes clusters by IDs
+func (b *KubernetesClusterQueryBuilder) IDIn(ids ...string) *KubernetesClusterQueryBuilder {
+	return b.where("id", PredicateIn, ids...)
+}
+
+// NameEq filters kubernetes clusters by name
+func (b *KubernetesClusterQueryBuilder) NameEq(name string) *KubernetesClusterQueryBuilder {
+	return b.where("name", PredicateEq, name)
+}
+
+// NameContains filters kubernetes clusters which name contains the value
+func (b *KubernetesClusterQueryBuilder) NameContains(value string) *KubernetesClusterQueryBuilder {
+	return b.where("name", PredicateCont, value)
+}
+
+// StateIn filters kubernetes clusters in any of the states
+func (b *KubernetesClusterQueryBuilder) StateIn(states ...string) *KubernetesClusterQueryBuilder {
+	return b.where("state", PredicateIn, states...)
+}
+
+// K8sVersionEq filters kubernetes clusters by kubernetes version
+func (b *KubernetesClusterQueryBuilder) K8sVersionEq(version string) *KubernetesClusterQueryBuilder {
+	return b.where("k8s_version", PredicateEq, version)
+}
+
+// DatacenterIDEq filters kubernetes clusters by datacenter
+func (b *KubernetesClusterQueryBuilder) DatacenterIDEq(datacenterID string) *KubernetesClusterQueryBuilder {
+	return b.where("datacenter_id", PredicateEq, datacenterID)
+}
+
+// LoadBalancerQueryBuilder builds ListOptions of load balancers
+type LoadBalancerQueryBuilder struct {
+	queryBuilder[LoadBalancerQueryBuilder]
+}
+
+// LoadBalancerQuery returns a builder of ListOptions for LoadBalancersAPI.ListPage
+func LoadBalancerQuery() *LoadBalancerQueryBuilder {
+	b := &LoadBalancerQueryBuilder{}
+	b.init(b, "load balancer", "id", "name", "state", "balancing_algorithm")
+	return b
+}
+
+// IDIn filters load balancers by IDs
+func (b *LoadBalancerQueryBuilder) IDIn(ids ...string) *LoadBalancerQueryBuilder {
+	return b.where("id", PredicateIn, ids...)
+}
+
+// NameEq filters load balancers by name
+func (b *LoadBalancerQueryBuilder) NameEq(name string) *LoadBalancerQueryBuilder {
+	return b.where("name", PredicateEq, name)
+}
+
+// NameContains filters load balancers which name contains the value
+func (b *LoadBalancerQueryBuilder) NameContains(value string) *LoadBalancerQueryBuilder {
+	return b.where("name", PredicateCont, value)
+}
+
+// StateIn filters load balancers in any of the states
+func (b *LoadBalancerQueryBuilder) StateIn(states ...string) *LoadBalancerQueryBuilder {
+	return b.where("state", PredicateIn, states...)
+}
+
+// DatacenterIDEq filters load balancers by datacenter
+func (b *LoadBalancerQueryBuilder) DatacenterIDEq(datacenterID string) *LoadBalancerQueryBuilder {
+	return b.where("datacenter_id", PredicateEq, datacenterID)
+}
+
+// BalancingAlgorithmEq filters load balancers by balancing algorithm
+func (b *LoadBalancerQueryBuilder) BalancingAlgorithmEq(algorithm LBBalancingAlgorithm) *LoadBalancerQueryBuilder {
+	return b.where("balancing_algorithm", PredicateEq, string(algorithm))
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2023 Advanced Hosting
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ah
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestInstanceQuery(t *testing.T) {
+	options, err := InstanceQuery().
+		NameContains("web").
+		StateIn("running").
+		TagsAny("prod").
+		CreatedAfter(time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)).
+		SortBy("created_at", Desc).
+		SortBy("name", Asc).
+		Page(2).
+		PerPage(100).
+		Build()
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	expected := url.Values{
+		"page":             {"2"},
+		"per_page":         {"100"},
+		"q[name_cont]":     {"web"},
+		"q[state_in][]":    {"running"},
+		"q[tags_in][]":     {"prod"},
+		"q[created_at_gt]": {"2023-01-02T03:04:05Z"},
+		"q[s][]":           {"created_at desc", "name asc"},
+	}
+	query := buildListQuery(options)
+
+	if query != expected.Encode() {
+		t.Fatalf("Wrong query. Expected %s, got %s", expected.Encode(), query)
+	}
+}
+
+func TestQueryBuilder_BuildCopies(t *testing.T) {
+	builder := VolumeQuery().NameEq("data").Page(1)
+	first, _ := builder.Build()
+	second, _ := builder.SizeGte(10).Page(3).Build()
+
+	if len(first.Filters) != 1 || first.Meta.Page != 1 {
+		t.Errorf("Built options changed by the builder: %+v", first)
+	}
+	if len(second.Filters) != 2 || second.Meta.Page != 3 {
+		t.Errorf("Unexpected options %+v", second)
+	}
+}
+
+func TestQueryBuilder_InvalidSortField(t *testing.T) {
+	options, err := SSHKeyQuery().SortBy("nmae", Asc).SortBy("name", Asc).Build()
+	if !errors.Is(err, ErrInvalidSortField) || options != nil {
+		t.Errorf("Unexpected error %v", err)
+	}
+}
+
+func TestLoadBalancerQuery(t *testing.T) {
+	options, err := LoadBalancerQuery().
+		StateIn("active").
+		BalancingAlgorithmEq(LBBalancingAlgorithmRoundRobin).
+		SortBy("name", Asc).
+		Build()
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	expected := url.Values{
+		"q[state_in][]":             {"active"},
+		"q[balancing_algorithm_eq]": {"round_robin"},
+		"q[s]":                      {"name asc"},
+	}
+	query := buildListQuery(options)
+
+	if query != expected.Encode() {
+		t.Fatalf("Wrong query. Expected %s, got %s", expected.Encode(), query)
+	}
+
+	if _, err := LoadBalancerQuery().SortBy("created_at", Desc).Build(); !errors.Is(err, ErrInvalidSortField) {
+		t.Errorf("Unexpected error %v", err)
+	}
+}
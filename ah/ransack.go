@@ -18,14 +18,113 @@ package ah
 
 import (
 	"fmt"
+	"net/url"
+	"sort"
 	"strings"
 )
 
+// Predicate is a Ransack predicate
+type Predicate string
+
+// Ransack predicates. Any and All methods return "*_any" and "*_all" variants matching several values.
+const (
+	PredicateEq      Predicate = "eq"
+	PredicateNotEq   Predicate = "not_eq"
+	PredicateLt      Predicate = "lt"
+	PredicateLteq    Predicate = "lteq"
+	PredicateGt      Predicate = "gt"
+	PredicateGteq    Predicate = "gteq"
+	PredicateCont    Predicate = "cont"
+	PredicateNotCont Predicate = "not_cont"
+	PredicateStart   Predicate = "start"
+	PredicateEnd     Predicate = "end"
+	PredicateMatches Predicate = "matches"
+	PredicateIn      Predicate = "in"
+	PredicateNotIn   Predicate = "not_in"
+	PredicateNull    Predicate = "null"
+	PredicateNotNull Predicate = "not_null"
+	PredicatePresent Predicate = "present"
+	PredicateBlank   Predicate = "blank"
+)
+
+// Any returns the "*_any" variant of the predicate matching any of the values
+func (p Predicate) Any() Predicate {
+	return p + "_any"
+}
+
+// All returns the "*_all" variant of the predicate matching all of the values
+func (p Predicate) All() Predicate {
+	return p + "_all"
+}
+
+// multiValued reports whether the predicate takes an array of values
+func (p Predicate) multiValued() bool {
+	switch p {
+	case PredicateIn, PredicateNotIn:
+		return true
+	}
+	return strings.HasSuffix(string(p), "_any") || strings.HasSuffix(string(p), "_all")
+}
+
+// boolean reports whether the predicate takes a boolean flag instead of a value
+func (p Predicate) boolean() bool {
+	switch p {
+	case PredicateNull, PredicateNotNull, PredicatePresent, PredicateBlank:
+		return true
+	}
+	return false
+}
+
+// Combinator joins conditions of a FilterGroup
+type Combinator string
+
+// Ransack combinators
+const (
+	CombinatorAnd Combinator = "and"
+	CombinatorOr  Combinator = "or"
+)
+
 // FilterInterface is an interface for Filter.
 type FilterInterface interface {
+	// Encode returns escaped "key=value" pairs of the filter
 	Encode() []string
 }
 
+// ValuesEncoder is implemented by filters which can be nested in FilterGroup.
+// Filters implementing only FilterInterface are added using pairs of Encode.
+type ValuesEncoder interface {
+	// EncodeValues adds the filter parameters to values under the prefix, e.g. "q" or "q[g][0]"
+	EncodeValues(prefix string, values url.Values)
+}
+
+// Filter represents Ransack condition with any predicate.
+// Boolean predicates like null and present default to "true" if Values is empty.
+type Filter struct {
+	Predicate Predicate
+	Keys      []string
+	Values    []string
+}
+
+// Encode returns Ransack filter expression
+func (f *Filter) Encode() []string {
+	return encodeFilter(f)
+}
+
+// EncodeValues adds Ransack filter parameters to values
+func (f *Filter) EncodeValues(prefix string, values url.Values) {
+	key := fmt.Sprintf("%s[%s_%s]", prefix, strings.Join(f.Keys, "_or_"), f.Predicate)
+	switch {
+	case f.Predicate.multiValued():
+		for _, value := range f.Values {
+			values.Add(key+"[]", value)
+		}
+	case len(f.Values) > 0:
+		values.Set(key, f.Values[0])
+	case f.Predicate.boolean():
+		values.Set(key, "true")
+	}
+}
+
 // InFilter represents Ransack "*_in" filter .
 type InFilter struct {
 	Keys   []string
@@ -34,12 +133,12 @@ type InFilter struct {
 
 // Encode returns Ransack "*_in" filter expression
 func (f *InFilter) Encode() []string {
-	var query []string
-	keys := strings.Join(f.Keys, "_or_")
-	for _, value := range f.Values {
-		query = append(query, fmt.Sprintf("q[%s_in][]=%s", keys, value))
-	}
-	return query
+	return encodeFilter(f)
+}
+
+// EncodeValues adds Ransack "*_in" filter parameters to values
+func (f *InFilter) EncodeValues(prefix string, values url.Values) {
+	(&Filter{Keys: f.Keys, Predicate: PredicateIn, Values: f.Values}).EncodeValues(prefix, values)
 }
 
 // EqFilter represents Ransack "*_eq" filter .
@@ -50,8 +149,12 @@ type EqFilter struct {
 
 // Encode returns Ransack "*_eq" filter expression
 func (f *EqFilter) Encode() []string {
-	keys := strings.Join(f.Keys, "_or_")
-	return []string{fmt.Sprintf("q[%s_eq]=%s", keys, f.Value)}
+	return encodeFilter(f)
+}
+
+// EncodeValues adds Ransack "*_eq" filter parameters to values
+func (f *EqFilter) EncodeValues(prefix string, values url.Values) {
+	(&Filter{Keys: f.Keys, Predicate: PredicateEq, Values: []string{f.Value}}).EncodeValues(prefix, values)
 }
 
 // ContFilter represents Ransack "*_cont" filter .
@@ -60,19 +163,108 @@ type ContFilter struct {
 	Keys  []string
 }
 
-// Encode returns Ransack "*_eq" filter expression
+// Encode returns Ransack "*_cont" filter expression
 func (f *ContFilter) Encode() []string {
-	keys := strings.Join(f.Keys, "_or_")
-	return []string{fmt.Sprintf("q[%s_cont]=%s", keys, f.Value)}
+	return encodeFilter(f)
+}
+
+// EncodeValues adds Ransack "*_cont" filter parameters to values
+func (f *ContFilter) EncodeValues(prefix string, values url.Values) {
+	(&Filter{Keys: f.Keys, Predicate: PredicateCont, Values: []string{f.Value}}).EncodeValues(prefix, values)
+}
+
+// FilterGroup represents Ransack grouping "g[]" of conditions joined with Combinator.
+// Groups can be nested. Conditions are joined with "and" if Combinator is empty.
+type FilterGroup struct {
+	Combinator Combinator
+	Filters    []FilterInterface
+}
+
+// Encode returns Ransack grouping expression
+func (g *FilterGroup) Encode() []string {
+	return encodeFilter(g)
+}
+
+// EncodeValues adds Ransack grouping parameters to values using the next free group index of the prefix
+func (g *FilterGroup) EncodeValues(prefix string, values url.Values) {
+	index := 0
+	for hasPrefix(values, fmt.Sprintf("%s[g][%d]", prefix, index)) {
+		index++
+	}
+	groupPrefix := fmt.Sprintf("%s[g][%d]", prefix, index)
+
+	if g.Combinator != "" {
+		values.Set(groupPrefix+"[m]", string(g.Combinator))
+	}
+	for _, filter := range g.Filters {
+		encodeValues(filter, groupPrefix, values)
+	}
+}
+
+// encodeValues adds the filter parameters to values under the prefix. Parameters of filters
+// which don't implement ValuesEncoder are parsed from Encode and moved from "q" to the prefix.
+func encodeValues(filter FilterInterface, prefix string, values url.Values) {
+	if encoder, ok := filter.(ValuesEncoder); ok {
+		encoder.EncodeValues(prefix, values)
+		return
+	}
+	for _, pair := range filter.Encode() {
+		parsed, err := url.ParseQuery(pair)
+		if err != nil {
+			continue
+		}
+		for key, items := range parsed {
+			if rest, ok := strings.CutPrefix(key, "q["); ok {
+				key = prefix + "[" + rest
+			}
+			values[key] = append(values[key], items...)
+		}
+	}
+}
+
+func hasPrefix(values url.Values, prefix string) bool {
+	for key := range values {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func encodeFilter(filter ValuesEncoder) []string {
+	values := url.Values{}
+	filter.EncodeValues("q", values)
+	return encodePairs(values)
+}
+
+// encodePairs returns escaped "key=value" pairs sorted by key
+func encodePairs(values url.Values) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var pairs []string
+	for _, key := range keys {
+		for _, value := range values[key] {
+			pairs = append(pairs, url.QueryEscape(key)+"="+url.QueryEscape(value))
+		}
+	}
+	return pairs
 }
 
 // BuildFilterQuery returns Ransack filter expression
 func BuildFilterQuery(filters []FilterInterface) string {
-	var query []string
+	values := url.Values{}
+	addFilters(values, filters)
+	return values.Encode()
+}
+
+func addFilters(values url.Values, filters []FilterInterface) {
 	for _, filter := range filters {
-		query = append(query, filter.Encode()...)
+		encodeValues(filter, "q", values)
 	}
-	return strings.Join(query, "&")
 }
 
 // Sorting represents Ransack sorting expression
@@ -83,14 +275,30 @@ type Sorting struct {
 
 // Encode returns Ransack sorting expression
 func (s *Sorting) Encode() []string {
-	return []string{fmt.Sprintf("q[s]=%s+%s", s.Key, s.Order)}
+	return []string{url.QueryEscape("q[s]") + "=" + url.QueryEscape(s.value())}
+}
+
+func (s *Sorting) value() string {
+	if s.Order == "" {
+		return s.Key
+	}
+	return s.Key + " " + s.Order
 }
 
-// BuildSortingQuery returns Ransack sorting expression
+// BuildSortingQuery returns Ransack sorting expression.
+// Several sortings are sent as "q[s][]" array, the first one has the highest priority.
 func BuildSortingQuery(sortings []*Sorting) string {
-	var query []string
+	values := url.Values{}
+	addSortings(values, sortings)
+	return values.Encode()
+}
+
+func addSortings(values url.Values, sortings []*Sorting) {
+	if len(sortings) == 1 {
+		values.Set("q[s]", sortings[0].value())
+		return
+	}
 	for _, sorting := range sortings {
-		query = append(query, sorting.Encode()...)
+		values.Add("q[s][]", sorting.value())
 	}
-	return strings.Join(query, "&")
 }
@@ -17,6 +17,7 @@ limitations under the License.
 package ah
 
 import (
+	"net/url"
 	"testing"
 )
 
@@ -28,7 +29,7 @@ func TestFilterIn_Basic(t *testing.T) {
 		},
 	}
 
-	expectedQuery := "q[test_in][]=1&q[test_in][]=2"
+	expectedQuery := "q%5Btest_in%5D%5B%5D=1&q%5Btest_in%5D%5B%5D=2"
 	query := BuildFilterQuery(filters)
 
 	if query != expectedQuery {
@@ -43,7 +44,7 @@ func TestFilterIn_MultipleKey(t *testing.T) {
 		},
 	}
 
-	expectedQuery := "q[test_or_test2_in][]=1&q[test_or_test2_in][]=2"
+	expectedQuery := "q%5Btest_or_test2_in%5D%5B%5D=1&q%5Btest_or_test2_in%5D%5B%5D=2"
 	query := BuildFilterQuery(filters)
 
 	if query != expectedQuery {
@@ -63,7 +64,7 @@ func TestSorting_Basic(t *testing.T) {
 		},
 	}
 
-	expectedQuery := "q[s]=test+asc&q[s]=test2+desc"
+	expectedQuery := "q%5Bs%5D%5B%5D=test+asc&q%5Bs%5D%5B%5D=test2+desc"
 	query := BuildSortingQuery(sortings)
 
 	if query != expectedQuery {
@@ -79,7 +80,7 @@ func TestFilterEq_Basic(t *testing.T) {
 		},
 	}
 
-	expectedQuery := "q[test_eq]=1"
+	expectedQuery := "q%5Btest_eq%5D=1"
 	query := BuildFilterQuery(filters)
 
 	if query != expectedQuery {
@@ -95,10 +96,115 @@ func TestFilterCont_Basic(t *testing.T) {
 		},
 	}
 
-	expectedQuery := "q[test_cont]=1"
+	expectedQuery := "q%5Btest_cont%5D=1"
 	query := BuildFilterQuery(filters)
 
 	if query != expectedQuery {
 		t.Fatalf("Wrong query. Expected %s, got %s", expectedQuery, query)
 	}
 }
+
+func TestFilter_Escaping(t *testing.T) {
+	filters := []FilterInterface{
+		&InFilter{
+			Keys:   []string{"tags"},
+			Values: []string{"dev & test", "a+b"},
+		},
+	}
+
+	expectedQuery := "q%5Btags_in%5D%5B%5D=dev+%26+test&q%5Btags_in%5D%5B%5D=a%2Bb"
+	query := BuildFilterQuery(filters)
+
+	if query != expectedQuery {
+		t.Fatalf("Wrong query. Expected %s, got %s", expectedQuery, query)
+	}
+}
+
+func TestFilter_Predicates(t *testing.T) {
+	filters := []FilterInterface{
+		&Filter{Keys: []string{"name"}, Predicate: PredicateNotEq, Values: []string{"test"}},
+		&Filter{Keys: []string{"disk_size"}, Predicate: PredicateGteq, Values: []string{"10"}},
+		&Filter{Keys: []string{"name"}, Predicate: PredicateStart.Any(), Values: []string{"web", "db"}},
+		&Filter{Keys: []string{"tags"}, Predicate: PredicateCont.All(), Values: []string{"prod"}},
+		&Filter{Keys: []string{"ssh_key_id"}, Predicate: PredicateNull},
+		&Filter{Keys: []string{"state"}, Predicate: PredicateNotIn, Values: []string{"error"}},
+	}
+
+	expected := url.Values{
+		"q[name_not_eq]":      {"test"},
+		"q[disk_size_gteq]":   {"10"},
+		"q[name_start_any][]": {"web", "db"},
+		"q[tags_cont_all][]":  {"prod"},
+		"q[ssh_key_id_null]":  {"true"},
+		"q[state_not_in][]":   {"error"},
+	}
+	query := BuildFilterQuery(filters)
+
+	if query != expected.Encode() {
+		t.Fatalf("Wrong query. Expected %s, got %s", expected.Encode(), query)
+	}
+}
+
+func TestFilterGroup_Or(t *testing.T) {
+	filters := []FilterInterface{
+		&EqFilter{Keys: []string{"datacenter_id"}, Value: "dc"},
+		&FilterGroup{
+			Combinator: CombinatorOr,
+			Filters: []FilterInterface{
+				&ContFilter{Keys: []string{"name"}, Value: "web"},
+				&FilterGroup{
+					Filters: []FilterInterface{
+						&EqFilter{Keys: []string{"state"}, Value: "running"},
+						&Filter{Keys: []string{"tags"}, Predicate: PredicatePresent},
+					},
+				},
+			},
+		},
+		&FilterGroup{
+			Combinator: CombinatorOr,
+			Filters: []FilterInterface{
+				&Filter{Keys: []string{"vcpu"}, Predicate: PredicateLt, Values: []string{"4"}},
+			},
+		},
+	}
+
+	expected := url.Values{
+		"q[datacenter_id_eq]":         {"dc"},
+		"q[g][0][m]":                  {"or"},
+		"q[g][0][name_cont]":          {"web"},
+		"q[g][0][g][0][state_eq]":     {"running"},
+		"q[g][0][g][0][tags_present]": {"true"},
+		"q[g][1][m]":                  {"or"},
+		"q[g][1][vcpu_lt]":            {"4"},
+	}
+	query := BuildFilterQuery(filters)
+
+	if query != expected.Encode() {
+		t.Fatalf("Wrong query. Expected %s, got %s", expected.Encode(), query)
+	}
+}
+
+// legacyFilter implements only FilterInterface
+type legacyFilter struct{}
+
+func (legacyFilter) Encode() []string {
+	return []string{url.QueryEscape("q[name_start]") + "=" + url.QueryEscape("web")}
+}
+
+func TestFilterGroup_LegacyFilter(t *testing.T) {
+	filters := []FilterInterface{
+		legacyFilter{},
+		&FilterGroup{Combinator: CombinatorOr, Filters: []FilterInterface{legacyFilter{}}},
+	}
+
+	expected := url.Values{
+		"q[name_start]":       {"web"},
+		"q[g][0][m]":          {"or"},
+		"q[g][0][name_start]": {"web"},
+	}
+	query := BuildFilterQuery(filters)
+
+	if query != expected.Encode() {
+		t.Fatalf("Wrong query. Expected %s, got %s", expected.Encode(), query)
+	}
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2023 Advanced Hosting
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ah
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	rateLimitRemainingHeader = "X-RateLimit-Remaining"
+	rateLimitResetHeader     = "X-RateLimit-Reset"
+)
+
+// RateLimiter is a token bucket limiting the rate of API requests.
+// It adapts to X-RateLimit-Remaining/X-RateLimit-Reset and Retry-After response headers.
+// One RateLimiter can be shared between several APIClient instances using the same token.
+type RateLimiter struct {
+	last        time.Time
+	pausedUntil time.Time
+	rate        float64
+	burst       float64
+	tokens      float64
+	mu          sync.Mutex
+}
+
+// NewRateLimiter returns a RateLimiter allowing requestsPerSecond requests
+// on average with bursts of up to burst requests.
+// A non-positive requestsPerSecond disables the local limit, so only the
+// rate limit headers sent by the API are taken into account.
+func NewRateLimiter(requestsPerSecond float64, burst int) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &RateLimiter{
+		rate:   requestsPerSecond,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// Wait blocks until a request is allowed or ctx is done
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	for {
+		delay, ok := l.reserve(time.Now())
+		if ok {
+			return nil
+		}
+		if err := sleepContext(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
+
+// reserve takes a token if it's available, otherwise returns the delay until the next one
+func (l *RateLimiter) reserve(now time.Time) (time.Duration, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now.Before(l.pausedUntil) {
+		return l.pausedUntil.Sub(now), false
+	}
+	if l.rate <= 0 {
+		return 0, true
+	}
+
+	l.refill(now)
+	if l.tokens >= 1 {
+		l.tokens--
+		return 0, true
+	}
+	return time.Duration((1 - l.tokens) / l.rate * float64(time.Second)), false
+}
+
+func (l *RateLimiter) refill(now time.Time) {
+	if elapsed := now.Sub(l.last); elapsed > 0 {
+		l.tokens += elapsed.Seconds() * l.rate
+		if l.tokens > l.burst {
+			l.tokens = l.burst
+		}
+	}
+	l.last = now
+}
+
+// Update adapts the limiter to the rate limit headers of the API response
+func (l *RateLimiter) Update(resp *http.Response) {
+	now := time.Now()
+
+	var pauseUntil time.Time
+	remaining, err := strconv.Atoi(resp.Header.Get(rateLimitRemainingHeader))
+	hasRemaining := err == nil
+	if hasRemaining && remaining <= 0 {
+		if reset, ok := parseRateLimitReset(resp.Header.Get(rateLimitResetHeader), now); ok {
+			pauseUntil = reset
+		}
+	}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			if until := now.Add(retryAfter); until.After(pauseUntil) {
+				pauseUntil = until
+			}
+		}
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if pauseUntil.After(l.pausedUntil) {
+		l.pausedUntil = pauseUntil
+	}
+	if hasRemaining && l.rate > 0 {
+		l.refill(now)
+		if float64(remaining) < l.tokens {
+			l.tokens = float64(remaining)
+		}
+	}
+}
+
+// parseRateLimitReset parses reset header value as unix timestamp or delay in seconds
+func parseRateLimitReset(value string, now time.Time) (time.Time, bool) {
+	reset, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || reset < 0 {
+		return time.Time{}, false
+	}
+	// Values which look like a unix timestamp are absolute, others are relative
+	if reset > 1e9 {
+		return time.Unix(reset, 0), true
+	}
+	return now.Add(time.Duration(reset) * time.Second), true
+}
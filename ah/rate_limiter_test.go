@@ -0,0 +1,103 @@
+/*
+Copyright 2023 Advanced Hosting
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ah
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter_Burst(t *testing.T) {
+	limiter := NewRateLimiter(1, 2)
+	now := time.Now()
+
+	for i := 0; i < 2; i++ {
+		if _, ok := limiter.reserve(now); !ok {
+			t.Fatalf("Request %d should be allowed", i+1)
+		}
+	}
+
+	delay, ok := limiter.reserve(now)
+	if ok {
+		t.Fatalf("Request should be limited")
+	}
+	if delay <= 0 || delay > time.Second {
+		t.Errorf("Unexpected delay %s", delay)
+	}
+
+	if _, ok := limiter.reserve(now.Add(time.Second)); !ok {
+		t.Errorf("Request should be allowed after refill")
+	}
+}
+
+func TestRateLimiter_UpdateRemaining(t *testing.T) {
+	limiter := NewRateLimiter(0, 1)
+	resp := &http.Response{
+		StatusCode: http.StatusOK,
+		Header: http.Header{
+			"X-Ratelimit-Remaining": []string{"0"},
+			"X-Ratelimit-Reset":     []string{"60"},
+		},
+	}
+	limiter.Update(resp)
+
+	delay, ok := limiter.reserve(time.Now())
+	if ok {
+		t.Fatalf("Request should be limited until reset")
+	}
+	if delay < 59*time.Second {
+		t.Errorf("Unexpected delay %s", delay)
+	}
+}
+
+func TestRateLimiter_UpdateRetryAfter(t *testing.T) {
+	limiter := NewRateLimiter(10, 10)
+	resp := &http.Response{
+		StatusCode: http.StatusTooManyRequests,
+		Header:     http.Header{"Retry-After": []string{"30"}},
+	}
+	limiter.Update(resp)
+
+	if _, ok := limiter.reserve(time.Now()); ok {
+		t.Fatalf("Request should be limited after 429")
+	}
+}
+
+func TestRateLimiter_SharedBetweenClients(t *testing.T) {
+	fakeResponse := &fakeServerResponse{responseBody: getResponse}
+	server := newFakeServer("/api/v1/instances/2a758843-b82c-435d-b2b2-65581361345b", fakeResponse)
+
+	limiter := NewRateLimiter(20, 1)
+	options := newFakeClientOptions(server)
+	options.RateLimiter = limiter
+	first, _ := NewAPIClient(options)
+	second, _ := NewAPIClient(options)
+
+	ctx := context.Background()
+	start := time.Now()
+	for _, api := range []*APIClient{first, second, first} {
+		if _, err := api.Instances.Get(ctx, "2a758843-b82c-435d-b2b2-65581361345b"); err != nil {
+			t.Fatalf("Unexpected error %v", err)
+		}
+	}
+
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Errorf("Requests weren't limited, elapsed %s", elapsed)
+	}
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2023 Advanced Hosting
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ah
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// IdempotencyKeyHeader is the header carrying the key set by WithIdempotencyKey
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// RequestOption customizes a single API call. Options are passed to service methods:
+//
+//	var resp ah.Response
+//	volume, err := client.Volumes.Get(ctx, volumeID, ah.WithResponse(&resp))
+type RequestOption func(*requestOptions)
+
+type requestOptions struct {
+	header   http.Header
+	response *Response
+	timeout  time.Duration
+}
+
+type requestOptionsKey struct{}
+
+func newRequestOptions(opts []RequestOption) *requestOptions {
+	options := &requestOptions{header: http.Header{}}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(options)
+		}
+	}
+	return options
+}
+
+// withRequestOptions returns the request carrying the options to APIClient.Do
+func withRequestOptions(req *http.Request, options *requestOptions) *http.Request {
+	return req.WithContext(context.WithValue(req.Context(), requestOptionsKey{}, options))
+}
+
+func requestOptionsFrom(req *http.Request) *requestOptions {
+	options, _ := req.Context().Value(requestOptionsKey{}).(*requestOptions)
+	return options
+}
+
+// WithHeader sets the header of the request
+func WithHeader(key, value string) RequestOption {
+	return func(o *requestOptions) {
+		o.header.Set(key, value)
+	}
+}
+
+// WithIdempotencyKey sets Idempotency-Key header. Retries of the request send the same key,
+// so repeated requests can be detected by the API if it supports the header. POST and PATCH
+// requests are retried only if RetryPolicy.RetryNonIdempotent is enabled.
+// The same key must be used when the call itself is repeated.
+func WithIdempotencyKey(key string) RequestOption {
+	return WithHeader(IdempotencyKeyHeader, key)
+}
+
+// NewIdempotencyKey returns a random key for WithIdempotencyKey
+func NewIdempotencyKey() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+// WithTimeout limits the duration of the call including retries and decoding of the response
+func WithTimeout(timeout time.Duration) RequestOption {
+	return func(o *requestOptions) {
+		o.timeout = timeout
+	}
+}
+
+// WithResponse stores metadata of the API response in resp, including failed calls.
+// Paginating iterators store the response of the last fetched page.
+func WithResponse(resp *Response) RequestOption {
+	return func(o *requestOptions) {
+		o.response = resp
+	}
+}
+
+// Response represents metadata of an API response. The body is already consumed.
+type Response struct {
+	*http.Response
+	// RequestID is the server request ID, it should be reported to Advanced Hosting support
+	RequestID string
+	// Rate is the rate limit state reported by the server
+	Rate Rate
+}
+
+// Rate represents rate limit headers of a response
+type Rate struct {
+	// Reset is the time when the rate limit resets, zero if it's unknown
+	Reset time.Time
+	// Remaining is the number of requests left until Reset, -1 if it's unknown
+	Remaining int
+}
+
+func newResponse(resp *http.Response) *Response {
+	response := &Response{
+		Response:  resp,
+		RequestID: resp.Header.Get(requestIDHeader),
+		Rate:      Rate{Remaining: -1},
+	}
+	if remaining, err := strconv.Atoi(resp.Header.Get(rateLimitRemainingHeader)); err == nil {
+		response.Rate.Remaining = remaining
+	}
+	if reset, ok := parseRateLimitReset(resp.Header.Get(rateLimitResetHeader), time.Now()); ok {
+		response.Rate.Reset = reset
+	}
+	return response
+}
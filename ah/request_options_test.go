@@ -0,0 +1,173 @@
+/*
+Copyright 2023 Advanced Hosting
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ah
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newFakeResponseHeadersServer(statusCode int, body string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Header().Set("X-Request-Id", "request-id")
+		rw.Header().Set("X-RateLimit-Remaining", "42")
+		rw.Header().Set("X-RateLimit-Reset", "1700000000")
+		rw.WriteHeader(statusCode)
+		_, _ = rw.Write([]byte(body))
+	}))
+}
+
+func TestWithResponse(t *testing.T) {
+	server := newFakeResponseHeadersServer(http.StatusOK, volumeGetResponse)
+	defer server.Close()
+	api, _ := NewAPIClient(newFakeClientOptions(server))
+
+	var resp Response
+	if _, err := api.Volumes.Get(context.Background(), "volume-id", WithResponse(&resp)); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	if resp.StatusCode != http.StatusOK || resp.RequestID != "request-id" {
+		t.Errorf("Unexpected response %+v", resp)
+	}
+	if resp.Rate.Remaining != 42 || resp.Rate.Reset.Unix() != 1700000000 {
+		t.Errorf("Unexpected rate %+v", resp.Rate)
+	}
+}
+
+func TestWithResponse_Error(t *testing.T) {
+	server := newFakeResponseHeadersServer(http.StatusNotFound, `{"error": "not found"}`)
+	defer server.Close()
+	api, _ := NewAPIClient(newFakeClientOptions(server))
+
+	var resp Response
+	_, err := api.Instances.Get(context.Background(), "instance-id", WithResponse(&resp))
+	if !errors.Is(err, ErrResourceNotFound) {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if resp.StatusCode != http.StatusNotFound || resp.RequestID != "request-id" {
+		t.Errorf("Unexpected response %+v", resp)
+	}
+}
+
+func TestWithResponse_NoRateLimitHeaders(t *testing.T) {
+	api, _ := newFakeAPIClient("/api/v1/volumes/volume-id", &fakeServerResponse{responseBody: volumeGetResponse})
+
+	var resp Response
+	if _, err := api.Volumes.Get(context.Background(), "volume-id", WithResponse(&resp)); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if resp.Rate.Remaining != -1 || !resp.Rate.Reset.IsZero() {
+		t.Errorf("Unexpected rate %+v", resp.Rate)
+	}
+}
+
+func TestWithHeader(t *testing.T) {
+	var header http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		header = req.Header
+		_, _ = rw.Write([]byte(volumeGetResponse))
+	}))
+	defer server.Close()
+	api, _ := NewAPIClient(newFakeClientOptions(server))
+
+	if _, err := api.Volumes.Get(context.Background(), "volume-id", WithHeader("X-Trace-Id", "trace")); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if header.Get("X-Trace-Id") != "trace" {
+		t.Errorf("Unexpected header %v", header)
+	}
+}
+
+func TestWithIdempotencyKey_NotRetried(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		rw.WriteHeader(http.StatusGatewayTimeout)
+	}))
+	defer server.Close()
+
+	options := newFakeClientOptions(server)
+	options.RetryPolicy = &RetryPolicy{MaxAttempts: 3, BaseBackoff: time.Millisecond}
+	api, _ := NewAPIClient(options)
+
+	ctx := context.Background()
+	if _, err := api.Instances.Create(ctx, &InstanceCreateRequest{Name: "test"}, WithIdempotencyKey("key")); err == nil {
+		t.Fatalf("Expected error")
+	}
+	if attempts != 1 {
+		t.Errorf("Expected a single attempt without RetryNonIdempotent, got %d", attempts)
+	}
+}
+
+func TestWithIdempotencyKey_Retried(t *testing.T) {
+	var attempts int32
+	var keys []string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		keys = append(keys, req.Header.Get(IdempotencyKeyHeader))
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			rw.WriteHeader(http.StatusGatewayTimeout)
+			return
+		}
+		rw.WriteHeader(http.StatusAccepted)
+		_, _ = rw.Write([]byte(getResponse))
+	}))
+	defer server.Close()
+
+	options := newFakeClientOptions(server)
+	options.RetryPolicy = &RetryPolicy{MaxAttempts: 3, BaseBackoff: time.Millisecond, RetryNonIdempotent: true}
+	api, _ := NewAPIClient(options)
+
+	ctx := context.Background()
+	if _, err := api.Instances.Create(ctx, &InstanceCreateRequest{Name: "test"}, WithIdempotencyKey("key")); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if attempts != 2 || keys[0] != "key" || keys[1] != "key" {
+		t.Errorf("Unexpected attempts %d with keys %v", attempts, keys)
+	}
+}
+
+func TestNewIdempotencyKey(t *testing.T) {
+	pattern := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	key := NewIdempotencyKey()
+	if !pattern.MatchString(key) || key == NewIdempotencyKey() {
+		t.Errorf("Unexpected key %s", key)
+	}
+}
+
+func TestWithTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		select {
+		case <-req.Context().Done():
+		case <-time.After(time.Second):
+		}
+		_, _ = rw.Write([]byte(volumeGetResponse))
+	}))
+	defer server.Close()
+	api, _ := NewAPIClient(newFakeClientOptions(server))
+
+	_, err := api.Volumes.Get(context.Background(), "volume-id", WithTimeout(10*time.Millisecond))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Unexpected error %v", err)
+	}
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2023 Advanced Hosting
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ah
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newFlakyServer(failures int32, statusCode int, body string, attempts *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if atomic.AddInt32(attempts, 1) <= failures {
+			rw.Header().Set("Retry-After", "0")
+			rw.WriteHeader(statusCode)
+			return
+		}
+		_, _ = rw.Write([]byte(body))
+	}))
+}
+
+func TestRetry_ServiceUnavailable(t *testing.T) {
+	var attempts int32
+	server := newFlakyServer(2, http.StatusServiceUnavailable, getResponse, &attempts)
+
+	options := newFakeClientOptions(server)
+	options.RetryPolicy = &RetryPolicy{MaxAttempts: 3, BaseBackoff: time.Millisecond}
+	api, _ := NewAPIClient(options)
+
+	ctx := context.Background()
+	instance, err := api.Instances.Get(ctx, "2a758843-b82c-435d-b2b2-65581361345b")
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	if instance == nil {
+		t.Errorf("Empty response")
+	}
+
+	if attempts != 3 {
+		t.Errorf("Expected 3 attempts, got %d", attempts)
+	}
+}
+
+func TestRetry_MaxAttemptsExceeded(t *testing.T) {
+	var attempts int32
+	server := newFlakyServer(5, http.StatusTooManyRequests, getResponse, &attempts)
+
+	options := newFakeClientOptions(server)
+	options.RetryPolicy = &RetryPolicy{MaxAttempts: 2, BaseBackoff: time.Millisecond}
+	api, _ := NewAPIClient(options)
+
+	ctx := context.Background()
+	_, err := api.Instances.Get(ctx, "2a758843-b82c-435d-b2b2-65581361345b")
+
+	apiErr, ok := err.(*APIError)
+	if !ok || apiErr.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("Unexpected error %v", err)
+	}
+
+	if attempts != 2 {
+		t.Errorf("Expected 2 attempts, got %d", attempts)
+	}
+}
+
+func TestRetry_NonIdempotentIsNotRetried(t *testing.T) {
+	var attempts int32
+	server := newFlakyServer(1, http.StatusServiceUnavailable, getResponse, &attempts)
+
+	options := newFakeClientOptions(server)
+	options.RetryPolicy = &RetryPolicy{MaxAttempts: 3, BaseBackoff: time.Millisecond}
+	api, _ := NewAPIClient(options)
+
+	ctx := context.Background()
+	if _, err := api.Instances.Create(ctx, &InstanceCreateRequest{Name: "test"}); err == nil {
+		t.Errorf("Expected error")
+	}
+
+	if attempts != 1 {
+		t.Errorf("Expected 1 attempt, got %d", attempts)
+	}
+}
+
+func TestRetry_NonIdempotentOptIn(t *testing.T) {
+	var attempts int32
+	var lastBody string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		lastBody = string(body)
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			rw.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		rw.WriteHeader(http.StatusAccepted)
+		_, _ = rw.Write([]byte(getResponse))
+	}))
+
+	options := newFakeClientOptions(server)
+	options.RetryPolicy = &RetryPolicy{MaxAttempts: 3, BaseBackoff: time.Millisecond, RetryNonIdempotent: true}
+	api, _ := NewAPIClient(options)
+
+	ctx := context.Background()
+	if _, err := api.Instances.Create(ctx, &InstanceCreateRequest{Name: "test"}); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	if attempts != 2 {
+		t.Errorf("Expected 2 attempts, got %d", attempts)
+	}
+
+	if lastBody == "" {
+		t.Errorf("Request body wasn't resent")
+	}
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	policy := &RetryPolicy{BaseBackoff: time.Second, MaxBackoff: 5 * time.Second}
+
+	expected := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second}
+	for i, delay := range expected {
+		if result := policy.backoff(i + 1); result != delay {
+			t.Errorf("Wrong backoff for attempt %d. Expected %s, got %s", i+1, delay, result)
+		}
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	if delay, ok := parseRetryAfter("3"); !ok || delay != 3*time.Second {
+		t.Errorf("Wrong delay %s", delay)
+	}
+
+	date := time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)
+	if delay, ok := parseRetryAfter(date); !ok || delay <= 0 {
+		t.Errorf("Wrong delay %s", delay)
+	}
+
+	if _, ok := parseRetryAfter("invalid"); ok {
+		t.Errorf("Invalid value shouldn't be parsed")
+	}
+}
@@ -19,25 +19,27 @@ package ah
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 )
 
 // SSHKey object
 type SSHKey struct {
-	ID          string `json:"id,omitempty"`
-	Name        string `json:"name,omitempty"`
-	PublicKey   string `json:"public_key,omitempty"`
-	Fingerprint string `json:"fingerprint,omitempty"`
-	CreatedAt   string `json:"created_at,omitempty"`
+	ID          string    `json:"id,omitempty"`
+	Name        string    `json:"name,omitempty"`
+	PublicKey   string    `json:"public_key,omitempty"`
+	Fingerprint string    `json:"fingerprint,omitempty"`
+	CreatedAt   Timestamp `json:"created_at,omitempty"`
 }
 
 // SSHKeysAPI is an interface for ssh keys.
 type SSHKeysAPI interface {
-	List(context.Context, *ListOptions) ([]SSHKey, *Meta, error)
-	Get(context.Context, string) (*SSHKey, error)
-	Create(context.Context, *SSHKeyCreateRequest) (*SSHKey, error)
-	Update(context.Context, string, *SSHKeyUpdateRequest) (*SSHKey, error)
-	Delete(context.Context, string) error
+	List(context.Context, *ListOptions, ...RequestOption) ([]SSHKey, *Meta, error)
+	All(context.Context, *ListOptions, ...RequestOption) iter.Seq2[SSHKey, error]
+	Get(context.Context, string, ...RequestOption) (*SSHKey, error)
+	Create(context.Context, *SSHKeyCreateRequest, ...RequestOption) (*SSHKey, error)
+	Update(context.Context, string, *SSHKeyUpdateRequest, ...RequestOption) (*SSHKey, error)
+	Delete(context.Context, string, ...RequestOption) error
 }
 
 // SSHKeysService implements SSHKeysAPI interface.
@@ -51,27 +53,38 @@ type sshKeysRoot struct {
 }
 
 // List returns all available ssh keys
-func (sk *SSHKeysService) List(ctx context.Context, options *ListOptions) ([]SSHKey, *Meta, error) {
+func (sk *SSHKeysService) List(ctx context.Context, options *ListOptions, opts ...RequestOption) ([]SSHKey, *Meta, error) {
+	return sk.list(withOperation(ctx, "SSHKeys.List"), options, opts...)
+}
+
+// list is List without the operation name, so that wrappers keep their own
+func (sk *SSHKeysService) list(ctx context.Context, options *ListOptions, opts ...RequestOption) ([]SSHKey, *Meta, error) {
 	path := "api/v1/ssh_keys"
 
 	var sshRoot sshKeysRoot
 
-	if err := sk.client.list(ctx, path, options, &sshRoot); err != nil {
+	if err := sk.client.list(ctx, path, options, &sshRoot, opts...); err != nil {
 		return nil, nil, err
 	}
 
 	return sshRoot.SSHKeys, sshRoot.Meta, nil
 }
 
+// All returns an iterator over all ssh keys fetching pages lazily
+func (sk *SSHKeysService) All(ctx context.Context, options *ListOptions, opts ...RequestOption) iter.Seq2[SSHKey, error] {
+	return paginate(withOperation(ctx, "SSHKeys.All"), options, sk.list, opts...)
+}
+
 type sshKeyRoot struct {
 	SSHKey *SSHKey `json:"ssh_key"`
 	Meta   *Meta   `json:"meta"`
 }
 
 // Get ssh key info
-func (sk *SSHKeysService) Get(ctx context.Context, sshKeyID string) (*SSHKey, error) {
+func (sk *SSHKeysService) Get(ctx context.Context, sshKeyID string, opts ...RequestOption) (*SSHKey, error) {
+	ctx = withOperation(ctx, "SSHKeys.Get")
 	path := fmt.Sprintf("api/v1/ssh_keys/%s", sshKeyID)
-	req, err := sk.client.newRequest(http.MethodGet, path, nil)
+	req, err := sk.client.newRequest(http.MethodGet, path, nil, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -93,12 +106,13 @@ type SSHKeyCreateRequest struct {
 }
 
 // Create ssh key
-func (sk *SSHKeysService) Create(ctx context.Context, createRequest *SSHKeyCreateRequest) (*SSHKey, error) {
+func (sk *SSHKeysService) Create(ctx context.Context, createRequest *SSHKeyCreateRequest, opts ...RequestOption) (*SSHKey, error) {
+	ctx = withOperation(ctx, "SSHKeys.Create")
 
 	type request struct {
 		SSHKey *SSHKeyCreateRequest `json:"ssh_key"`
 	}
-	req, err := sk.client.newRequest(http.MethodPost, "api/v1/ssh_keys", &request{createRequest})
+	req, err := sk.client.newRequest(http.MethodPost, "api/v1/ssh_keys", &request{createRequest}, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -118,9 +132,10 @@ type SSHKeyUpdateRequest struct {
 }
 
 // Update ssh key
-func (sk *SSHKeysService) Update(ctx context.Context, sshKeyID string, updateRequest *SSHKeyUpdateRequest) (*SSHKey, error) {
+func (sk *SSHKeysService) Update(ctx context.Context, sshKeyID string, updateRequest *SSHKeyUpdateRequest, opts ...RequestOption) (*SSHKey, error) {
+	ctx = withOperation(ctx, "SSHKeys.Update")
 	path := fmt.Sprintf("api/v1/ssh_keys/%s", sshKeyID)
-	req, err := sk.client.newRequest(http.MethodPut, path, updateRequest)
+	req, err := sk.client.newRequest(http.MethodPut, path, updateRequest, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -134,9 +149,10 @@ func (sk *SSHKeysService) Update(ctx context.Context, sshKeyID string, updateReq
 }
 
 // Delete ssh key
-func (sk *SSHKeysService) Delete(ctx context.Context, sshKeyID string) error {
+func (sk *SSHKeysService) Delete(ctx context.Context, sshKeyID string, opts ...RequestOption) error {
+	ctx = withOperation(ctx, "SSHKeys.Delete")
 	path := fmt.Sprintf("api/v1/ssh_keys/%s", sshKeyID)
-	req, err := sk.client.newRequest(http.MethodDelete, path, nil)
+	req, err := sk.client.newRequest(http.MethodDelete, path, nil, opts...)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,183 @@
+/*
+Copyright 2023 Advanced Hosting
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ah
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	instrumentationName = "github.com/advancedhosting/advancedhosting-api-go/ah"
+	defaultOperation    = "APIClient.Do"
+)
+
+var resourceIDPattern = regexp.MustCompile(`^([0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}|[0-9]+)$`)
+
+type attemptsKey struct{}
+
+type operationKey struct{}
+
+// telemetry records spans and metrics of API calls
+type telemetry struct {
+	tracer     trace.Tracer
+	propagator propagation.TextMapPropagator
+	requests   metric.Int64Counter
+	duration   metric.Float64Histogram
+}
+
+func newTelemetry(tracerProvider trace.TracerProvider, meterProvider metric.MeterProvider, propagator propagation.TextMapPropagator) (*telemetry, error) {
+	if tracerProvider == nil && meterProvider == nil {
+		return nil, nil
+	}
+
+	t := &telemetry{propagator: propagator}
+	if tracerProvider != nil {
+		t.tracer = tracerProvider.Tracer(instrumentationName)
+	}
+	if meterProvider != nil {
+		meter := meterProvider.Meter(instrumentationName)
+
+		var err error
+		t.requests, err = meter.Int64Counter("ah.client.requests",
+			metric.WithDescription("Number of AH API calls"),
+			metric.WithUnit("{request}"))
+		if err != nil {
+			return nil, err
+		}
+
+		t.duration, err = meter.Float64Histogram("ah.client.request.duration",
+			metric.WithDescription("Duration of AH API calls including retries"),
+			metric.WithUnit("s"))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return t, nil
+}
+
+// start starts a span of the API call and returns the function to finish it
+func (t *telemetry) start(ctx context.Context, req *http.Request) (context.Context, func(*http.Response, error)) {
+	operation := operationFrom(ctx)
+	attempts := new(int)
+	ctx = context.WithValue(ctx, attemptsKey{}, attempts)
+
+	attrs := []attribute.KeyValue{
+		attribute.String("ah.operation", operation),
+		attribute.String("http.request.method", req.Method),
+	}
+
+	var span trace.Span
+	if t.tracer != nil {
+		spanAttrs := append([]attribute.KeyValue{
+			attribute.String("url.path", req.URL.Path),
+			attribute.String("server.address", req.URL.Hostname()),
+		}, attrs...)
+		if ids := resourceIDs(req.URL.Path); len(ids) > 0 {
+			spanAttrs = append(spanAttrs, attribute.StringSlice("ah.resource_ids", ids))
+		}
+		ctx, span = t.tracer.Start(ctx, operation,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(spanAttrs...))
+		t.textMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+	}
+
+	start := time.Now()
+	return ctx, func(resp *http.Response, err error) {
+		statusCode := 0
+		if resp != nil {
+			statusCode = resp.StatusCode
+		}
+		var apiErr *APIError
+		if errors.As(err, &apiErr) {
+			statusCode = apiErr.StatusCode
+		}
+		if statusCode != 0 {
+			attrs = append(attrs, attribute.Int("http.response.status_code", statusCode))
+		}
+
+		if span != nil {
+			span.SetAttributes(attribute.Int("ah.retry_count", max(*attempts-1, 0)))
+			if statusCode != 0 {
+				span.SetAttributes(attribute.Int("http.response.status_code", statusCode))
+			}
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+			span.End()
+		}
+
+		if t.requests != nil {
+			set := metric.WithAttributes(attrs...)
+			t.requests.Add(ctx, 1, set)
+			t.duration.Record(ctx, time.Since(start).Seconds(), set)
+		}
+	}
+}
+
+// recordAttempt counts request attempts of the traced API call
+func recordAttempt(ctx context.Context, attempt int) {
+	if attempts, ok := ctx.Value(attemptsKey{}).(*int); ok {
+		*attempts = attempt
+	}
+}
+
+// textMapPropagator returns the configured propagator or the global one
+func (t *telemetry) textMapPropagator() propagation.TextMapPropagator {
+	if t.propagator != nil {
+		return t.propagator
+	}
+	return otel.GetTextMapPropagator()
+}
+
+// withOperation names the API call made with ctx after the service method, e.g. "Instances.Create".
+// Every public service method sets it. Calls of other public methods override it, so the calls of
+// composite methods like Teardown are named after the methods they use, e.g. "Instances.Get".
+// Wrappers like List, All or Get use unexported helpers to keep their own names.
+func withOperation(ctx context.Context, operation string) context.Context {
+	return context.WithValue(ctx, operationKey{}, operation)
+}
+
+// operationFrom returns the operation name set by withOperation
+func operationFrom(ctx context.Context) string {
+	if operation, ok := ctx.Value(operationKey{}).(string); ok {
+		return operation
+	}
+	return defaultOperation
+}
+
+func resourceIDs(path string) []string {
+	var ids []string
+	for _, segment := range strings.Split(path, "/") {
+		if resourceIDPattern.MatchString(segment) {
+			ids = append(ids, segment)
+		}
+	}
+	return ids
+}
@@ -0,0 +1,229 @@
+/*
+Copyright 2023 Advanced Hosting
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ah
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func spanAttribute(span sdktrace.ReadOnlySpan, key attribute.Key) (attribute.Value, bool) {
+	for _, attr := range span.Attributes() {
+		if attr.Key == key {
+			return attr.Value, true
+		}
+	}
+	return attribute.Value{}, false
+}
+
+func TestTelemetry_Span(t *testing.T) {
+	var attempts int32
+	server := newFlakyServer(1, http.StatusServiceUnavailable, getResponse, &attempts)
+
+	recorder := tracetest.NewSpanRecorder()
+	options := newFakeClientOptions(server)
+	options.TracerProvider = sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	options.RetryPolicy = &RetryPolicy{MaxAttempts: 2, BaseBackoff: time.Millisecond}
+	api, _ := NewAPIClient(options)
+
+	ctx := context.Background()
+	if _, err := api.Instances.Get(ctx, "2a758843-b82c-435d-b2b2-65581361345b"); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("Expected 1 span, got %d", len(spans))
+	}
+
+	span := spans[0]
+	if span.Name() != "Instances.Get" {
+		t.Errorf("Unexpected span name %s", span.Name())
+	}
+
+	if value, _ := spanAttribute(span, "http.response.status_code"); value.AsInt64() != 200 {
+		t.Errorf("Unexpected status code %v", value.AsInt64())
+	}
+
+	if value, _ := spanAttribute(span, "ah.retry_count"); value.AsInt64() != 1 {
+		t.Errorf("Unexpected retry count %v", value.AsInt64())
+	}
+
+	ids, _ := spanAttribute(span, "ah.resource_ids")
+	if slice := ids.AsStringSlice(); len(slice) != 1 || slice[0] != "2a758843-b82c-435d-b2b2-65581361345b" {
+		t.Errorf("Unexpected resource ids %v", slice)
+	}
+}
+
+func TestTelemetry_ErrorSpan(t *testing.T) {
+	fakeResponse := &fakeServerResponse{responseBody: "", statusCode: 404}
+	server := newFakeServer("/api/v2/kubernetes/clusters/test/kubeconfig", fakeResponse)
+
+	recorder := tracetest.NewSpanRecorder()
+	options := newFakeClientOptions(server)
+	options.TracerProvider = sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	api, _ := NewAPIClient(options)
+
+	ctx := context.Background()
+	if _, err := api.KubernetesClusters.GetConfig(ctx, "test"); err == nil {
+		t.Fatalf("Expected error")
+	}
+
+	span := recorder.Ended()[0]
+	if span.Name() != "KubernetesClusters.GetConfig" {
+		t.Errorf("Unexpected span name %s", span.Name())
+	}
+
+	if span.Status().Code != codes.Error {
+		t.Errorf("Unexpected span status %v", span.Status())
+	}
+
+	if value, _ := spanAttribute(span, "http.response.status_code"); value.AsInt64() != 404 {
+		t.Errorf("Unexpected status code %v", value.AsInt64())
+	}
+}
+
+func TestTelemetry_SpanNames(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.Header().Set("content-type", "application/json")
+		_, _ = rw.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	recorder := tracetest.NewSpanRecorder()
+	options := newFakeClientOptions(server)
+	options.TracerProvider = sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	api, _ := NewAPIClient(options)
+
+	ctx := context.Background()
+	cases := map[string]func(){
+		"InstancePrivateNetworks.Create": func() {
+			_, _ = api.InstancePrivateNetworks.Create(ctx, &InstancePrivateNetworkCreateRequest{})
+		},
+		"InstancePrivateNetworks.Update": func() {
+			_, _ = api.InstancePrivateNetworks.Update(ctx, "instance_private_network_id", &InstancePrivateNetworkUpdateRequest{})
+		},
+		"IPAddresses.Get":   func() { _, _ = api.IPAddresses.Get(ctx, "ip_address_id") },
+		"IPAddresses.List":  func() { _, _ = api.IPAddresses.List(ctx, nil) },
+		"Instances.Destroy": func() { _ = api.Instances.Destroy(ctx, "instance_id") },
+		"Volumes.Update":    func() { _, _ = api.Volumes.Update(ctx, "volume_id", &VolumeUpdateRequest{}) },
+		"SSHKeys.Delete":    func() { _ = api.SSHKeys.Delete(ctx, "ssh_key_id") },
+		"Datacenters.All": func() {
+			for range api.Datacenters.All(ctx, nil) {
+			}
+		},
+	}
+	for name, call := range cases {
+		call()
+		spans := recorder.Ended()
+		if len(spans) == 0 {
+			t.Fatalf("%s: expected span", name)
+		}
+		if span := spans[len(spans)-1]; span.Name() != name {
+			t.Errorf("Unexpected span name %s, expected %s", span.Name(), name)
+		}
+	}
+}
+
+func TestTelemetry_Metrics(t *testing.T) {
+	fakeResponse := &fakeServerResponse{responseBody: listResponse}
+	server := newFakeServer("/api/v1/instances", fakeResponse)
+
+	reader := sdkmetric.NewManualReader()
+	options := newFakeClientOptions(server)
+	options.MeterProvider = sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	api, _ := NewAPIClient(options)
+
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		if _, _, err := api.Instances.List(ctx, nil); err != nil {
+			t.Fatalf("Unexpected error %v", err)
+		}
+	}
+
+	var data metricdata.ResourceMetrics
+	if err := reader.Collect(ctx, &data); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	found := map[string]bool{}
+	for _, scope := range data.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			found[m.Name] = true
+			if sum, ok := m.Data.(metricdata.Sum[int64]); ok {
+				if len(sum.DataPoints) != 1 || sum.DataPoints[0].Value != 2 {
+					t.Errorf("Unexpected requests count %v", sum.DataPoints)
+				}
+				if value, _ := sum.DataPoints[0].Attributes.Value("ah.operation"); value.AsString() != "Instances.List" {
+					t.Errorf("Unexpected operation %v", value.AsString())
+				}
+			}
+		}
+	}
+
+	if !found["ah.client.requests"] || !found["ah.client.request.duration"] {
+		t.Errorf("Unexpected metrics %v", found)
+	}
+}
+
+func TestTelemetry_Propagator(t *testing.T) {
+	var traceparent string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/instances/instance_id", func(rw http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+		rw.Header().Set("content-type", "application/json")
+		_, _ = rw.Write([]byte(`{"instance": {"id": "instance_id"}}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	options := newFakeClientOptions(server)
+	options.TracerProvider = sdktrace.NewTracerProvider()
+	options.Propagator = propagation.TraceContext{}
+	api, _ := NewAPIClient(options)
+
+	if _, err := api.Instances.Get(context.Background(), "instance_id"); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	if traceparent == "" {
+		t.Errorf("Trace context wasn't propagated")
+	}
+}
+
+func TestOperationFrom(t *testing.T) {
+	ctx := context.Background()
+	if operation := operationFrom(ctx); operation != defaultOperation {
+		t.Errorf("Unexpected default operation %s", operation)
+	}
+
+	ctx = withOperation(withOperation(ctx, "Instances.Teardown"), "Instances.Get")
+	if operation := operationFrom(ctx); operation != "Instances.Get" {
+		t.Errorf("Unexpected operation %s", operation)
+	}
+}
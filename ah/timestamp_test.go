@@ -0,0 +1,100 @@
+/*
+Copyright 2023 Advanced Hosting
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ah
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func mustParseTimestamp(value string) Timestamp {
+	t, err := ParseTimestamp(value)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestTimestamp_UnmarshalJSON(t *testing.T) {
+	expected := time.Date(2020, 7, 3, 8, 33, 27, 127000000, time.UTC)
+	cases := map[string]time.Time{
+		`"2020-07-03T08:33:27.127Z"`:      expected,
+		`"2020-07-03T08:33:27.127+00:00"`: expected,
+		`"2020-07-03T11:33:27.127+03:00"`: expected,
+		`"2020-07-03 08:33:27 UTC"`:       expected.Truncate(time.Second),
+		`"2020-07-03"`:                    expected.Truncate(24 * time.Hour),
+		`""`:                              {},
+		`null`:                            {},
+	}
+	for data, want := range cases {
+		var ts Timestamp
+		if err := json.Unmarshal([]byte(data), &ts); err != nil {
+			t.Errorf("Unexpected error of %s: %v", data, err)
+			continue
+		}
+		if !ts.Equal(want) {
+			t.Errorf("Unexpected time of %s: %v", data, ts)
+		}
+	}
+
+	var ts Timestamp
+	if err := json.Unmarshal([]byte(`"yesterday"`), &ts); err == nil {
+		t.Errorf("Expected error")
+	}
+}
+
+func TestTimestamp_MarshalJSON(t *testing.T) {
+	var v struct {
+		CreatedAt  Timestamp `json:"created_at"`
+		AttachedAt Timestamp `json:"attached_at"`
+	}
+	v.CreatedAt = mustParseTimestamp("2023-02-27T16:38:15.000+00:00")
+	data, _ := json.Marshal(&v)
+	if string(data) != `{"created_at":"2023-02-27T16:38:15.000Z","attached_at":null}` {
+		t.Errorf("Unexpected json %s", data)
+	}
+}
+
+func TestAction_Duration(t *testing.T) {
+	action := &Action{}
+	if action.Duration() != 0 {
+		t.Errorf("Not started action must have zero duration")
+	}
+
+	action.StartedAt = mustParseTimestamp("2020-09-21T08:37:18.071Z")
+	action.CompletedAt = mustParseTimestamp("2020-09-21T08:37:33.860Z")
+	if action.Duration() != 15789*time.Millisecond {
+		t.Errorf("Unexpected duration %v", action.Duration())
+	}
+
+	action.StartedAt = NewTimestamp(time.Now().Add(-time.Minute))
+	action.CompletedAt = Timestamp{}
+	if action.Duration() < time.Minute {
+		t.Errorf("Unexpected duration of running action %v", action.Duration())
+	}
+}
+
+func TestBackup_Age(t *testing.T) {
+	backup := &Backup{CreatedAt: NewTimestamp(time.Now().Add(-48 * time.Hour))}
+	if age := backup.Age(); age < 48*time.Hour || age > 49*time.Hour {
+		t.Errorf("Unexpected age %v", age)
+	}
+	if (&Backup{}).Age() != 0 {
+		t.Errorf("Backup without creation time must have zero age")
+	}
+}
@@ -0,0 +1,295 @@
+/*
+Copyright 2023 Advanced Hosting
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ah
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+const (
+	defaultRotateBefore     = time.Hour
+	defaultRotateRetryDelay = time.Minute
+)
+
+var (
+	// ErrEmptyToken is returned by token sources which read an empty access token
+	ErrEmptyToken = errors.New("empty access token")
+)
+
+// fileTokenSource reads the access token from a file, reloading it when the file changes
+type fileTokenSource struct {
+	modTime time.Time
+	token   *oauth2.Token
+	path    string
+	size    int64
+	mu      sync.Mutex
+}
+
+// FileTokenSource returns a token source reading the access token from the file.
+// The file is read again whenever its modification time or size changes,
+// so tokens rotated on disk, e.g. mounted secrets, are picked up without restarts.
+func FileTokenSource(path string) oauth2.TokenSource {
+	return &fileTokenSource{path: path}
+}
+
+// Token returns the access token of the file
+func (s *fileTokenSource) Token() (*oauth2.Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	info, err := os.Stat(s.path)
+	if err != nil {
+		return nil, err
+	}
+	if s.token != nil && info.ModTime().Equal(s.modTime) && info.Size() == s.size {
+		return s.token, nil
+	}
+
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return nil, err
+	}
+	accessToken := strings.TrimSpace(string(data))
+	if accessToken == "" {
+		return nil, fmt.Errorf("%w in %s", ErrEmptyToken, s.path)
+	}
+	s.token = &oauth2.Token{AccessToken: accessToken}
+	s.modTime = info.ModTime()
+	s.size = info.Size()
+	return s.token, nil
+}
+
+// commandTokenSource runs a command printing the access token
+type commandTokenSource struct {
+	token *oauth2.Token
+	name  string
+	args  []string
+	ttl   time.Duration
+	mu    sync.Mutex
+}
+
+// CommandTokenSource returns a token source reading the access token from the output of the command,
+// e.g. a secrets manager CLI. The token is cached for ttl, the command runs for every request if ttl is zero.
+func CommandTokenSource(ttl time.Duration, name string, args ...string) oauth2.TokenSource {
+	return &commandTokenSource{name: name, args: args, ttl: ttl}
+}
+
+// Token returns the cached access token or runs the command
+func (s *commandTokenSource) Token() (*oauth2.Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != nil && time.Now().Before(s.token.Expiry) {
+		return s.token, nil
+	}
+
+	var stderr bytes.Buffer
+	cmd := exec.Command(s.name, s.args...)
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("running token command %s: %w: %s", s.name, err, strings.TrimSpace(stderr.String()))
+	}
+	accessToken := strings.TrimSpace(string(output))
+	if accessToken == "" {
+		return nil, fmt.Errorf("%w in output of %s", ErrEmptyToken, s.name)
+	}
+
+	s.token = &oauth2.Token{AccessToken: accessToken}
+	if s.ttl > 0 {
+		s.token.Expiry = time.Now().Add(s.ttl)
+	}
+	return s.token, nil
+}
+
+// TokenRotationOptions configures RotatingTokenSource
+type TokenRotationOptions struct {
+	// Tokens is used to create and delete access tokens. If it's nil, NewAPIClient
+	// sets it to Tokens of the client using the source, so the current token authenticates the rotation.
+	Tokens TokensAPI
+	// OnRotate is called with the new token after each rotation, e.g. to persist it
+	OnRotate func(*Token)
+	// OnRotateError is called when a rotation started by Token fails
+	OnRotateError func(error)
+	// Name of created access tokens
+	Name string
+	// RotateBefore is the time before the token expiration when a new token is created. Defaults to 1h.
+	RotateBefore time.Duration
+	// RetryDelay is the time after a failed rotation when Token doesn't try to rotate again. Defaults to 1m.
+	RetryDelay time.Duration
+	// KeepPrevious disables deletion of the previous token after rotation
+	KeepPrevious bool
+}
+
+// RotatingTokenSource mints a fresh access token with TokensAPI before the current one expires
+// and deletes the previous token. Tokens without expiration are never rotated.
+type RotatingTokenSource struct {
+	current *Token
+	expiry  time.Time
+	// retryAt is the time when the rotation is retried after a failure
+	retryAt  time.Time
+	lastErr  error
+	options  TokenRotationOptions
+	mu       sync.Mutex
+	rotating bool
+}
+
+// NewRotatingTokenSource returns a RotatingTokenSource starting with the token.
+// The token must contain ID to be deleted after rotation and ExpiresIn to be rotated.
+func NewRotatingTokenSource(token *Token, options *TokenRotationOptions) (*RotatingTokenSource, error) {
+	if token == nil || token.Token == "" {
+		return nil, ErrEmptyToken
+	}
+	expiry, err := parseTokenExpiry(token)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &RotatingTokenSource{current: token, expiry: expiry}
+	if options != nil {
+		s.options = *options
+	}
+	if s.options.RotateBefore <= 0 {
+		s.options.RotateBefore = defaultRotateBefore
+	}
+	if s.options.RetryDelay <= 0 {
+		s.options.RetryDelay = defaultRotateRetryDelay
+	}
+	return s, nil
+}
+
+// Token returns the current access token, rotating it if it's about to expire.
+// Requests sent during the rotation use the current token. After a failed rotation
+// the current token is used without rotating until RetryDelay passes.
+func (s *RotatingTokenSource) Token() (*oauth2.Token, error) {
+	s.mu.Lock()
+	now := time.Now()
+	if s.rotating || !s.shouldRotate(now) || now.Before(s.retryAt) {
+		defer s.mu.Unlock()
+		return s.currentToken(now)
+	}
+	s.rotating = true
+	s.mu.Unlock()
+
+	err := s.Rotate(context.Background())
+	if err != nil && s.options.OnRotateError != nil {
+		s.options.OnRotateError(err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rotating = false
+	now = time.Now()
+	s.lastErr = err
+	s.retryAt = time.Time{}
+	if err != nil {
+		s.retryAt = now.Add(s.options.RetryDelay)
+	}
+	return s.currentToken(now)
+}
+
+// currentToken returns the current token or the last rotation error if the token has expired
+func (s *RotatingTokenSource) currentToken(now time.Time) (*oauth2.Token, error) {
+	if s.lastErr != nil && !s.expiry.After(now) {
+		return nil, fmt.Errorf("rotating expired access token: %w", s.lastErr)
+	}
+	return s.oauth2Token(), nil
+}
+
+// Rotate creates a new access token, switches to it and deletes the previous one
+func (s *RotatingTokenSource) Rotate(ctx context.Context) error {
+	s.mu.Lock()
+	tokens := s.options.Tokens
+	previous := s.current
+	s.mu.Unlock()
+	if tokens == nil {
+		return errors.New("tokens API of rotating token source isn't set")
+	}
+
+	token, err := tokens.Create(ctx, &TokenCreateRequest{Name: s.options.Name})
+	if err != nil {
+		return err
+	}
+	if token == nil || token.Token == "" {
+		return ErrEmptyToken
+	}
+	expiry, err := parseTokenExpiry(token)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.current = token
+	s.expiry = expiry
+	s.mu.Unlock()
+
+	if s.options.OnRotate != nil {
+		s.options.OnRotate(token)
+	}
+	if s.options.KeepPrevious || previous.ID == "" {
+		return nil
+	}
+	if err := tokens.Delete(ctx, previous.ID); err != nil && !errors.Is(err, ErrResourceNotFound) {
+		return fmt.Errorf("deleting previous access token %s: %w", previous.ID, err)
+	}
+	return nil
+}
+
+// Current returns the current access token
+func (s *RotatingTokenSource) Current() *Token {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.current
+}
+
+func (s *RotatingTokenSource) setTokens(tokens TokensAPI) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.options.Tokens == nil {
+		s.options.Tokens = tokens
+	}
+}
+
+func (s *RotatingTokenSource) shouldRotate(now time.Time) bool {
+	return !s.expiry.IsZero() && !now.Before(s.expiry.Add(-s.options.RotateBefore))
+}
+
+func (s *RotatingTokenSource) oauth2Token() *oauth2.Token {
+	return &oauth2.Token{AccessToken: s.current.Token, Expiry: s.expiry}
+}
+
+// parseTokenExpiry returns the expiration time of the token or zero time if it doesn't expire
+func parseTokenExpiry(token *Token) (time.Time, error) {
+	if token.ExpiresIn == "" {
+		return time.Time{}, nil
+	}
+	expiry, err := time.Parse(time.RFC3339, token.ExpiresIn)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("parsing expiration of access token %s: %w", token.ID, err)
+	}
+	return expiry, nil
+}
@@ -0,0 +1,241 @@
+/*
+Copyright 2023 Advanced Hosting
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ah
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newFakeTokensServer accepts valid tokens and implements creation and deletion of access tokens
+func newFakeTokensServer(initial string, expiresIn time.Duration) (*httptest.Server, map[string]string) {
+	var mu sync.Mutex
+	tokens := map[string]string{initial: "initial"}
+	seq := 0
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if _, ok := tokens[strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer ")]; !ok {
+			rw.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch {
+		case req.Method == http.MethodPost && req.URL.Path == "/id/api/v1/access_tokens":
+			seq++
+			token := &Token{
+				ID:        fmt.Sprintf("token-%d", seq),
+				Token:     fmt.Sprintf("secret-%d", seq),
+				ExpiresIn: time.Now().Add(expiresIn).UTC().Format(time.RFC3339),
+			}
+			tokens[token.Token] = token.ID
+			_ = json.NewEncoder(rw).Encode(token)
+		case req.Method == http.MethodDelete:
+			id := strings.TrimPrefix(req.URL.Path, "/id/api/v1/access_tokens/")
+			for secret, tokenID := range tokens {
+				if tokenID == id {
+					delete(tokens, secret)
+				}
+			}
+		default:
+			_, _ = rw.Write([]byte(getResponse))
+		}
+	}))
+	return server, tokens
+}
+
+func TestFileTokenSource_Reload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(path, []byte("first\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	source := FileTokenSource(path)
+
+	token, err := source.Token()
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if token.AccessToken != "first" {
+		t.Errorf("Expected first token, got %q", token.AccessToken)
+	}
+
+	if err := os.WriteFile(path, []byte("second-token"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	token, err = source.Token()
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if token.AccessToken != "second-token" {
+		t.Errorf("Expected reloaded token, got %q", token.AccessToken)
+	}
+
+	if err := os.WriteFile(path, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := source.Token(); err == nil {
+		t.Errorf("Expected error for empty token file")
+	}
+}
+
+func TestCommandTokenSource(t *testing.T) {
+	source := CommandTokenSource(time.Minute, "echo", "command-token")
+	token, err := source.Token()
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if token.AccessToken != "command-token" {
+		t.Errorf("Expected command token, got %q", token.AccessToken)
+	}
+	if token.Expiry.IsZero() {
+		t.Errorf("Expected token expiry")
+	}
+
+	if _, err := CommandTokenSource(0, "false").Token(); err == nil {
+		t.Errorf("Expected error of failed command")
+	}
+}
+
+func TestClientOptions_TokenSource(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(path, []byte("initial-token"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	server, _ := newFakeTokensServer("initial-token", time.Hour)
+	defer server.Close()
+
+	api, err := NewAPIClient(&ClientOptions{BaseURL: server.URL, TokenSource: FileTokenSource(path)})
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if _, err := api.Instances.Get(context.Background(), "test"); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	if err := os.WriteFile(path, []byte("revoked-token"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := api.Instances.Get(context.Background(), "test"); err == nil {
+		t.Errorf("Expected reloaded token to be used")
+	}
+}
+
+func TestRotatingTokenSource(t *testing.T) {
+	server, tokens := newFakeTokensServer("initial-token", 2*time.Hour)
+	defer server.Close()
+
+	initial := &Token{
+		ID:        "initial",
+		Token:     "initial-token",
+		ExpiresIn: time.Now().Add(30 * time.Minute).UTC().Format(time.RFC3339),
+	}
+	var rotated []*Token
+	source, err := NewRotatingTokenSource(initial, &TokenRotationOptions{
+		Name:     "controller",
+		OnRotate: func(token *Token) { rotated = append(rotated, token) },
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	api, err := NewAPIClient(&ClientOptions{BaseURL: server.URL, TokenSource: source})
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	ctx := context.Background()
+	if _, err := api.Instances.Get(ctx, "test"); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if len(rotated) != 1 || source.Current().ID != "token-1" {
+		t.Fatalf("Expected token to be rotated once, got %v", rotated)
+	}
+	if _, ok := tokens["initial-token"]; ok {
+		t.Errorf("Expected previous token to be deleted")
+	}
+
+	if _, err := api.Instances.Get(ctx, "test"); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if len(rotated) != 1 {
+		t.Errorf("Expected no rotation of fresh token, got %d rotations", len(rotated))
+	}
+}
+
+func TestRotatingTokenSource_ExpiredAfterFailure(t *testing.T) {
+	server, _ := newFakeTokensServer("other-token", time.Hour)
+	defer server.Close()
+
+	initial := &Token{
+		ID:        "initial",
+		Token:     "initial-token",
+		ExpiresIn: time.Now().Add(-time.Minute).UTC().Format(time.RFC3339),
+	}
+	source, err := NewRotatingTokenSource(initial, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if _, err := NewAPIClient(&ClientOptions{BaseURL: server.URL, TokenSource: source}); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	if _, err := source.Token(); err == nil {
+		t.Errorf("Expected error of expired token")
+	}
+}
+
+func TestRotatingTokenSource_RetryDelay(t *testing.T) {
+	server, _ := newFakeTokensServer("other-token", time.Hour)
+	defer server.Close()
+
+	initial := &Token{
+		ID:        "initial",
+		Token:     "initial-token",
+		ExpiresIn: time.Now().Add(30 * time.Minute).UTC().Format(time.RFC3339),
+	}
+	var rotateErrors []error
+	source, err := NewRotatingTokenSource(initial, &TokenRotationOptions{
+		OnRotateError: func(err error) { rotateErrors = append(rotateErrors, err) },
+		RetryDelay:    time.Hour,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if _, err := NewAPIClient(&ClientOptions{BaseURL: server.URL, TokenSource: source}); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		token, err := source.Token()
+		if err != nil {
+			t.Fatalf("Unexpected error %v", err)
+		}
+		if token.AccessToken != "initial-token" {
+			t.Errorf("Expected current token, got %q", token.AccessToken)
+		}
+	}
+	if len(rotateErrors) != 1 {
+		t.Errorf("Expected one rotation attempt, got %v", rotateErrors)
+	}
+}
@@ -19,17 +19,18 @@ package ah
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 )
 
 // Token object
 type Token struct {
-	ID        string   `json:"id,omitempty"`
-	Name      string   `json:"name,omitempty"`
-	Token     string   `json:"token,omitempty"`
-	ExpiresIn string   `json:"expires_in,omitempty"`
-	CreatedAt string   `json:"created_at,omitempty"`
-	Scopes    []string `json:"scopes,omitempty"`
+	ID        string    `json:"id,omitempty"`
+	Name      string    `json:"name,omitempty"`
+	Token     string    `json:"token,omitempty"`
+	ExpiresIn string    `json:"expires_in,omitempty"`
+	CreatedAt Timestamp `json:"created_at,omitempty"`
+	Scopes    []string  `json:"scopes,omitempty"`
 }
 
 // TokenCreateRequest object
@@ -39,10 +40,12 @@ type TokenCreateRequest struct {
 
 // TokensAPI is an interface for tokens.
 type TokensAPI interface {
-	List(context.Context, *ListOptions) ([]Token, error)
-	Get(context.Context, string) (*Token, error)
-	Create(context.Context, *TokenCreateRequest) (*Token, error)
-	Delete(context.Context, string) error
+	List(context.Context, *ListOptions, ...RequestOption) ([]Token, error)
+	ListPage(context.Context, *ListOptions, ...RequestOption) ([]Token, *Meta, error)
+	All(context.Context, *ListOptions, ...RequestOption) iter.Seq2[Token, error]
+	Get(context.Context, string, ...RequestOption) (*Token, error)
+	Create(context.Context, *TokenCreateRequest, ...RequestOption) (*Token, error)
+	Delete(context.Context, string, ...RequestOption) error
 }
 
 // TokensService implements TokensAPI interface.
@@ -51,9 +54,10 @@ type TokensService struct {
 }
 
 // Get returns a token by ID
-func (s *TokensService) Get(ctx context.Context, tokenId string) (*Token, error) {
+func (s *TokensService) Get(ctx context.Context, tokenId string, opts ...RequestOption) (*Token, error) {
+	ctx = withOperation(ctx, "Tokens.Get")
 	path := fmt.Sprintf("id/api/v1/access_tokens/%s", tokenId)
-	req, err := s.client.newRequest(http.MethodGet, path, nil)
+	req, err := s.client.newRequest(http.MethodGet, path, nil, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -68,21 +72,42 @@ func (s *TokensService) Get(ctx context.Context, tokenId string) (*Token, error)
 }
 
 // List returns all available tokens
-func (s *TokensService) List(ctx context.Context, options *ListOptions) ([]Token, error) {
+//
+// Deprecated: Please use ListPage instead.
+func (s *TokensService) List(ctx context.Context, options *ListOptions, opts ...RequestOption) ([]Token, error) {
+	ctx = withOperation(ctx, "Tokens.List")
+	tokens, _, err := s.listPage(ctx, options, opts...)
+	return tokens, err
+}
+
+// ListPage returns all tokens and nil metadata. The endpoint isn't paginated,
+// so all tokens are returned as a single page.
+func (s *TokensService) ListPage(ctx context.Context, options *ListOptions, opts ...RequestOption) ([]Token, *Meta, error) {
+	return s.listPage(withOperation(ctx, "Tokens.ListPage"), options, opts...)
+}
+
+// listPage is ListPage without the operation name, so that wrappers keep their own
+func (s *TokensService) listPage(ctx context.Context, options *ListOptions, opts ...RequestOption) ([]Token, *Meta, error) {
 	path := "id/api/v1/access_tokens"
 
 	var tokens []Token
-	if err := s.client.list(ctx, path, options, &tokens); err != nil {
-		return nil, err
+	if err := s.client.list(ctx, path, options, &tokens, opts...); err != nil {
+		return nil, nil, err
 	}
 
-	return tokens, nil
+	return tokens, nil, nil
+}
+
+// All returns an iterator over all tokens
+func (s *TokensService) All(ctx context.Context, options *ListOptions, opts ...RequestOption) iter.Seq2[Token, error] {
+	return paginate(withOperation(ctx, "Tokens.All"), options, s.listPage, opts...)
 }
 
 // Create creates a new token
-func (s *TokensService) Create(ctx context.Context, request *TokenCreateRequest) (*Token, error) {
+func (s *TokensService) Create(ctx context.Context, request *TokenCreateRequest, opts ...RequestOption) (*Token, error) {
+	ctx = withOperation(ctx, "Tokens.Create")
 	path := "id/api/v1/access_tokens"
-	req, err := s.client.newRequest(http.MethodPost, path, request)
+	req, err := s.client.newRequest(http.MethodPost, path, request, opts...)
 
 	if err != nil {
 		return nil, err
@@ -97,9 +122,10 @@ func (s *TokensService) Create(ctx context.Context, request *TokenCreateRequest)
 }
 
 // Delete deletes a token by ID
-func (s *TokensService) Delete(ctx context.Context, tokenId string) error {
+func (s *TokensService) Delete(ctx context.Context, tokenId string, opts ...RequestOption) error {
+	ctx = withOperation(ctx, "Tokens.Delete")
 	path := fmt.Sprintf("id/api/v1/access_tokens/%s", tokenId)
-	req, err := s.client.newRequest(http.MethodDelete, path, nil)
+	req, err := s.client.newRequest(http.MethodDelete, path, nil, opts...)
 	if err != nil {
 		return err
 	}
@@ -18,6 +18,7 @@ package ah
 
 import (
 	"context"
+	"iter"
 )
 
 type VolumePlanAttributes struct {
@@ -46,7 +47,9 @@ type VolumePlan struct {
 
 // VolumePlansAPI is an interface for volume plans.
 type VolumePlansAPI interface {
-	List(context.Context) ([]VolumePlan, error)
+	List(context.Context, ...RequestOption) ([]VolumePlan, error)
+	ListPage(context.Context, *ListOptions, ...RequestOption) ([]VolumePlan, *Meta, error)
+	All(context.Context, *ListOptions, ...RequestOption) iter.Seq2[VolumePlan, error]
 }
 
 // VolumePlansService implements VolumePlansAPI interface.
@@ -55,18 +58,37 @@ type VolumePlansService struct {
 }
 
 type volumePlansRoot struct {
+	Meta  *Meta        `json:"meta"`
 	Plans []VolumePlan `json:"data"`
 }
 
 // List returns all available volume plans
-func (vp *VolumePlansService) List(ctx context.Context) ([]VolumePlan, error) {
+//
+// Deprecated: Please use ListPage instead.
+func (vp *VolumePlansService) List(ctx context.Context, opts ...RequestOption) ([]VolumePlan, error) {
+	ctx = withOperation(ctx, "VolumePlans.List")
+	plans, _, err := vp.listPage(ctx, nil, opts...)
+	return plans, err
+}
+
+// ListPage returns a page of volume plans with pagination metadata
+func (vp *VolumePlansService) ListPage(ctx context.Context, options *ListOptions, opts ...RequestOption) ([]VolumePlan, *Meta, error) {
+	return vp.listPage(withOperation(ctx, "VolumePlans.ListPage"), options, opts...)
+}
 
+// listPage is ListPage without the operation name, so that wrappers keep their own
+func (vp *VolumePlansService) listPage(ctx context.Context, options *ListOptions, opts ...RequestOption) ([]VolumePlan, *Meta, error) {
 	path := "api/v1/plans/public?type=volume"
 
-	var ipRoot volumePlansRoot
+	var pRoot volumePlansRoot
 
-	if err := vp.client.list(ctx, path, nil, &ipRoot); err != nil {
-		return nil, err
+	if err := vp.client.list(ctx, path, options, &pRoot, opts...); err != nil {
+		return nil, nil, err
 	}
-	return ipRoot.Plans, nil
+	return pRoot.Plans, pRoot.Meta, nil
+}
+
+// All returns an iterator over all volume plans fetching pages lazily
+func (vp *VolumePlansService) All(ctx context.Context, options *ListOptions, opts ...RequestOption) iter.Seq2[VolumePlan, error] {
+	return paginate(withOperation(ctx, "VolumePlans.All"), options, vp.listPage, opts...)
 }
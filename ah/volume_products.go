@@ -39,8 +39,8 @@ type VolumeProduct struct {
 		ReplicationLevel int    `json:"replication_level,omitempty"`
 	}
 	ID            string                `json:"id,omitempty"`
-	CreatedAt     string                `json:"created_at,omitempty"`
-	UpdatedAt     string                `json:"updated_at,omitempty"`
+	CreatedAt     Timestamp             `json:"created_at,omitempty"`
+	UpdatedAt     Timestamp             `json:"updated_at,omitempty"`
 	Name          string                `json:"name,omitempty"`
 	Type          string                `json:"type,omitempty"`
 	Price         string                `json:"price,omitempty"`
@@ -55,7 +55,7 @@ type VolumeProduct struct {
 
 // VolumeProductsAPI is an interface for volume products.
 type VolumeProductsAPI interface {
-	List(context.Context, *ListOptions) ([]VolumeProduct, *Meta, error)
+	List(context.Context, *ListOptions, ...RequestOption) ([]VolumeProduct, *Meta, error)
 }
 
 // VolumeProductsService implements VolumeProductsAPI interface.
@@ -69,13 +69,14 @@ type productsRoot struct {
 }
 
 // List returns all available volume products
-func (vps *VolumeProductsService) List(ctx context.Context, options *ListOptions) ([]VolumeProduct, *Meta, error) {
+func (vps *VolumeProductsService) List(ctx context.Context, options *ListOptions, opts ...RequestOption) ([]VolumeProduct, *Meta, error) {
+	ctx = withOperation(ctx, "VolumeProducts.List")
 
 	path := "api/v1/products/volumes"
 
 	var pRoot productsRoot
 
-	if err := vps.client.list(ctx, path, options, &pRoot); err != nil {
+	if err := vps.client.list(ctx, path, options, &pRoot, opts...); err != nil {
 		return nil, nil, err
 	}
 	return pRoot.Products, pRoot.Meta, nil
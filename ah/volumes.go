@@ -20,9 +20,13 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"iter"
 	"net/http"
 )
 
+// VolumeStateError is the state of a broken volume
+const VolumeStateError = "error"
+
 // Volume object
 type Volume struct {
 	Instance *struct {
@@ -40,8 +44,8 @@ type Volume struct {
 	State      string          `json:"state,omitempty"`
 	Number     string          `json:"number,omitempty"`
 	OriginalID string          `json:"original_id,omitempty"`
-	CreatedAt  string          `json:"created_at,omitempty"`
-	AttachedAt string          `json:"attached_at,omitempty"`
+	CreatedAt  Timestamp       `json:"created_at,omitempty"`
+	AttachedAt Timestamp       `json:"attached_at,omitempty"`
 	ProductID  string          `json:"product_id,omitempty"`
 	Meta       json.RawMessage `json:"meta,omitempty"`
 	Size       int             `json:"size,omitempty"`
@@ -67,15 +71,16 @@ type volumeActionsRoot struct {
 
 // VolumesAPI is an interface for volumes.
 type VolumesAPI interface {
-	List(context.Context, *ListOptions) ([]Volume, *Meta, error)
-	Get(context.Context, string) (*Volume, error)
-	Create(context.Context, *VolumeCreateRequest) (*Volume, error)
-	Update(context.Context, string, *VolumeUpdateRequest) (*Volume, error)
-	Copy(context.Context, string, *VolumeCopyActionRequest) (*VolumeAction, error)
-	Resize(context.Context, string, int) (*Action, error)
-	ActionInfo(context.Context, string, string) (*VolumeAction, error)
-	Actions(context.Context, string) ([]VolumeAction, error)
-	Delete(context.Context, string) error
+	List(context.Context, *ListOptions, ...RequestOption) ([]Volume, *Meta, error)
+	All(context.Context, *ListOptions, ...RequestOption) iter.Seq2[Volume, error]
+	Get(context.Context, string, ...RequestOption) (*Volume, error)
+	Create(context.Context, *VolumeCreateRequest, ...RequestOption) (*Volume, error)
+	Update(context.Context, string, *VolumeUpdateRequest, ...RequestOption) (*Volume, error)
+	Copy(context.Context, string, *VolumeCopyActionRequest, ...RequestOption) (*VolumeAction, error)
+	Resize(context.Context, string, int, ...RequestOption) (*Action, error)
+	ActionInfo(context.Context, string, string, ...RequestOption) (*VolumeAction, error)
+	Actions(context.Context, string, ...RequestOption) ([]VolumeAction, error)
+	Delete(context.Context, string, ...RequestOption) error
 }
 
 // VolumesService implements VolumesAPI interface.
@@ -89,12 +94,17 @@ type volumesRoot struct {
 }
 
 // List returns all available private networks
-func (vs *VolumesService) List(ctx context.Context, options *ListOptions) ([]Volume, *Meta, error) {
+func (vs *VolumesService) List(ctx context.Context, options *ListOptions, opts ...RequestOption) ([]Volume, *Meta, error) {
+	return vs.list(withOperation(ctx, "Volumes.List"), options, opts...)
+}
+
+// list is List without the operation name, so that wrappers keep their own
+func (vs *VolumesService) list(ctx context.Context, options *ListOptions, opts ...RequestOption) ([]Volume, *Meta, error) {
 	path := "api/v1/volumes"
 
 	var vsRoot volumesRoot
 
-	if err := vs.client.list(ctx, path, options, &vsRoot); err != nil {
+	if err := vs.client.list(ctx, path, options, &vsRoot, opts...); err != nil {
 		return nil, nil, err
 	}
 
@@ -102,14 +112,20 @@ func (vs *VolumesService) List(ctx context.Context, options *ListOptions) ([]Vol
 
 }
 
+// All returns an iterator over all volumes fetching pages lazily
+func (vs *VolumesService) All(ctx context.Context, options *ListOptions, opts ...RequestOption) iter.Seq2[Volume, error] {
+	return paginate(withOperation(ctx, "Volumes.All"), options, vs.list, opts...)
+}
+
 type volumeRoot struct {
 	Volume *Volume `json:"volume"`
 }
 
 // Get volume
-func (vs *VolumesService) Get(ctx context.Context, volumeID string) (*Volume, error) {
+func (vs *VolumesService) Get(ctx context.Context, volumeID string, opts ...RequestOption) (*Volume, error) {
+	ctx = withOperation(ctx, "Volumes.Get")
 	path := fmt.Sprintf("api/v1/volumes/%s", volumeID)
-	req, err := vs.client.newRequest(http.MethodGet, path, nil)
+	req, err := vs.client.newRequest(http.MethodGet, path, nil, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -140,12 +156,13 @@ type VolumeCreateRequest struct {
 }
 
 // Create volume
-func (vs *VolumesService) Create(ctx context.Context, createRequest *VolumeCreateRequest) (*Volume, error) {
+func (vs *VolumesService) Create(ctx context.Context, createRequest *VolumeCreateRequest, opts ...RequestOption) (*Volume, error) {
+	ctx = withOperation(ctx, "Volumes.Create")
 
 	type request struct {
 		Volume *VolumeCreateRequest `json:"volume"`
 	}
-	req, err := vs.client.newRequest(http.MethodPost, "api/v1/volumes", &request{createRequest})
+	req, err := vs.client.newRequest(http.MethodPost, "api/v1/volumes", &request{createRequest}, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -165,9 +182,10 @@ type VolumeUpdateRequest struct {
 }
 
 // Update volume
-func (vs *VolumesService) Update(ctx context.Context, volumeID string, request *VolumeUpdateRequest) (*Volume, error) {
+func (vs *VolumesService) Update(ctx context.Context, volumeID string, request *VolumeUpdateRequest, opts ...RequestOption) (*Volume, error) {
+	ctx = withOperation(ctx, "Volumes.Update")
 	path := fmt.Sprintf("api/v1/volumes/%s", volumeID)
-	req, err := vs.client.newRequest(http.MethodPut, path, request)
+	req, err := vs.client.newRequest(http.MethodPut, path, request, opts...)
 
 	if err != nil {
 		return nil, err
@@ -193,8 +211,8 @@ type VolumeCopyActionRequest struct {
 }
 
 type volumeCopyActionRequest struct {
-	Name string `json:"name"`
-	Type string `json:"type"`
+	Name string     `json:"name"`
+	Type ActionType `json:"type"`
 	// Deprecated: Please use PlanID instead.
 	ProductID string `json:"product_id,omitempty"`
 	// Deprecated: Please use PlanSlug instead.
@@ -204,12 +222,13 @@ type volumeCopyActionRequest struct {
 }
 
 // Copy volume
-func (vs *VolumesService) Copy(ctx context.Context, volumeID string, request *VolumeCopyActionRequest) (*VolumeAction, error) {
+func (vs *VolumesService) Copy(ctx context.Context, volumeID string, request *VolumeCopyActionRequest, opts ...RequestOption) (*VolumeAction, error) {
+	ctx = withOperation(ctx, "Volumes.Copy")
 	path := fmt.Sprintf("api/v1/volumes/%s/actions", volumeID)
 
 	copyRequest := &volumeCopyActionRequest{
 		Name: request.Name,
-		Type: "copy",
+		Type: ActionTypeCopy,
 	}
 
 	if request.PlanSlug != "" {
@@ -226,7 +245,7 @@ func (vs *VolumesService) Copy(ctx context.Context, volumeID string, request *Vo
 		}
 	}
 
-	req, err := vs.client.newRequest(http.MethodPost, path, copyRequest)
+	req, err := vs.client.newRequest(http.MethodPost, path, copyRequest, opts...)
 
 	if err != nil {
 		return nil, err
@@ -241,20 +260,21 @@ func (vs *VolumesService) Copy(ctx context.Context, volumeID string, request *Vo
 }
 
 type volumeResizeActionRequest struct {
-	Type string `json:"type"`
-	Size int    `json:"size"`
+	Type ActionType `json:"type"`
+	Size int        `json:"size"`
 }
 
 // Resize volume
-func (vs *VolumesService) Resize(ctx context.Context, volumeID string, size int) (*Action, error) {
+func (vs *VolumesService) Resize(ctx context.Context, volumeID string, size int, opts ...RequestOption) (*Action, error) {
+	ctx = withOperation(ctx, "Volumes.Resize")
 	path := fmt.Sprintf("api/v1/volumes/%s/actions", volumeID)
 
 	request := &volumeResizeActionRequest{
 		Size: size,
-		Type: "resize",
+		Type: ActionTypeResize,
 	}
 
-	req, err := vs.client.newRequest(http.MethodPost, path, request)
+	req, err := vs.client.newRequest(http.MethodPost, path, request, opts...)
 
 	if err != nil {
 		return nil, err
@@ -269,9 +289,10 @@ func (vs *VolumesService) Resize(ctx context.Context, volumeID string, size int)
 }
 
 // ActionInfo returns volume's action info by action ID
-func (vs *VolumesService) ActionInfo(ctx context.Context, volumeID, actionID string) (*VolumeAction, error) {
+func (vs *VolumesService) ActionInfo(ctx context.Context, volumeID, actionID string, opts ...RequestOption) (*VolumeAction, error) {
+	ctx = withOperation(ctx, "Volumes.ActionInfo")
 	path := fmt.Sprintf("api/v1/volumes/%s/actions/%s", volumeID, actionID)
-	req, err := vs.client.newRequest(http.MethodGet, path, nil)
+	req, err := vs.client.newRequest(http.MethodGet, path, nil, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -290,9 +311,10 @@ func (vs *VolumesService) ActionInfo(ctx context.Context, volumeID, actionID str
 }
 
 // Actions returns volume's actions list
-func (vs *VolumesService) Actions(ctx context.Context, volumeID string) ([]VolumeAction, error) {
+func (vs *VolumesService) Actions(ctx context.Context, volumeID string, opts ...RequestOption) ([]VolumeAction, error) {
+	ctx = withOperation(ctx, "Volumes.Actions")
 	path := fmt.Sprintf("api/v1/volumes/%s/actions", volumeID)
-	req, err := vs.client.newRequest(http.MethodGet, path, nil)
+	req, err := vs.client.newRequest(http.MethodGet, path, nil, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -306,9 +328,10 @@ func (vs *VolumesService) Actions(ctx context.Context, volumeID string) ([]Volum
 }
 
 // Delete volume
-func (vs *VolumesService) Delete(ctx context.Context, volumeID string) error {
+func (vs *VolumesService) Delete(ctx context.Context, volumeID string, opts ...RequestOption) error {
+	ctx = withOperation(ctx, "Volumes.Delete")
 	path := fmt.Sprintf("api/v1/volumes/%s", volumeID)
-	req, err := vs.client.newRequest(http.MethodDelete, path, nil)
+	req, err := vs.client.newRequest(http.MethodDelete, path, nil, opts...)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,252 @@
+/*
+Copyright 2023 Advanced Hosting
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ah
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+const defaultPollInterval = 2 * time.Second
+
+var (
+	// ErrUnsupportedActionResource is returned when action's resource type can't be polled
+	ErrUnsupportedActionResource = errors.New("unsupported action resource type")
+)
+
+// WaitOptions configures polling of asynchronous operations
+type WaitOptions struct {
+	// OnProgress is called with the action after each poll of WaitForAction
+	OnProgress func(*Action)
+	// PollInterval is the delay between polls. Defaults to 2s.
+	PollInterval time.Duration
+	// MaxPollInterval caps the poll interval growth when BackoffFactor is set
+	MaxPollInterval time.Duration
+	// Timeout limits the total waiting time. Only ctx is used if it's zero.
+	Timeout time.Duration
+	// BackoffFactor multiplies the poll interval after each poll
+	BackoffFactor float64
+}
+
+func (o *WaitOptions) pollInterval() time.Duration {
+	if o == nil || o.PollInterval <= 0 {
+		return defaultPollInterval
+	}
+	return o.PollInterval
+}
+
+func (o *WaitOptions) nextPollInterval(interval time.Duration) time.Duration {
+	if o == nil || o.BackoffFactor <= 1 {
+		return interval
+	}
+	next := time.Duration(float64(interval) * o.BackoffFactor)
+	if o.MaxPollInterval > 0 && next > o.MaxPollInterval {
+		next = o.MaxPollInterval
+	}
+	return next
+}
+
+// ActionError is returned when an action finishes unsuccessfully
+type ActionError struct {
+	Action *Action
+}
+
+// Error returns the error description with the action note
+func (e *ActionError) Error() string {
+	msg := fmt.Sprintf("%s action %s of %s %s finished with state %s",
+		e.Action.Type, e.Action.ID, e.Action.ResourceType, e.Action.ResourceID, e.Action.State)
+	if e.Action.Note != "" {
+		msg = fmt.Sprintf("%s: %s", msg, e.Action.Note)
+	}
+	return msg
+}
+
+// poll calls check until it reports done, returns an error or the wait times out
+func poll(ctx context.Context, options *WaitOptions, check func(context.Context) (bool, error)) error {
+	if options != nil && options.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, options.Timeout)
+		defer cancel()
+	}
+
+	interval := options.pollInterval()
+	for {
+		done, err := check(ctx)
+		if err != nil || done {
+			return err
+		}
+
+		if err := sleepContext(ctx, interval); err != nil {
+			return err
+		}
+		interval = options.nextPollInterval(interval)
+	}
+}
+
+// actionInfo returns the current state of the action using resource's actions endpoint
+func (c *APIClient) actionInfo(ctx context.Context, action *Action) (*Action, error) {
+	switch action.ResourceType {
+	case "instance":
+		info, err := c.Instances.ActionInfo(ctx, action.ResourceID, action.ID)
+		if err != nil {
+			return nil, err
+		}
+		return info.Action, nil
+	case "volume":
+		info, err := c.Volumes.ActionInfo(ctx, action.ResourceID, action.ID)
+		if err != nil {
+			return nil, err
+		}
+		return info.Action, nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnsupportedActionResource, action.ResourceType)
+}
+
+// WaitForAction polls the action until it reaches a terminal state.
+// It supports instance and volume actions, including backup actions of instances.
+// Other actions, e.g. of private networks, can't be polled and ErrUnsupportedActionResource is returned.
+// ActionError is returned if the action fails.
+func (c *APIClient) WaitForAction(ctx context.Context, action *Action, options *WaitOptions) (*Action, error) {
+	if action == nil {
+		return nil, errors.New("action is nil")
+	}
+
+	current := action
+	err := poll(ctx, options, func(ctx context.Context) (bool, error) {
+		info, err := c.actionInfo(ctx, current)
+		if err != nil {
+			return false, err
+		}
+		if info == nil {
+			return false, fmt.Errorf("empty info of action %s", current.ID)
+		}
+		current = info
+
+		if options != nil && options.OnProgress != nil {
+			options.OnProgress(current)
+		}
+
+		if current.isError() {
+			return false, &ActionError{Action: current}
+		}
+		return current.isTerminal(), nil
+	})
+	if err != nil {
+		var actionErr *ActionError
+		if errors.As(err, &actionErr) {
+			return current, err
+		}
+		return nil, fmt.Errorf("waiting for action %s: %w", action.ID, err)
+	}
+	return current, nil
+}
+
+// ResourceStateError is returned when a resource reaches an error state while waiting
+type ResourceStateError struct {
+	ResourceType string
+	ResourceID   string
+	State        string
+	Description  string
+}
+
+// Error returns the error description
+func (e *ResourceStateError) Error() string {
+	msg := fmt.Sprintf("%s %s is in %s state", e.ResourceType, e.ResourceID, e.State)
+	if e.Description != "" {
+		msg = fmt.Sprintf("%s: %s", msg, e.Description)
+	}
+	return msg
+}
+
+// waitForState polls the resource until its state matches the expected one.
+// isError reports the resource's error states which stop the polling.
+func waitForState[T any, S ~string](ctx context.Context, options *WaitOptions, resourceType, resourceID string, expectedState S, get func(context.Context, string, ...RequestOption) (*T, error), state func(*T) (S, string), isError func(S) bool) (*T, error) {
+	var resource *T
+	err := poll(ctx, options, func(ctx context.Context) (bool, error) {
+		var err error
+		if resource, err = get(ctx, resourceID); err != nil {
+			return false, err
+		}
+		if resource == nil {
+			return false, fmt.Errorf("empty %s %s", resourceType, resourceID)
+		}
+
+		current, description := state(resource)
+		if current == expectedState {
+			return true, nil
+		}
+		if isError(current) {
+			return false, &ResourceStateError{
+				ResourceType: resourceType,
+				ResourceID:   resourceID,
+				State:        string(current),
+				Description:  description,
+			}
+		}
+		return false, nil
+	})
+	if err != nil {
+		var stateErr *ResourceStateError
+		if errors.As(err, &stateErr) {
+			return resource, err
+		}
+		return nil, fmt.Errorf("waiting for %s %s to be %s: %w", resourceType, resourceID, expectedState, err)
+	}
+	return resource, nil
+}
+
+// isState returns a predicate matching the given states
+func isState(states ...string) func(string) bool {
+	return func(state string) bool {
+		return slices.Contains(states, state)
+	}
+}
+
+// WaitForInstanceState polls the instance until it reaches the state.
+// ResourceStateError is returned if the instance reaches an error state.
+func (c *APIClient) WaitForInstanceState(ctx context.Context, instanceID string, state InstanceState, options *WaitOptions) (*Instance, error) {
+	return waitForState(ctx, options, "instance", instanceID, state, c.Instances.Get, func(i *Instance) (InstanceState, string) {
+		return i.State, i.StateDescription
+	}, InstanceState.IsError)
+}
+
+// WaitForVolumeState polls the volume until it reaches the state.
+// ResourceStateError is returned if the volume reaches an error state.
+func (c *APIClient) WaitForVolumeState(ctx context.Context, volumeID, state string, options *WaitOptions) (*Volume, error) {
+	return waitForState(ctx, options, "volume", volumeID, state, c.Volumes.Get, func(v *Volume) (string, string) {
+		return v.State, ""
+	}, isState(VolumeStateError))
+}
+
+// WaitForLoadBalancerState polls the load balancer until it reaches the state.
+// ResourceStateError is returned if the load balancer reaches an error state.
+func (c *APIClient) WaitForLoadBalancerState(ctx context.Context, lbID, state string, options *WaitOptions) (*LoadBalancer, error) {
+	return waitForState(ctx, options, "load balancer", lbID, state, c.LoadBalancers.Get, func(lb *LoadBalancer) (string, string) {
+		return lb.State, ""
+	}, isState(LoadBalancerStateError))
+}
+
+// WaitForKubernetesClusterReady polls the kubernetes cluster until it's active.
+// ResourceStateError is returned if the cluster reaches an error state.
+func (c *APIClient) WaitForKubernetesClusterReady(ctx context.Context, clusterID string, options *WaitOptions) (*KubernetesCluster, error) {
+	return waitForState(ctx, options, "kubernetes cluster", clusterID, KubernetesClusterStateActive, c.KubernetesClusters.Get, func(kc *KubernetesCluster) (string, string) {
+		return kc.State, ""
+	}, isState(KubernetesClusterStateError))
+}
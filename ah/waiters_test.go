@@ -0,0 +1,249 @@
+/*
+Copyright 2023 Advanced Hosting
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ah
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// newFakeSequenceServer returns responses one by one repeating the last one
+func newFakeSequenceServer(url string, responses []string, requests *int) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc(url, func(rw http.ResponseWriter, r *http.Request) {
+		i := *requests
+		if i >= len(responses) {
+			i = len(responses) - 1
+		}
+		*requests++
+		rw.Header().Set("content-type", "application/json")
+		_, _ = rw.Write([]byte(responses[i]))
+	})
+	return httptest.NewServer(mux)
+}
+
+func fakeActionResponse(resourceType string, state ActionState, note string) string {
+	return fmt.Sprintf(`{"action": {"id": "action_id", "resource_id": "resource_id", "resource_type": "%s", "type": "copy", "state": "%s", "note": "%s"}}`,
+		resourceType, state, note)
+}
+
+func TestWaitForAction_Success(t *testing.T) {
+	var requests int
+	responses := []string{
+		fakeActionResponse("volume", ActionStateRunning, ""),
+		fakeActionResponse("volume", ActionStateSuccess, ""),
+	}
+	server := newFakeSequenceServer("/api/v1/volumes/resource_id/actions/action_id", responses, &requests)
+	api, _ := NewAPIClient(newFakeClientOptions(server))
+
+	var progress []ActionState
+	options := &WaitOptions{
+		PollInterval: time.Millisecond,
+		OnProgress: func(action *Action) {
+			progress = append(progress, action.State)
+		},
+	}
+
+	ctx := context.Background()
+	action := &Action{ID: "action_id", ResourceID: "resource_id", ResourceType: "volume"}
+	result, err := api.WaitForAction(ctx, action, options)
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	if result.State != ActionStateSuccess {
+		t.Errorf("Unexpected state %s", result.State)
+	}
+
+	if len(progress) != 2 || requests != 2 {
+		t.Errorf("Unexpected progress %v", progress)
+	}
+}
+
+func TestWaitForAction_Failed(t *testing.T) {
+	var requests int
+	responses := []string{fakeActionResponse("instance", ActionStateFailed, "Not enough space")}
+	server := newFakeSequenceServer("/api/v1/instances/resource_id/actions/action_id", responses, &requests)
+	api, _ := NewAPIClient(newFakeClientOptions(server))
+
+	ctx := context.Background()
+	action := &Action{ID: "action_id", ResourceID: "resource_id", ResourceType: "instance"}
+	_, err := api.WaitForAction(ctx, action, &WaitOptions{PollInterval: time.Millisecond})
+
+	var actionErr *ActionError
+	if !errors.As(err, &actionErr) {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	if actionErr.Action.Note != "Not enough space" {
+		t.Errorf("Unexpected note %s", actionErr.Action.Note)
+	}
+}
+
+func TestWaitForAction_Timeout(t *testing.T) {
+	var requests int
+	responses := []string{fakeActionResponse("volume", ActionStateRunning, "")}
+	server := newFakeSequenceServer("/api/v1/volumes/resource_id/actions/action_id", responses, &requests)
+	api, _ := NewAPIClient(newFakeClientOptions(server))
+
+	options := &WaitOptions{
+		PollInterval:    time.Millisecond,
+		BackoffFactor:   2,
+		MaxPollInterval: 5 * time.Millisecond,
+		Timeout:         50 * time.Millisecond,
+	}
+
+	ctx := context.Background()
+	action := &Action{ID: "action_id", ResourceID: "resource_id", ResourceType: "volume"}
+	_, err := api.WaitForAction(ctx, action, options)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Unexpected error %v", err)
+	}
+}
+
+func TestWaitForAction_UnsupportedResource(t *testing.T) {
+	fakeResponse := &fakeServerResponse{responseBody: ""}
+	api, _ := newFakeAPIClient("/", fakeResponse)
+
+	ctx := context.Background()
+	// last_action of a private network as returned by the API
+	action := &Action{
+		ID:           "b55d976a-e7df-4d31-b357-4d67e4bef42e",
+		ResourceID:   "17a1b879-5354-4118-b572-a73688523035",
+		ResourceType: "privatenetwork",
+		Type:         "update",
+		State:        "running",
+	}
+	_, err := api.WaitForAction(ctx, action, nil)
+	if !errors.Is(err, ErrUnsupportedActionResource) {
+		t.Errorf("Unexpected error %v", err)
+	}
+}
+
+func TestWaitForInstanceState_Running(t *testing.T) {
+	var requests int
+	responses := []string{
+		`{"instance": {"id": "instance_id", "state": "creating"}}`,
+		`{"instance": {"id": "instance_id", "state": "running"}}`,
+	}
+	server := newFakeSequenceServer("/api/v1/instances/instance_id", responses, &requests)
+	api, _ := NewAPIClient(newFakeClientOptions(server))
+
+	ctx := context.Background()
+	instance, err := api.WaitForInstanceState(ctx, "instance_id", InstanceStateRunning, &WaitOptions{PollInterval: time.Millisecond})
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	if instance.State != InstanceStateRunning || requests != 2 {
+		t.Errorf("Unexpected instance %v", instance)
+	}
+}
+
+func TestWaitForInstanceState_Error(t *testing.T) {
+	var requests int
+	responses := []string{`{"instance": {"id": "instance_id", "state": "error", "state_description": "No capacity"}}`}
+	server := newFakeSequenceServer("/api/v1/instances/instance_id", responses, &requests)
+	api, _ := NewAPIClient(newFakeClientOptions(server))
+
+	ctx := context.Background()
+	_, err := api.WaitForInstanceState(ctx, "instance_id", InstanceStateRunning, &WaitOptions{PollInterval: time.Millisecond})
+
+	var stateErr *ResourceStateError
+	if !errors.As(err, &stateErr) {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	if stateErr.Description != "No capacity" || requests != 1 {
+		t.Errorf("Unexpected error %v", stateErr)
+	}
+}
+
+func TestWaitForVolumeState_NotFound(t *testing.T) {
+	fakeResponse := &fakeServerResponse{responseBody: "", statusCode: 404}
+	api, _ := newFakeAPIClient("/api/v1/volumes/volume_id", fakeResponse)
+
+	ctx := context.Background()
+	_, err := api.WaitForVolumeState(ctx, "volume_id", "attached", &WaitOptions{PollInterval: time.Millisecond})
+	if !errors.Is(err, ErrResourceNotFound) {
+		t.Errorf("Unexpected error %v", err)
+	}
+}
+
+func TestWaitForLoadBalancerState(t *testing.T) {
+	var requests int
+	responses := []string{
+		`{"load_balancer": {"id": "lb_id", "state": "defined"}}`,
+		`{"load_balancer": {"id": "lb_id", "state": "active"}}`,
+	}
+	server := newFakeSequenceServer("/api/v1/load_balancers/lb_id", responses, &requests)
+	api, _ := NewAPIClient(newFakeClientOptions(server))
+
+	ctx := context.Background()
+	lb, err := api.WaitForLoadBalancerState(ctx, "lb_id", "active", &WaitOptions{PollInterval: time.Millisecond})
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	if lb.State != "active" {
+		t.Errorf("Unexpected state %s", lb.State)
+	}
+}
+
+func TestWaitForLoadBalancerState_Error(t *testing.T) {
+	var requests int
+	responses := []string{`{"load_balancer": {"id": "lb_id", "state": "error"}}`}
+	server := newFakeSequenceServer("/api/v1/load_balancers/lb_id", responses, &requests)
+	api, _ := NewAPIClient(newFakeClientOptions(server))
+
+	ctx := context.Background()
+	_, err := api.WaitForLoadBalancerState(ctx, "lb_id", "active", &WaitOptions{PollInterval: time.Millisecond})
+
+	var stateErr *ResourceStateError
+	if !errors.As(err, &stateErr) {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	if stateErr.State != LoadBalancerStateError || requests != 1 {
+		t.Errorf("Unexpected error %v", stateErr)
+	}
+}
+
+func TestWaitForKubernetesClusterReady(t *testing.T) {
+	var requests int
+	responses := []string{
+		`{"cluster": {"id": "cluster_id", "state": "creating"}}`,
+		`{"cluster": {"id": "cluster_id", "state": "active"}}`,
+	}
+	server := newFakeSequenceServer("/api/v2/kubernetes/clusters/cluster_id", responses, &requests)
+	api, _ := NewAPIClient(newFakeClientOptions(server))
+
+	ctx := context.Background()
+	cluster, err := api.WaitForKubernetesClusterReady(ctx, "cluster_id", &WaitOptions{PollInterval: time.Millisecond})
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	if cluster.State != KubernetesClusterStateActive {
+		t.Errorf("Unexpected state %s", cluster.State)
+	}
+}
@@ -0,0 +1,326 @@
+/*
+Copyright 2023 Advanced Hosting
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Command mockgen generates ahmock mocks of the ah package service interfaces.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	ahImportPath    = "github.com/advancedhosting/advancedhosting-api-go/ah"
+	interfaceSuffix = "API"
+)
+
+type method struct {
+	name    string
+	params  []string
+	results []string
+}
+
+type mock struct {
+	name    string
+	methods []method
+}
+
+// generator renders types of the ah package as seen from ahmock
+type generator struct {
+	types   map[string]bool
+	imports map[string]string
+	used    map[string]bool
+}
+
+func main() {
+	source := flag.String("source", "../ah", "directory of the ah package")
+	output := flag.String("output", "mocks.go", "output file")
+	flag.Parse()
+
+	src, err := generate(*source)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*output, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// generate returns the formatted source of mocks of *API interfaces declared in the source directory
+func generate(source string) ([]byte, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, source, func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		return nil, err
+	}
+	pkg, ok := pkgs["ah"]
+	if !ok {
+		return nil, fmt.Errorf("package ah not found in %s", source)
+	}
+
+	g := &generator{types: map[string]bool{}, imports: map[string]string{}, used: map[string]bool{}}
+	var interfaces []*ast.TypeSpec
+	for _, file := range pkg.Files {
+		for _, imp := range file.Imports {
+			importPath, _ := strconv.Unquote(imp.Path.Value)
+			name := path.Base(importPath)
+			if imp.Name != nil {
+				name = imp.Name.Name
+			}
+			g.imports[name] = importPath
+		}
+		for _, decl := range file.Decls {
+			genDecl, ok := decl.(*ast.GenDecl)
+			if !ok || genDecl.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range genDecl.Specs {
+				typeSpec := spec.(*ast.TypeSpec)
+				g.types[typeSpec.Name.Name] = true
+				if _, ok := typeSpec.Type.(*ast.InterfaceType); ok && strings.HasSuffix(typeSpec.Name.Name, interfaceSuffix) {
+					interfaces = append(interfaces, typeSpec)
+				}
+			}
+		}
+	}
+	sort.Slice(interfaces, func(i, j int) bool {
+		return interfaces[i].Name.Name < interfaces[j].Name.Name
+	})
+
+	var mocks []mock
+	for _, spec := range interfaces {
+		m, err := g.mock(spec)
+		if err != nil {
+			return nil, err
+		}
+		mocks = append(mocks, m)
+	}
+	return g.render(mocks)
+}
+
+func (g *generator) mock(spec *ast.TypeSpec) (mock, error) {
+	m := mock{name: spec.Name.Name}
+	for _, field := range spec.Type.(*ast.InterfaceType).Methods.List {
+		funcType, ok := field.Type.(*ast.FuncType)
+		if !ok || len(field.Names) != 1 {
+			return m, fmt.Errorf("%s: embedded interfaces are not supported", m.name)
+		}
+		meth := method{name: field.Names[0].Name}
+		var err error
+		if meth.params, err = g.fieldTypes(funcType.Params); err != nil {
+			return m, err
+		}
+		if meth.results, err = g.fieldTypes(funcType.Results); err != nil {
+			return m, err
+		}
+		m.methods = append(m.methods, meth)
+	}
+	return m, nil
+}
+
+// fieldTypes returns a type per parameter, repeating types of grouped names
+func (g *generator) fieldTypes(fields *ast.FieldList) ([]string, error) {
+	if fields == nil {
+		return nil, nil
+	}
+	var result []string
+	for _, field := range fields.List {
+		typ, err := g.typeString(field.Type)
+		if err != nil {
+			return nil, err
+		}
+		for i := 0; i < max(len(field.Names), 1); i++ {
+			result = append(result, typ)
+		}
+	}
+	return result, nil
+}
+
+func (g *generator) typeString(expr ast.Expr) (string, error) {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		if g.types[t.Name] {
+			g.used["ah"] = true
+			return "ah." + t.Name, nil
+		}
+		return t.Name, nil
+	case *ast.StarExpr:
+		elem, err := g.typeString(t.X)
+		return "*" + elem, err
+	case *ast.Ellipsis:
+		elem, err := g.typeString(t.Elt)
+		return "..." + elem, err
+	case *ast.ArrayType:
+		if t.Len != nil {
+			return "", fmt.Errorf("arrays are not supported")
+		}
+		elem, err := g.typeString(t.Elt)
+		return "[]" + elem, err
+	case *ast.MapType:
+		key, err := g.typeString(t.Key)
+		if err != nil {
+			return "", err
+		}
+		value, err := g.typeString(t.Value)
+		return fmt.Sprintf("map[%s]%s", key, value), err
+	case *ast.SelectorExpr:
+		pkg, ok := t.X.(*ast.Ident)
+		if !ok {
+			return "", fmt.Errorf("unsupported selector %T", t.X)
+		}
+		g.used[pkg.Name] = true
+		return pkg.Name + "." + t.Sel.Name, nil
+	case *ast.IndexExpr:
+		return g.genericString(t.X, t.Index)
+	case *ast.IndexListExpr:
+		return g.genericString(t.X, t.Indices...)
+	case *ast.InterfaceType:
+		if t.Methods != nil && len(t.Methods.List) > 0 {
+			return "", fmt.Errorf("non-empty interface literals are not supported")
+		}
+		return "interface{}", nil
+	case *ast.FuncType:
+		params, err := g.fieldTypes(t.Params)
+		if err != nil {
+			return "", err
+		}
+		results, err := g.fieldTypes(t.Results)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("func(%s) (%s)", strings.Join(params, ", "), strings.Join(results, ", ")), nil
+	}
+	return "", fmt.Errorf("unsupported type %T", expr)
+}
+
+func (g *generator) genericString(x ast.Expr, indices ...ast.Expr) (string, error) {
+	base, err := g.typeString(x)
+	if err != nil {
+		return "", err
+	}
+	args := make([]string, len(indices))
+	for i, index := range indices {
+		if args[i], err = g.typeString(index); err != nil {
+			return "", err
+		}
+	}
+	return fmt.Sprintf("%s[%s]", base, strings.Join(args, ", ")), nil
+}
+
+func (g *generator) render(mocks []mock) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by mockgen. DO NOT EDIT.\n\npackage ahmock\n\nimport (\n")
+	var std, external []string
+	for name := range g.used {
+		importPath := g.imports[name]
+		if name == "ah" {
+			importPath = ahImportPath
+		}
+		imp := strconv.Quote(importPath)
+		if path.Base(importPath) != name {
+			imp = fmt.Sprintf("%s %s", name, imp)
+		}
+		if strings.Contains(strings.Split(importPath, "/")[0], ".") {
+			external = append(external, imp)
+		} else {
+			std = append(std, imp)
+		}
+	}
+	sort.Strings(std)
+	sort.Strings(external)
+	for _, imp := range std {
+		fmt.Fprintf(&buf, "\t%s\n", imp)
+	}
+	buf.WriteString("\n")
+	for _, imp := range external {
+		fmt.Fprintf(&buf, "\t%s\n", imp)
+	}
+	buf.WriteString(")\n")
+
+	for _, m := range mocks {
+		fmt.Fprintf(&buf, "\n// %[1]s is a mock of ah.%[1]s\ntype %[1]s struct {\n\tMock\n}\n\n", m.name)
+		fmt.Fprintf(&buf, "var _ ah.%[1]s = &%[1]s{}\n\n", m.name)
+		fmt.Fprintf(&buf, "// New%[1]s returns a new %[1]s mock\nfunc New%[1]s() *%[1]s {\n\treturn &%[1]s{}\n}\n", m.name)
+		for _, meth := range m.methods {
+			renderMethod(&buf, m.name, meth)
+		}
+	}
+	return format.Source(buf.Bytes())
+}
+
+// renderMethod renders the mock method. Variadic arguments are passed to Called one by one,
+// so calls without them match expectations without them.
+func renderMethod(buf *bytes.Buffer, mockName string, meth method) {
+	params := make([]string, len(meth.params))
+	var args []string
+	variadic := ""
+	for i, typ := range meth.params {
+		params[i] = fmt.Sprintf("arg%d %s", i, typ)
+		if strings.HasPrefix(typ, "...") {
+			variadic = fmt.Sprintf("arg%d", i)
+			continue
+		}
+		args = append(args, fmt.Sprintf("arg%d", i))
+	}
+
+	fmt.Fprintf(buf, "\n// %s mocks ah.%s.%s\n", meth.name, mockName, meth.name)
+	fmt.Fprintf(buf, "func (m *%s) %s(%s) (%s) {\n", mockName, meth.name, strings.Join(params, ", "), strings.Join(meth.results, ", "))
+	called := strings.Join(append([]string{strconv.Quote(meth.name)}, args...), ", ")
+	if variadic != "" {
+		fmt.Fprintf(buf, "\targs := []interface{}{%s}\n", strings.Join(args, ", "))
+		fmt.Fprintf(buf, "\tfor _, arg := range %s {\n\t\targs = append(args, arg)\n\t}\n", variadic)
+		called = strconv.Quote(meth.name) + ", args..."
+	}
+	if len(meth.results) == 0 {
+		fmt.Fprintf(buf, "\t_, _ = m.Called(%s)\n}\n", called)
+		return
+	}
+
+	fmt.Fprintf(buf, "\tresults, err := m.Called(%s)\n", called)
+	returns := make([]string, len(meth.results))
+	usesErr := false
+	for i, typ := range meth.results {
+		name := strconv.Quote(meth.name)
+		switch {
+		case typ == "error":
+			returns[i] = fmt.Sprintf("errorResult(%s, results, %d, err)", name, i)
+			usesErr = true
+		case strings.HasPrefix(typ, "iter.Seq2[") && strings.HasSuffix(typ, ", error]"):
+			elem := strings.TrimSuffix(strings.TrimPrefix(typ, "iter.Seq2["), ", error]")
+			returns[i] = fmt.Sprintf("seqResult[%s](%s, results, %d, err)", elem, name, i)
+			usesErr = true
+		default:
+			returns[i] = fmt.Sprintf("result[%s](%s, results, %d)", typ, name, i)
+		}
+	}
+	if !usesErr {
+		buf.WriteString("\t_ = err\n")
+	}
+	fmt.Fprintf(buf, "\treturn %s\n}\n", strings.Join(returns, ", "))
+}
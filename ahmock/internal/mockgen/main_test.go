@@ -0,0 +1,39 @@
+/*
+Copyright 2023 Advanced Hosting
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"testing"
+)
+
+func TestGenerate_UpToDate(t *testing.T) {
+	generated, err := generate("../../../ah")
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	current, err := os.ReadFile("../../mocks.go")
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	if !bytes.Equal(generated, current) {
+		t.Error("ahmock/mocks.go is outdated, run go generate ./ahmock")
+	}
+}
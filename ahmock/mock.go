@@ -0,0 +1,315 @@
+/*
+Copyright 2023 Advanced Hosting
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ahmock provides mocks of the ah package service interfaces.
+//
+// Every mock embeds Mock, which records calls and returns scripted values.
+// Expectations are matched in registration order, so responses can be
+// sequenced with Once and Times:
+//
+//	instances := ahmock.NewInstancesAPI()
+//	instances.On("Get", ahmock.Anything, "instance-id").Return(&ah.Instance{State: "creating"}, nil).Once()
+//	instances.On("Get", ahmock.Anything, "instance-id").Return(&ah.Instance{State: "running"}, nil)
+//
+//	client := &ah.APIClient{Instances: instances}
+//	// ...
+//	instances.AssertNumberOfCalls(t, "Get", 2)
+//
+// Calls without a matching expectation return zero values and an error wrapping ErrUnexpectedCall.
+package ahmock
+
+//go:generate go run ./internal/mockgen -source ../ah -output mocks.go
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// ErrUnexpectedCall is returned by mocked methods called without a matching expectation
+var ErrUnexpectedCall = errors.New("unexpected call")
+
+// Anything matches any argument
+var Anything Matcher = matcherFunc(func(interface{}) bool { return true })
+
+// Matcher matches an argument of a call
+type Matcher interface {
+	Matches(arg interface{}) bool
+}
+
+type matcherFunc func(interface{}) bool
+
+func (f matcherFunc) Matches(arg interface{}) bool {
+	return f(arg)
+}
+
+// MatchedBy returns a Matcher for arguments of type T accepted by fn
+func MatchedBy[T any](fn func(T) bool) Matcher {
+	return matcherFunc(func(arg interface{}) bool {
+		v, ok := arg.(T)
+		return ok && fn(v)
+	})
+}
+
+// TestingT is the subset of testing.TB used by assertions
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}
+
+// Call is a recorded call of a mocked method
+type Call struct {
+	Method string
+	Args   []interface{}
+}
+
+// Expectation describes the arguments of expected calls and the values they return
+type Expectation struct {
+	method   string
+	args     []interface{}
+	results  []interface{}
+	resultFn func(args []interface{}) []interface{}
+	run      func(args []interface{})
+	times    int
+	calls    int
+}
+
+// Return sets the values returned by the call
+func (e *Expectation) Return(results ...interface{}) *Expectation {
+	e.results = results
+	return e
+}
+
+// ReturnFn sets a function computing the values returned by the call from its arguments
+func (e *Expectation) ReturnFn(fn func(args []interface{}) []interface{}) *Expectation {
+	e.resultFn = fn
+	return e
+}
+
+// Run sets a function called with the arguments of the call before returning
+func (e *Expectation) Run(fn func(args []interface{})) *Expectation {
+	e.run = fn
+	return e
+}
+
+// Times limits the number of calls matched by the expectation
+func (e *Expectation) Times(n int) *Expectation {
+	e.times = n
+	return e
+}
+
+// Once limits the expectation to a single call
+func (e *Expectation) Once() *Expectation {
+	return e.Times(1)
+}
+
+func (e *Expectation) exhausted() bool {
+	return e.times > 0 && e.calls >= e.times
+}
+
+func (e *Expectation) matches(method string, args []interface{}) bool {
+	if e.method != method || len(e.args) > len(args) {
+		return false
+	}
+	for i, expected := range e.args {
+		if !matchArg(expected, args[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func matchArg(expected, actual interface{}) bool {
+	if matcher, ok := expected.(Matcher); ok {
+		return matcher.Matches(actual)
+	}
+	return reflect.DeepEqual(expected, actual)
+}
+
+// Mock records calls and returns values of matching expectations. It's safe for concurrent use.
+type Mock struct {
+	mu           sync.Mutex
+	calls        []Call
+	expectations []*Expectation
+}
+
+// On adds an expectation of the method call. Arguments are compared with reflect.DeepEqual
+// unless they are a Matcher. Omitted trailing arguments match anything.
+func (m *Mock) On(method string, args ...interface{}) *Expectation {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e := &Expectation{method: method, args: args}
+	m.expectations = append(m.expectations, e)
+	return e
+}
+
+// Reset removes all expectations and recorded calls
+func (m *Mock) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = nil
+	m.expectations = nil
+}
+
+// Called records the call and returns the values of the first matching expectation.
+// It's used by the generated mocks.
+func (m *Mock) Called(method string, args ...interface{}) ([]interface{}, error) {
+	m.mu.Lock()
+	m.calls = append(m.calls, Call{Method: method, Args: args})
+	var expectation *Expectation
+	for _, e := range m.expectations {
+		if !e.exhausted() && e.matches(method, args) {
+			expectation = e
+			e.calls++
+			break
+		}
+	}
+	m.mu.Unlock()
+
+	if expectation == nil {
+		return nil, fmt.Errorf("%w: %s(%s)", ErrUnexpectedCall, method, formatArgs(args))
+	}
+	if expectation.run != nil {
+		expectation.run(args)
+	}
+	if expectation.resultFn != nil {
+		return expectation.resultFn(args), nil
+	}
+	return expectation.results, nil
+}
+
+// Calls returns recorded calls of the method. All calls are returned if method is empty.
+func (m *Mock) Calls(method string) []Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var result []Call
+	for _, call := range m.calls {
+		if method == "" || call.Method == method {
+			result = append(result, call)
+		}
+	}
+	return result
+}
+
+// AssertCalled asserts that the method was called with matching arguments
+func (m *Mock) AssertCalled(t TestingT, method string, args ...interface{}) bool {
+	t.Helper()
+	expected := &Expectation{method: method, args: args}
+	for _, call := range m.Calls(method) {
+		if expected.matches(call.Method, call.Args) {
+			return true
+		}
+	}
+	t.Errorf("ahmock: expected call %s(%s) not found in %v", method, formatArgs(args), m.Calls(""))
+	return false
+}
+
+// AssertNotCalled asserts that the method wasn't called with matching arguments
+func (m *Mock) AssertNotCalled(t TestingT, method string, args ...interface{}) bool {
+	t.Helper()
+	expected := &Expectation{method: method, args: args}
+	for _, call := range m.Calls(method) {
+		if expected.matches(call.Method, call.Args) {
+			t.Errorf("ahmock: unexpected call %s(%s)", method, formatArgs(call.Args))
+			return false
+		}
+	}
+	return true
+}
+
+// AssertNumberOfCalls asserts the number of calls of the method
+func (m *Mock) AssertNumberOfCalls(t TestingT, method string, expected int) bool {
+	t.Helper()
+	if actual := len(m.Calls(method)); actual != expected {
+		t.Errorf("ahmock: expected %d calls of %s, got %d", expected, method, actual)
+		return false
+	}
+	return true
+}
+
+// AssertExpectations asserts that every expectation was called, the ones limited with Times exactly n times
+func (m *Mock) AssertExpectations(t TestingT) bool {
+	t.Helper()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	ok := true
+	for _, e := range m.expectations {
+		if e.calls == 0 || (e.times > 0 && e.calls != e.times) {
+			t.Errorf("ahmock: expectation %s(%s) was called %d times", e.method, formatArgs(e.args), e.calls)
+			ok = false
+		}
+	}
+	return ok
+}
+
+func formatArgs(args []interface{}) string {
+	formatted := make([]string, len(args))
+	for i, arg := range args {
+		formatted[i] = fmt.Sprintf("%#v", arg)
+	}
+	return strings.Join(formatted, ", ")
+}
+
+// Seq returns an iterator over items followed by err if it's not nil.
+// It's useful as a return value of mocked All methods.
+func Seq[T any](items []T, err error) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for _, item := range items {
+			if !yield(item, nil) {
+				return
+			}
+		}
+		if err != nil {
+			var zero T
+			yield(zero, err)
+		}
+	}
+}
+
+// result returns i-th result converted to T or zero value of T
+func result[T any](method string, results []interface{}, i int) T {
+	var zero T
+	if i >= len(results) || results[i] == nil {
+		return zero
+	}
+	v, ok := results[i].(T)
+	if !ok {
+		panic(fmt.Sprintf("ahmock: %s result %d is %T, expected %T", method, i, results[i], zero))
+	}
+	return v
+}
+
+// errorResult returns i-th result as error, or err if the call was unexpected
+func errorResult(method string, results []interface{}, i int, err error) error {
+	if err != nil {
+		return err
+	}
+	return result[error](method, results, i)
+}
+
+// seqResult returns i-th result as iterator, or an iterator yielding err if the call was unexpected
+func seqResult[T any](method string, results []interface{}, i int, err error) iter.Seq2[T, error] {
+	if err != nil {
+		return Seq[T](nil, err)
+	}
+	if seq := result[iter.Seq2[T, error]](method, results, i); seq != nil {
+		return seq
+	}
+	return Seq[T](nil, nil)
+}
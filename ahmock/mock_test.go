@@ -0,0 +1,184 @@
+/*
+Copyright 2023 Advanced Hosting
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ahmock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/advancedhosting/advancedhosting-api-go/ah"
+)
+
+type fakeT struct {
+	errors []string
+}
+
+func (t *fakeT) Helper() {}
+
+func (t *fakeT) Errorf(format string, args ...interface{}) {
+	t.errors = append(t.errors, fmt.Sprintf(format, args...))
+}
+
+func TestMock_Sequence(t *testing.T) {
+	instances := NewInstancesAPI()
+	instances.On("Get", Anything, "instance_id").Return(&ah.Instance{ID: "instance_id", State: ah.InstanceStateCreating}, nil).Times(2)
+	instances.On("Get", Anything, "instance_id").Return(&ah.Instance{ID: "instance_id", State: ah.InstanceStateRunning}, nil)
+
+	client := &ah.APIClient{Instances: instances}
+	instance, err := client.WaitForInstanceState(context.Background(), "instance_id", ah.InstanceStateRunning, &ah.WaitOptions{PollInterval: time.Millisecond})
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if instance.State != ah.InstanceStateRunning {
+		t.Errorf("Unexpected state %s", instance.State)
+	}
+
+	instances.AssertNumberOfCalls(t, "Get", 3)
+	instances.AssertCalled(t, "Get", Anything, "instance_id")
+	instances.AssertNotCalled(t, "Destroy")
+	instances.AssertExpectations(t)
+}
+
+func TestMock_WaitForAction(t *testing.T) {
+	running := &ah.Action{ID: "action_id", ResourceID: "instance_id", ResourceType: "instance", State: ah.ActionStateRunning}
+	instances := NewInstancesAPI()
+	instances.On("ActionInfo", Anything, "instance_id", "action_id").Return(&ah.InstanceAction{Action: running}, nil).Once()
+	instances.On("ActionInfo", Anything, "instance_id", "action_id").Return(&ah.InstanceAction{Action: &ah.Action{
+		ID: "action_id", ResourceID: "instance_id", ResourceType: "instance", State: ah.ActionStateSuccess,
+	}}, nil)
+
+	client := &ah.APIClient{Instances: instances}
+	action, err := client.WaitForAction(context.Background(), running, &ah.WaitOptions{PollInterval: time.Millisecond})
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if action.State != ah.ActionStateSuccess {
+		t.Errorf("Unexpected state %s", action.State)
+	}
+
+	instances.AssertNumberOfCalls(t, "ActionInfo", 2)
+	instances.AssertExpectations(t)
+}
+
+func TestMock_UnexpectedCall(t *testing.T) {
+	volumes := NewVolumesAPI()
+	volumes.On("Get", Anything, "volume_id").Return(&ah.Volume{ID: "volume_id"}, nil)
+
+	volume, err := volumes.Get(context.Background(), "other_id")
+	if !errors.Is(err, ErrUnexpectedCall) {
+		t.Errorf("Unexpected error %v", err)
+	}
+	if volume != nil {
+		t.Errorf("Unexpected volume %v", volume)
+	}
+
+	for _, err := range volumes.All(context.Background(), nil) {
+		if !errors.Is(err, ErrUnexpectedCall) {
+			t.Errorf("Unexpected error %v", err)
+		}
+	}
+}
+
+func TestMock_ScriptedErrorsAndFunctions(t *testing.T) {
+	ctx := context.Background()
+	expectedErr := errors.New("failure")
+
+	loadBalancers := NewLoadBalancersAPI()
+	loadBalancers.On("Delete", Anything, "lb_id").Return(expectedErr).Once()
+	loadBalancers.On("Delete").Return(nil)
+	loadBalancers.On("Create", Anything, MatchedBy(func(r *ah.LoadBalancerCreateRequest) bool {
+		return r.Name != ""
+	})).ReturnFn(func(args []interface{}) []interface{} {
+		return []interface{}{&ah.LoadBalancer{Name: args[1].(*ah.LoadBalancerCreateRequest).Name}, nil}
+	})
+
+	if err := loadBalancers.Delete(ctx, "lb_id"); err != expectedErr {
+		t.Errorf("Unexpected error %v", err)
+	}
+	if err := loadBalancers.Delete(ctx, "lb_id"); err != nil {
+		t.Errorf("Unexpected error %v", err)
+	}
+
+	lb, err := loadBalancers.Create(ctx, &ah.LoadBalancerCreateRequest{Name: "lb"})
+	if err != nil || lb.Name != "lb" {
+		t.Errorf("Unexpected result %v, %v", lb, err)
+	}
+	if _, err := loadBalancers.Create(ctx, &ah.LoadBalancerCreateRequest{}); !errors.Is(err, ErrUnexpectedCall) {
+		t.Errorf("Unexpected error %v", err)
+	}
+
+	calls := loadBalancers.Calls("Create")
+	if len(calls) != 2 {
+		t.Errorf("Unexpected calls %v", calls)
+	}
+}
+
+func TestMock_Seq(t *testing.T) {
+	expectedErr := errors.New("failure")
+	sshKeys := NewSSHKeysAPI()
+	sshKeys.On("All").Return(Seq([]ah.SSHKey{{ID: "1"}, {ID: "2"}}, expectedErr))
+
+	keys, err := ah.ListAll(sshKeys.All(context.Background(), nil))
+	if err != expectedErr || keys != nil {
+		t.Errorf("Unexpected result %v, %v", keys, err)
+	}
+
+	sshKeys.Reset()
+	sshKeys.On("All").Return(Seq([]ah.SSHKey{{ID: "1"}, {ID: "2"}}, nil))
+	if keys, err = ah.ListAll(sshKeys.All(context.Background(), nil)); err != nil || len(keys) != 2 {
+		t.Errorf("Unexpected result %v, %v", keys, err)
+	}
+}
+
+func TestMock_FailedAssertions(t *testing.T) {
+	backups := NewBackupsAPI()
+	backups.On("Get", Anything, "backup_id").Return(&ah.Backup{}, nil).Once()
+	backups.On("Delete")
+	_, _ = backups.Get(context.Background(), "other_id")
+
+	ft := &fakeT{}
+	if backups.AssertCalled(ft, "Get", Anything, "backup_id") {
+		t.Error("AssertCalled must fail")
+	}
+	if backups.AssertNotCalled(ft, "Get", Anything, "other_id") {
+		t.Error("AssertNotCalled must fail")
+	}
+	if backups.AssertNumberOfCalls(ft, "Get", 2) {
+		t.Error("AssertNumberOfCalls must fail")
+	}
+	if backups.AssertExpectations(ft) {
+		t.Error("AssertExpectations must fail")
+	}
+	if len(ft.errors) != 5 {
+		t.Errorf("Unexpected errors %v", ft.errors)
+	}
+}
+
+func TestMock_WrongResultType(t *testing.T) {
+	datacenters := NewDatacentersAPI()
+	datacenters.On("Get").Return("datacenter", nil)
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected panic")
+		}
+	}()
+	_, _ = datacenters.Get(context.Background(), "datacenter_id")
+}
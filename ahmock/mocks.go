@@ -0,0 +1,1604 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package ahmock
+
+import (
+	"context"
+	"iter"
+
+	"github.com/advancedhosting/advancedhosting-api-go/ah"
+)
+
+// BackupsAPI is a mock of ah.BackupsAPI
+type BackupsAPI struct {
+	Mock
+}
+
+var _ ah.BackupsAPI = &BackupsAPI{}
+
+// NewBackupsAPI returns a new BackupsAPI mock
+func NewBackupsAPI() *BackupsAPI {
+	return &BackupsAPI{}
+}
+
+// List mocks ah.BackupsAPI.List
+func (m *BackupsAPI) List(arg0 context.Context, arg1 *ah.ListOptions, arg2 ...ah.RequestOption) ([]ah.InstanceBackups, error) {
+	args := []interface{}{arg0, arg1}
+	for _, arg := range arg2 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("List", args...)
+	return result[[]ah.InstanceBackups]("List", results, 0), errorResult("List", results, 1, err)
+}
+
+// ListPage mocks ah.BackupsAPI.ListPage
+func (m *BackupsAPI) ListPage(arg0 context.Context, arg1 *ah.ListOptions, arg2 ...ah.RequestOption) ([]ah.BackupWithEmbeddedInstance, *ah.Meta, error) {
+	args := []interface{}{arg0, arg1}
+	for _, arg := range arg2 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("ListPage", args...)
+	return result[[]ah.BackupWithEmbeddedInstance]("ListPage", results, 0), result[*ah.Meta]("ListPage", results, 1), errorResult("ListPage", results, 2, err)
+}
+
+// All mocks ah.BackupsAPI.All
+func (m *BackupsAPI) All(arg0 context.Context, arg1 *ah.ListOptions, arg2 ...ah.RequestOption) iter.Seq2[ah.BackupWithEmbeddedInstance, error] {
+	args := []interface{}{arg0, arg1}
+	for _, arg := range arg2 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("All", args...)
+	return seqResult[ah.BackupWithEmbeddedInstance]("All", results, 0, err)
+}
+
+// Get mocks ah.BackupsAPI.Get
+func (m *BackupsAPI) Get(arg0 context.Context, arg1 string, arg2 ...ah.RequestOption) (*ah.Backup, error) {
+	args := []interface{}{arg0, arg1}
+	for _, arg := range arg2 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("Get", args...)
+	return result[*ah.Backup]("Get", results, 0), errorResult("Get", results, 1, err)
+}
+
+// Update mocks ah.BackupsAPI.Update
+func (m *BackupsAPI) Update(arg0 context.Context, arg1 string, arg2 *ah.BackUpUpdateRequest, arg3 ...ah.RequestOption) (*ah.Backup, error) {
+	args := []interface{}{arg0, arg1, arg2}
+	for _, arg := range arg3 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("Update", args...)
+	return result[*ah.Backup]("Update", results, 0), errorResult("Update", results, 1, err)
+}
+
+// Delete mocks ah.BackupsAPI.Delete
+func (m *BackupsAPI) Delete(arg0 context.Context, arg1 string, arg2 ...ah.RequestOption) (*ah.Action, error) {
+	args := []interface{}{arg0, arg1}
+	for _, arg := range arg2 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("Delete", args...)
+	return result[*ah.Action]("Delete", results, 0), errorResult("Delete", results, 1, err)
+}
+
+// DatacentersAPI is a mock of ah.DatacentersAPI
+type DatacentersAPI struct {
+	Mock
+}
+
+var _ ah.DatacentersAPI = &DatacentersAPI{}
+
+// NewDatacentersAPI returns a new DatacentersAPI mock
+func NewDatacentersAPI() *DatacentersAPI {
+	return &DatacentersAPI{}
+}
+
+// List mocks ah.DatacentersAPI.List
+func (m *DatacentersAPI) List(arg0 context.Context, arg1 *ah.ListOptions, arg2 ...ah.RequestOption) ([]ah.Datacenter, error) {
+	args := []interface{}{arg0, arg1}
+	for _, arg := range arg2 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("List", args...)
+	return result[[]ah.Datacenter]("List", results, 0), errorResult("List", results, 1, err)
+}
+
+// ListPage mocks ah.DatacentersAPI.ListPage
+func (m *DatacentersAPI) ListPage(arg0 context.Context, arg1 *ah.ListOptions, arg2 ...ah.RequestOption) ([]ah.Datacenter, *ah.Meta, error) {
+	args := []interface{}{arg0, arg1}
+	for _, arg := range arg2 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("ListPage", args...)
+	return result[[]ah.Datacenter]("ListPage", results, 0), result[*ah.Meta]("ListPage", results, 1), errorResult("ListPage", results, 2, err)
+}
+
+// All mocks ah.DatacentersAPI.All
+func (m *DatacentersAPI) All(arg0 context.Context, arg1 *ah.ListOptions, arg2 ...ah.RequestOption) iter.Seq2[ah.Datacenter, error] {
+	args := []interface{}{arg0, arg1}
+	for _, arg := range arg2 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("All", args...)
+	return seqResult[ah.Datacenter]("All", results, 0, err)
+}
+
+// Get mocks ah.DatacentersAPI.Get
+func (m *DatacentersAPI) Get(arg0 context.Context, arg1 string, arg2 ...ah.RequestOption) (*ah.Datacenter, error) {
+	args := []interface{}{arg0, arg1}
+	for _, arg := range arg2 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("Get", args...)
+	return result[*ah.Datacenter]("Get", results, 0), errorResult("Get", results, 1, err)
+}
+
+// IPAddressAssignmentsAPI is a mock of ah.IPAddressAssignmentsAPI
+type IPAddressAssignmentsAPI struct {
+	Mock
+}
+
+var _ ah.IPAddressAssignmentsAPI = &IPAddressAssignmentsAPI{}
+
+// NewIPAddressAssignmentsAPI returns a new IPAddressAssignmentsAPI mock
+func NewIPAddressAssignmentsAPI() *IPAddressAssignmentsAPI {
+	return &IPAddressAssignmentsAPI{}
+}
+
+// Create mocks ah.IPAddressAssignmentsAPI.Create
+func (m *IPAddressAssignmentsAPI) Create(arg0 context.Context, arg1 *ah.IPAddressAssignmentCreateRequest, arg2 ...ah.RequestOption) (*ah.IPAddressAssignment, error) {
+	args := []interface{}{arg0, arg1}
+	for _, arg := range arg2 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("Create", args...)
+	return result[*ah.IPAddressAssignment]("Create", results, 0), errorResult("Create", results, 1, err)
+}
+
+// Get mocks ah.IPAddressAssignmentsAPI.Get
+func (m *IPAddressAssignmentsAPI) Get(arg0 context.Context, arg1 string, arg2 ...ah.RequestOption) (*ah.IPAddressAssignment, error) {
+	args := []interface{}{arg0, arg1}
+	for _, arg := range arg2 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("Get", args...)
+	return result[*ah.IPAddressAssignment]("Get", results, 0), errorResult("Get", results, 1, err)
+}
+
+// List mocks ah.IPAddressAssignmentsAPI.List
+func (m *IPAddressAssignmentsAPI) List(arg0 context.Context, arg1 *ah.ListOptions, arg2 ...ah.RequestOption) ([]ah.IPAddressAssignment, error) {
+	args := []interface{}{arg0, arg1}
+	for _, arg := range arg2 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("List", args...)
+	return result[[]ah.IPAddressAssignment]("List", results, 0), errorResult("List", results, 1, err)
+}
+
+// ListPage mocks ah.IPAddressAssignmentsAPI.ListPage
+func (m *IPAddressAssignmentsAPI) ListPage(arg0 context.Context, arg1 *ah.ListOptions, arg2 ...ah.RequestOption) ([]ah.IPAddressAssignment, *ah.Meta, error) {
+	args := []interface{}{arg0, arg1}
+	for _, arg := range arg2 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("ListPage", args...)
+	return result[[]ah.IPAddressAssignment]("ListPage", results, 0), result[*ah.Meta]("ListPage", results, 1), errorResult("ListPage", results, 2, err)
+}
+
+// All mocks ah.IPAddressAssignmentsAPI.All
+func (m *IPAddressAssignmentsAPI) All(arg0 context.Context, arg1 *ah.ListOptions, arg2 ...ah.RequestOption) iter.Seq2[ah.IPAddressAssignment, error] {
+	args := []interface{}{arg0, arg1}
+	for _, arg := range arg2 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("All", args...)
+	return seqResult[ah.IPAddressAssignment]("All", results, 0, err)
+}
+
+// Delete mocks ah.IPAddressAssignmentsAPI.Delete
+func (m *IPAddressAssignmentsAPI) Delete(arg0 context.Context, arg1 string, arg2 ...ah.RequestOption) error {
+	args := []interface{}{arg0, arg1}
+	for _, arg := range arg2 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("Delete", args...)
+	return errorResult("Delete", results, 0, err)
+}
+
+// IPAddressesAPI is a mock of ah.IPAddressesAPI
+type IPAddressesAPI struct {
+	Mock
+}
+
+var _ ah.IPAddressesAPI = &IPAddressesAPI{}
+
+// NewIPAddressesAPI returns a new IPAddressesAPI mock
+func NewIPAddressesAPI() *IPAddressesAPI {
+	return &IPAddressesAPI{}
+}
+
+// List mocks ah.IPAddressesAPI.List
+func (m *IPAddressesAPI) List(arg0 context.Context, arg1 *ah.ListOptions, arg2 ...ah.RequestOption) ([]ah.IPAddress, error) {
+	args := []interface{}{arg0, arg1}
+	for _, arg := range arg2 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("List", args...)
+	return result[[]ah.IPAddress]("List", results, 0), errorResult("List", results, 1, err)
+}
+
+// ListPage mocks ah.IPAddressesAPI.ListPage
+func (m *IPAddressesAPI) ListPage(arg0 context.Context, arg1 *ah.ListOptions, arg2 ...ah.RequestOption) ([]ah.IPAddress, *ah.Meta, error) {
+	args := []interface{}{arg0, arg1}
+	for _, arg := range arg2 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("ListPage", args...)
+	return result[[]ah.IPAddress]("ListPage", results, 0), result[*ah.Meta]("ListPage", results, 1), errorResult("ListPage", results, 2, err)
+}
+
+// All mocks ah.IPAddressesAPI.All
+func (m *IPAddressesAPI) All(arg0 context.Context, arg1 *ah.ListOptions, arg2 ...ah.RequestOption) iter.Seq2[ah.IPAddress, error] {
+	args := []interface{}{arg0, arg1}
+	for _, arg := range arg2 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("All", args...)
+	return seqResult[ah.IPAddress]("All", results, 0, err)
+}
+
+// Create mocks ah.IPAddressesAPI.Create
+func (m *IPAddressesAPI) Create(arg0 context.Context, arg1 *ah.IPAddressCreateRequest, arg2 ...ah.RequestOption) (*ah.IPAddress, error) {
+	args := []interface{}{arg0, arg1}
+	for _, arg := range arg2 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("Create", args...)
+	return result[*ah.IPAddress]("Create", results, 0), errorResult("Create", results, 1, err)
+}
+
+// Get mocks ah.IPAddressesAPI.Get
+func (m *IPAddressesAPI) Get(arg0 context.Context, arg1 string, arg2 ...ah.RequestOption) (*ah.IPAddress, error) {
+	args := []interface{}{arg0, arg1}
+	for _, arg := range arg2 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("Get", args...)
+	return result[*ah.IPAddress]("Get", results, 0), errorResult("Get", results, 1, err)
+}
+
+// Delete mocks ah.IPAddressesAPI.Delete
+func (m *IPAddressesAPI) Delete(arg0 context.Context, arg1 string, arg2 ...ah.RequestOption) error {
+	args := []interface{}{arg0, arg1}
+	for _, arg := range arg2 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("Delete", args...)
+	return errorResult("Delete", results, 0, err)
+}
+
+// Update mocks ah.IPAddressesAPI.Update
+func (m *IPAddressesAPI) Update(arg0 context.Context, arg1 string, arg2 *ah.IPAddressUpdateRequest, arg3 ...ah.RequestOption) (*ah.IPAddress, error) {
+	args := []interface{}{arg0, arg1, arg2}
+	for _, arg := range arg3 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("Update", args...)
+	return result[*ah.IPAddress]("Update", results, 0), errorResult("Update", results, 1, err)
+}
+
+// ImagesAPI is a mock of ah.ImagesAPI
+type ImagesAPI struct {
+	Mock
+}
+
+var _ ah.ImagesAPI = &ImagesAPI{}
+
+// NewImagesAPI returns a new ImagesAPI mock
+func NewImagesAPI() *ImagesAPI {
+	return &ImagesAPI{}
+}
+
+// List mocks ah.ImagesAPI.List
+func (m *ImagesAPI) List(arg0 context.Context, arg1 *ah.ListOptions, arg2 ...ah.RequestOption) ([]ah.Image, *ah.Meta, error) {
+	args := []interface{}{arg0, arg1}
+	for _, arg := range arg2 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("List", args...)
+	return result[[]ah.Image]("List", results, 0), result[*ah.Meta]("List", results, 1), errorResult("List", results, 2, err)
+}
+
+// All mocks ah.ImagesAPI.All
+func (m *ImagesAPI) All(arg0 context.Context, arg1 *ah.ListOptions, arg2 ...ah.RequestOption) iter.Seq2[ah.Image, error] {
+	args := []interface{}{arg0, arg1}
+	for _, arg := range arg2 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("All", args...)
+	return seqResult[ah.Image]("All", results, 0, err)
+}
+
+// InstancePlansAPI is a mock of ah.InstancePlansAPI
+type InstancePlansAPI struct {
+	Mock
+}
+
+var _ ah.InstancePlansAPI = &InstancePlansAPI{}
+
+// NewInstancePlansAPI returns a new InstancePlansAPI mock
+func NewInstancePlansAPI() *InstancePlansAPI {
+	return &InstancePlansAPI{}
+}
+
+// List mocks ah.InstancePlansAPI.List
+func (m *InstancePlansAPI) List(arg0 context.Context, arg1 ...ah.RequestOption) ([]ah.InstancePlan, error) {
+	args := []interface{}{arg0}
+	for _, arg := range arg1 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("List", args...)
+	return result[[]ah.InstancePlan]("List", results, 0), errorResult("List", results, 1, err)
+}
+
+// ListPage mocks ah.InstancePlansAPI.ListPage
+func (m *InstancePlansAPI) ListPage(arg0 context.Context, arg1 *ah.ListOptions, arg2 ...ah.RequestOption) ([]ah.InstancePlan, *ah.Meta, error) {
+	args := []interface{}{arg0, arg1}
+	for _, arg := range arg2 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("ListPage", args...)
+	return result[[]ah.InstancePlan]("ListPage", results, 0), result[*ah.Meta]("ListPage", results, 1), errorResult("ListPage", results, 2, err)
+}
+
+// All mocks ah.InstancePlansAPI.All
+func (m *InstancePlansAPI) All(arg0 context.Context, arg1 *ah.ListOptions, arg2 ...ah.RequestOption) iter.Seq2[ah.InstancePlan, error] {
+	args := []interface{}{arg0, arg1}
+	for _, arg := range arg2 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("All", args...)
+	return seqResult[ah.InstancePlan]("All", results, 0, err)
+}
+
+// InstancePrivateNetworksAPI is a mock of ah.InstancePrivateNetworksAPI
+type InstancePrivateNetworksAPI struct {
+	Mock
+}
+
+var _ ah.InstancePrivateNetworksAPI = &InstancePrivateNetworksAPI{}
+
+// NewInstancePrivateNetworksAPI returns a new InstancePrivateNetworksAPI mock
+func NewInstancePrivateNetworksAPI() *InstancePrivateNetworksAPI {
+	return &InstancePrivateNetworksAPI{}
+}
+
+// Create mocks ah.InstancePrivateNetworksAPI.Create
+func (m *InstancePrivateNetworksAPI) Create(arg0 context.Context, arg1 *ah.InstancePrivateNetworkCreateRequest, arg2 ...ah.RequestOption) (*ah.InstancePrivateNetwork, error) {
+	args := []interface{}{arg0, arg1}
+	for _, arg := range arg2 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("Create", args...)
+	return result[*ah.InstancePrivateNetwork]("Create", results, 0), errorResult("Create", results, 1, err)
+}
+
+// Get mocks ah.InstancePrivateNetworksAPI.Get
+func (m *InstancePrivateNetworksAPI) Get(arg0 context.Context, arg1 string, arg2 ...ah.RequestOption) (*ah.InstancePrivateNetwork, error) {
+	args := []interface{}{arg0, arg1}
+	for _, arg := range arg2 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("Get", args...)
+	return result[*ah.InstancePrivateNetwork]("Get", results, 0), errorResult("Get", results, 1, err)
+}
+
+// Update mocks ah.InstancePrivateNetworksAPI.Update
+func (m *InstancePrivateNetworksAPI) Update(arg0 context.Context, arg1 string, arg2 *ah.InstancePrivateNetworkUpdateRequest, arg3 ...ah.RequestOption) (*ah.InstancePrivateNetwork, error) {
+	args := []interface{}{arg0, arg1, arg2}
+	for _, arg := range arg3 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("Update", args...)
+	return result[*ah.InstancePrivateNetwork]("Update", results, 0), errorResult("Update", results, 1, err)
+}
+
+// Delete mocks ah.InstancePrivateNetworksAPI.Delete
+func (m *InstancePrivateNetworksAPI) Delete(arg0 context.Context, arg1 string, arg2 ...ah.RequestOption) (*ah.InstancePrivateNetwork, error) {
+	args := []interface{}{arg0, arg1}
+	for _, arg := range arg2 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("Delete", args...)
+	return result[*ah.InstancePrivateNetwork]("Delete", results, 0), errorResult("Delete", results, 1, err)
+}
+
+// InstanceProductsAPI is a mock of ah.InstanceProductsAPI
+type InstanceProductsAPI struct {
+	Mock
+}
+
+var _ ah.InstanceProductsAPI = &InstanceProductsAPI{}
+
+// NewInstanceProductsAPI returns a new InstanceProductsAPI mock
+func NewInstanceProductsAPI() *InstanceProductsAPI {
+	return &InstanceProductsAPI{}
+}
+
+// List mocks ah.InstanceProductsAPI.List
+func (m *InstanceProductsAPI) List(arg0 context.Context, arg1 *ah.ListOptions, arg2 ...ah.RequestOption) ([]ah.InstanceProduct, *ah.Meta, error) {
+	args := []interface{}{arg0, arg1}
+	for _, arg := range arg2 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("List", args...)
+	return result[[]ah.InstanceProduct]("List", results, 0), result[*ah.Meta]("List", results, 1), errorResult("List", results, 2, err)
+}
+
+// InstancesAPI is a mock of ah.InstancesAPI
+type InstancesAPI struct {
+	Mock
+}
+
+var _ ah.InstancesAPI = &InstancesAPI{}
+
+// NewInstancesAPI returns a new InstancesAPI mock
+func NewInstancesAPI() *InstancesAPI {
+	return &InstancesAPI{}
+}
+
+// List mocks ah.InstancesAPI.List
+func (m *InstancesAPI) List(arg0 context.Context, arg1 *ah.ListOptions, arg2 ...ah.RequestOption) ([]ah.Instance, *ah.Meta, error) {
+	args := []interface{}{arg0, arg1}
+	for _, arg := range arg2 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("List", args...)
+	return result[[]ah.Instance]("List", results, 0), result[*ah.Meta]("List", results, 1), errorResult("List", results, 2, err)
+}
+
+// All mocks ah.InstancesAPI.All
+func (m *InstancesAPI) All(arg0 context.Context, arg1 *ah.ListOptions, arg2 ...ah.RequestOption) iter.Seq2[ah.Instance, error] {
+	args := []interface{}{arg0, arg1}
+	for _, arg := range arg2 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("All", args...)
+	return seqResult[ah.Instance]("All", results, 0, err)
+}
+
+// Get mocks ah.InstancesAPI.Get
+func (m *InstancesAPI) Get(arg0 context.Context, arg1 string, arg2 ...ah.RequestOption) (*ah.Instance, error) {
+	args := []interface{}{arg0, arg1}
+	for _, arg := range arg2 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("Get", args...)
+	return result[*ah.Instance]("Get", results, 0), errorResult("Get", results, 1, err)
+}
+
+// Create mocks ah.InstancesAPI.Create
+func (m *InstancesAPI) Create(arg0 context.Context, arg1 *ah.InstanceCreateRequest, arg2 ...ah.RequestOption) (*ah.Instance, error) {
+	args := []interface{}{arg0, arg1}
+	for _, arg := range arg2 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("Create", args...)
+	return result[*ah.Instance]("Create", results, 0), errorResult("Create", results, 1, err)
+}
+
+// Rename mocks ah.InstancesAPI.Rename
+func (m *InstancesAPI) Rename(arg0 context.Context, arg1 string, arg2 string, arg3 ...ah.RequestOption) (*ah.Instance, error) {
+	args := []interface{}{arg0, arg1, arg2}
+	for _, arg := range arg3 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("Rename", args...)
+	return result[*ah.Instance]("Rename", results, 0), errorResult("Rename", results, 1, err)
+}
+
+// Update mocks ah.InstancesAPI.Update
+func (m *InstancesAPI) Update(arg0 context.Context, arg1 string, arg2 *ah.InstanceUpdateRequest, arg3 ...ah.RequestOption) (*ah.Instance, error) {
+	args := []interface{}{arg0, arg1, arg2}
+	for _, arg := range arg3 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("Update", args...)
+	return result[*ah.Instance]("Update", results, 0), errorResult("Update", results, 1, err)
+}
+
+// AddTags mocks ah.InstancesAPI.AddTags
+func (m *InstancesAPI) AddTags(arg0 context.Context, arg1 string, arg2 []string, arg3 ...ah.RequestOption) (*ah.Instance, error) {
+	args := []interface{}{arg0, arg1, arg2}
+	for _, arg := range arg3 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("AddTags", args...)
+	return result[*ah.Instance]("AddTags", results, 0), errorResult("AddTags", results, 1, err)
+}
+
+// RemoveTags mocks ah.InstancesAPI.RemoveTags
+func (m *InstancesAPI) RemoveTags(arg0 context.Context, arg1 string, arg2 []string, arg3 ...ah.RequestOption) (*ah.Instance, error) {
+	args := []interface{}{arg0, arg1, arg2}
+	for _, arg := range arg3 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("RemoveTags", args...)
+	return result[*ah.Instance]("RemoveTags", results, 0), errorResult("RemoveTags", results, 1, err)
+}
+
+// Upgrade mocks ah.InstancesAPI.Upgrade
+func (m *InstancesAPI) Upgrade(arg0 context.Context, arg1 string, arg2 *ah.InstanceUpgradeRequest, arg3 ...ah.RequestOption) error {
+	args := []interface{}{arg0, arg1, arg2}
+	for _, arg := range arg3 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("Upgrade", args...)
+	return errorResult("Upgrade", results, 0, err)
+}
+
+// Shutdown mocks ah.InstancesAPI.Shutdown
+func (m *InstancesAPI) Shutdown(arg0 context.Context, arg1 string, arg2 ...ah.RequestOption) error {
+	args := []interface{}{arg0, arg1}
+	for _, arg := range arg2 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("Shutdown", args...)
+	return errorResult("Shutdown", results, 0, err)
+}
+
+// PowerOff mocks ah.InstancesAPI.PowerOff
+func (m *InstancesAPI) PowerOff(arg0 context.Context, arg1 string, arg2 ...ah.RequestOption) error {
+	args := []interface{}{arg0, arg1}
+	for _, arg := range arg2 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("PowerOff", args...)
+	return errorResult("PowerOff", results, 0, err)
+}
+
+// PowerOn mocks ah.InstancesAPI.PowerOn
+func (m *InstancesAPI) PowerOn(arg0 context.Context, arg1 string, arg2 ...ah.RequestOption) (*ah.InstanceAction, error) {
+	args := []interface{}{arg0, arg1}
+	for _, arg := range arg2 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("PowerOn", args...)
+	return result[*ah.InstanceAction]("PowerOn", results, 0), errorResult("PowerOn", results, 1, err)
+}
+
+// Reboot mocks ah.InstancesAPI.Reboot
+func (m *InstancesAPI) Reboot(arg0 context.Context, arg1 string, arg2 ...ah.RequestOption) (*ah.InstanceAction, error) {
+	args := []interface{}{arg0, arg1}
+	for _, arg := range arg2 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("Reboot", args...)
+	return result[*ah.InstanceAction]("Reboot", results, 0), errorResult("Reboot", results, 1, err)
+}
+
+// HardReboot mocks ah.InstancesAPI.HardReboot
+func (m *InstancesAPI) HardReboot(arg0 context.Context, arg1 string, arg2 ...ah.RequestOption) (*ah.InstanceAction, error) {
+	args := []interface{}{arg0, arg1}
+	for _, arg := range arg2 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("HardReboot", args...)
+	return result[*ah.InstanceAction]("HardReboot", results, 0), errorResult("HardReboot", results, 1, err)
+}
+
+// Reset mocks ah.InstancesAPI.Reset
+func (m *InstancesAPI) Reset(arg0 context.Context, arg1 string, arg2 ...ah.RequestOption) (*ah.InstanceAction, error) {
+	args := []interface{}{arg0, arg1}
+	for _, arg := range arg2 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("Reset", args...)
+	return result[*ah.InstanceAction]("Reset", results, 0), errorResult("Reset", results, 1, err)
+}
+
+// Rebuild mocks ah.InstancesAPI.Rebuild
+func (m *InstancesAPI) Rebuild(arg0 context.Context, arg1 string, arg2 *ah.InstanceRebuildRequest, arg3 ...ah.RequestOption) (*ah.InstanceAction, error) {
+	args := []interface{}{arg0, arg1, arg2}
+	for _, arg := range arg3 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("Rebuild", args...)
+	return result[*ah.InstanceAction]("Rebuild", results, 0), errorResult("Rebuild", results, 1, err)
+}
+
+// Destroy mocks ah.InstancesAPI.Destroy
+func (m *InstancesAPI) Destroy(arg0 context.Context, arg1 string, arg2 ...ah.RequestOption) error {
+	args := []interface{}{arg0, arg1}
+	for _, arg := range arg2 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("Destroy", args...)
+	return errorResult("Destroy", results, 0, err)
+}
+
+// DestroyWithOptions mocks ah.InstancesAPI.DestroyWithOptions
+func (m *InstancesAPI) DestroyWithOptions(arg0 context.Context, arg1 string, arg2 *ah.InstanceDestroyOptions, arg3 ...ah.RequestOption) error {
+	args := []interface{}{arg0, arg1, arg2}
+	for _, arg := range arg3 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("DestroyWithOptions", args...)
+	return errorResult("DestroyWithOptions", results, 0, err)
+}
+
+// Teardown mocks ah.InstancesAPI.Teardown
+func (m *InstancesAPI) Teardown(arg0 context.Context, arg1 string, arg2 *ah.InstanceTeardownOptions, arg3 ...ah.RequestOption) error {
+	args := []interface{}{arg0, arg1, arg2}
+	for _, arg := range arg3 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("Teardown", args...)
+	return errorResult("Teardown", results, 0, err)
+}
+
+// SetPrimaryIP mocks ah.InstancesAPI.SetPrimaryIP
+func (m *InstancesAPI) SetPrimaryIP(arg0 context.Context, arg1 string, arg2 string, arg3 ...ah.RequestOption) (*ah.Action, error) {
+	args := []interface{}{arg0, arg1, arg2}
+	for _, arg := range arg3 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("SetPrimaryIP", args...)
+	return result[*ah.Action]("SetPrimaryIP", results, 0), errorResult("SetPrimaryIP", results, 1, err)
+}
+
+// AttachVolume mocks ah.InstancesAPI.AttachVolume
+func (m *InstancesAPI) AttachVolume(arg0 context.Context, arg1 string, arg2 string, arg3 ...ah.RequestOption) (*ah.Action, error) {
+	args := []interface{}{arg0, arg1, arg2}
+	for _, arg := range arg3 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("AttachVolume", args...)
+	return result[*ah.Action]("AttachVolume", results, 0), errorResult("AttachVolume", results, 1, err)
+}
+
+// DetachVolume mocks ah.InstancesAPI.DetachVolume
+func (m *InstancesAPI) DetachVolume(arg0 context.Context, arg1 string, arg2 string, arg3 ...ah.RequestOption) (*ah.Action, error) {
+	args := []interface{}{arg0, arg1, arg2}
+	for _, arg := range arg3 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("DetachVolume", args...)
+	return result[*ah.Action]("DetachVolume", results, 0), errorResult("DetachVolume", results, 1, err)
+}
+
+// ActionInfo mocks ah.InstancesAPI.ActionInfo
+func (m *InstancesAPI) ActionInfo(arg0 context.Context, arg1 string, arg2 string, arg3 ...ah.RequestOption) (*ah.InstanceAction, error) {
+	args := []interface{}{arg0, arg1, arg2}
+	for _, arg := range arg3 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("ActionInfo", args...)
+	return result[*ah.InstanceAction]("ActionInfo", results, 0), errorResult("ActionInfo", results, 1, err)
+}
+
+// Actions mocks ah.InstancesAPI.Actions
+func (m *InstancesAPI) Actions(arg0 context.Context, arg1 string, arg2 ...ah.RequestOption) ([]ah.InstanceAction, error) {
+	args := []interface{}{arg0, arg1}
+	for _, arg := range arg2 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("Actions", args...)
+	return result[[]ah.InstanceAction]("Actions", results, 0), errorResult("Actions", results, 1, err)
+}
+
+// AvailableVolumes mocks ah.InstancesAPI.AvailableVolumes
+func (m *InstancesAPI) AvailableVolumes(arg0 context.Context, arg1 string, arg2 *ah.ListOptions, arg3 ...ah.RequestOption) ([]ah.Volume, *ah.Meta, error) {
+	args := []interface{}{arg0, arg1, arg2}
+	for _, arg := range arg3 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("AvailableVolumes", args...)
+	return result[[]ah.Volume]("AvailableVolumes", results, 0), result[*ah.Meta]("AvailableVolumes", results, 1), errorResult("AvailableVolumes", results, 2, err)
+}
+
+// CreateBackup mocks ah.InstancesAPI.CreateBackup
+func (m *InstancesAPI) CreateBackup(arg0 context.Context, arg1 string, arg2 string, arg3 ...ah.RequestOption) (*ah.InstanceAction, error) {
+	args := []interface{}{arg0, arg1, arg2}
+	for _, arg := range arg3 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("CreateBackup", args...)
+	return result[*ah.InstanceAction]("CreateBackup", results, 0), errorResult("CreateBackup", results, 1, err)
+}
+
+// RestoreFromBackup mocks ah.InstancesAPI.RestoreFromBackup
+func (m *InstancesAPI) RestoreFromBackup(arg0 context.Context, arg1 string, arg2 string, arg3 ...ah.RequestOption) (*ah.InstanceAction, error) {
+	args := []interface{}{arg0, arg1, arg2}
+	for _, arg := range arg3 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("RestoreFromBackup", args...)
+	return result[*ah.InstanceAction]("RestoreFromBackup", results, 0), errorResult("RestoreFromBackup", results, 1, err)
+}
+
+// CreateFromBackup mocks ah.InstancesAPI.CreateFromBackup
+func (m *InstancesAPI) CreateFromBackup(arg0 context.Context, arg1 string, arg2 *ah.InstanceCreateRequest, arg3 ...ah.RequestOption) (*ah.Instance, error) {
+	args := []interface{}{arg0, arg1, arg2}
+	for _, arg := range arg3 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("CreateFromBackup", args...)
+	return result[*ah.Instance]("CreateFromBackup", results, 0), errorResult("CreateFromBackup", results, 1, err)
+}
+
+// KubernetesClustersAPI is a mock of ah.KubernetesClustersAPI
+type KubernetesClustersAPI struct {
+	Mock
+}
+
+var _ ah.KubernetesClustersAPI = &KubernetesClustersAPI{}
+
+// NewKubernetesClustersAPI returns a new KubernetesClustersAPI mock
+func NewKubernetesClustersAPI() *KubernetesClustersAPI {
+	return &KubernetesClustersAPI{}
+}
+
+// Get mocks ah.KubernetesClustersAPI.Get
+func (m *KubernetesClustersAPI) Get(arg0 context.Context, arg1 string, arg2 ...ah.RequestOption) (*ah.KubernetesCluster, error) {
+	args := []interface{}{arg0, arg1}
+	for _, arg := range arg2 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("Get", args...)
+	return result[*ah.KubernetesCluster]("Get", results, 0), errorResult("Get", results, 1, err)
+}
+
+// List mocks ah.KubernetesClustersAPI.List
+func (m *KubernetesClustersAPI) List(arg0 context.Context, arg1 *ah.ListOptions, arg2 ...ah.RequestOption) ([]ah.KubernetesCluster, error) {
+	args := []interface{}{arg0, arg1}
+	for _, arg := range arg2 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("List", args...)
+	return result[[]ah.KubernetesCluster]("List", results, 0), errorResult("List", results, 1, err)
+}
+
+// ListPage mocks ah.KubernetesClustersAPI.ListPage
+func (m *KubernetesClustersAPI) ListPage(arg0 context.Context, arg1 *ah.ListOptions, arg2 ...ah.RequestOption) ([]ah.KubernetesCluster, *ah.Meta, error) {
+	args := []interface{}{arg0, arg1}
+	for _, arg := range arg2 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("ListPage", args...)
+	return result[[]ah.KubernetesCluster]("ListPage", results, 0), result[*ah.Meta]("ListPage", results, 1), errorResult("ListPage", results, 2, err)
+}
+
+// All mocks ah.KubernetesClustersAPI.All
+func (m *KubernetesClustersAPI) All(arg0 context.Context, arg1 *ah.ListOptions, arg2 ...ah.RequestOption) iter.Seq2[ah.KubernetesCluster, error] {
+	args := []interface{}{arg0, arg1}
+	for _, arg := range arg2 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("All", args...)
+	return seqResult[ah.KubernetesCluster]("All", results, 0, err)
+}
+
+// Create mocks ah.KubernetesClustersAPI.Create
+func (m *KubernetesClustersAPI) Create(arg0 context.Context, arg1 *ah.KubernetesClusterCreateRequest, arg2 ...ah.RequestOption) (*ah.KubernetesCluster, error) {
+	args := []interface{}{arg0, arg1}
+	for _, arg := range arg2 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("Create", args...)
+	return result[*ah.KubernetesCluster]("Create", results, 0), errorResult("Create", results, 1, err)
+}
+
+// Update mocks ah.KubernetesClustersAPI.Update
+func (m *KubernetesClustersAPI) Update(arg0 context.Context, arg1 string, arg2 *ah.KubernetesClusterUpdateRequest, arg3 ...ah.RequestOption) error {
+	args := []interface{}{arg0, arg1, arg2}
+	for _, arg := range arg3 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("Update", args...)
+	return errorResult("Update", results, 0, err)
+}
+
+// GetConfig mocks ah.KubernetesClustersAPI.GetConfig
+func (m *KubernetesClustersAPI) GetConfig(arg0 context.Context, arg1 string, arg2 ...ah.RequestOption) (string, error) {
+	args := []interface{}{arg0, arg1}
+	for _, arg := range arg2 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("GetConfig", args...)
+	return result[string]("GetConfig", results, 0), errorResult("GetConfig", results, 1, err)
+}
+
+// Delete mocks ah.KubernetesClustersAPI.Delete
+func (m *KubernetesClustersAPI) Delete(arg0 context.Context, arg1 string, arg2 ...ah.RequestOption) error {
+	args := []interface{}{arg0, arg1}
+	for _, arg := range arg2 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("Delete", args...)
+	return errorResult("Delete", results, 0, err)
+}
+
+// GetKubernetesClustersVersions mocks ah.KubernetesClustersAPI.GetKubernetesClustersVersions
+func (m *KubernetesClustersAPI) GetKubernetesClustersVersions(arg0 context.Context, arg1 ...ah.RequestOption) ([]string, error) {
+	args := []interface{}{arg0}
+	for _, arg := range arg1 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("GetKubernetesClustersVersions", args...)
+	return result[[]string]("GetKubernetesClustersVersions", results, 0), errorResult("GetKubernetesClustersVersions", results, 1, err)
+}
+
+// GetWorkerPool mocks ah.KubernetesClustersAPI.GetWorkerPool
+func (m *KubernetesClustersAPI) GetWorkerPool(arg0 context.Context, arg1 string, arg2 string, arg3 ...ah.RequestOption) (*ah.KubernetesWorkerPool, error) {
+	args := []interface{}{arg0, arg1, arg2}
+	for _, arg := range arg3 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("GetWorkerPool", args...)
+	return result[*ah.KubernetesWorkerPool]("GetWorkerPool", results, 0), errorResult("GetWorkerPool", results, 1, err)
+}
+
+// ListWorkerPools mocks ah.KubernetesClustersAPI.ListWorkerPools
+func (m *KubernetesClustersAPI) ListWorkerPools(arg0 context.Context, arg1 *ah.ListOptions, arg2 string, arg3 ...ah.RequestOption) ([]ah.KubernetesWorkerPool, error) {
+	args := []interface{}{arg0, arg1, arg2}
+	for _, arg := range arg3 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("ListWorkerPools", args...)
+	return result[[]ah.KubernetesWorkerPool]("ListWorkerPools", results, 0), errorResult("ListWorkerPools", results, 1, err)
+}
+
+// ListWorkerPoolsPage mocks ah.KubernetesClustersAPI.ListWorkerPoolsPage
+func (m *KubernetesClustersAPI) ListWorkerPoolsPage(arg0 context.Context, arg1 *ah.ListOptions, arg2 string, arg3 ...ah.RequestOption) ([]ah.KubernetesWorkerPool, *ah.Meta, error) {
+	args := []interface{}{arg0, arg1, arg2}
+	for _, arg := range arg3 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("ListWorkerPoolsPage", args...)
+	return result[[]ah.KubernetesWorkerPool]("ListWorkerPoolsPage", results, 0), result[*ah.Meta]("ListWorkerPoolsPage", results, 1), errorResult("ListWorkerPoolsPage", results, 2, err)
+}
+
+// AllWorkerPools mocks ah.KubernetesClustersAPI.AllWorkerPools
+func (m *KubernetesClustersAPI) AllWorkerPools(arg0 context.Context, arg1 *ah.ListOptions, arg2 string, arg3 ...ah.RequestOption) iter.Seq2[ah.KubernetesWorkerPool, error] {
+	args := []interface{}{arg0, arg1, arg2}
+	for _, arg := range arg3 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("AllWorkerPools", args...)
+	return seqResult[ah.KubernetesWorkerPool]("AllWorkerPools", results, 0, err)
+}
+
+// CreateWorkerPool mocks ah.KubernetesClustersAPI.CreateWorkerPool
+func (m *KubernetesClustersAPI) CreateWorkerPool(arg0 context.Context, arg1 string, arg2 *ah.CreateKubernetesWorkerPoolRequest, arg3 ...ah.RequestOption) (*ah.KubernetesWorkerPool, error) {
+	args := []interface{}{arg0, arg1, arg2}
+	for _, arg := range arg3 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("CreateWorkerPool", args...)
+	return result[*ah.KubernetesWorkerPool]("CreateWorkerPool", results, 0), errorResult("CreateWorkerPool", results, 1, err)
+}
+
+// UpdateWorkerPool mocks ah.KubernetesClustersAPI.UpdateWorkerPool
+func (m *KubernetesClustersAPI) UpdateWorkerPool(arg0 context.Context, arg1 string, arg2 string, arg3 *ah.UpdateKubernetesWorkerPoolRequest, arg4 ...ah.RequestOption) error {
+	args := []interface{}{arg0, arg1, arg2, arg3}
+	for _, arg := range arg4 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("UpdateWorkerPool", args...)
+	return errorResult("UpdateWorkerPool", results, 0, err)
+}
+
+// DeleteWorkerPool mocks ah.KubernetesClustersAPI.DeleteWorkerPool
+func (m *KubernetesClustersAPI) DeleteWorkerPool(arg0 context.Context, arg1 string, arg2 string, arg3 bool, arg4 ...ah.RequestOption) error {
+	args := []interface{}{arg0, arg1, arg2, arg3}
+	for _, arg := range arg4 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("DeleteWorkerPool", args...)
+	return errorResult("DeleteWorkerPool", results, 0, err)
+}
+
+// DeleteWorker mocks ah.KubernetesClustersAPI.DeleteWorker
+func (m *KubernetesClustersAPI) DeleteWorker(arg0 context.Context, arg1 string, arg2 string, arg3 string, arg4 *ah.ClusterDeleteWorkerRequest, arg5 ...ah.RequestOption) error {
+	args := []interface{}{arg0, arg1, arg2, arg3, arg4}
+	for _, arg := range arg5 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("DeleteWorker", args...)
+	return errorResult("DeleteWorker", results, 0, err)
+}
+
+// LoadBalancersAPI is a mock of ah.LoadBalancersAPI
+type LoadBalancersAPI struct {
+	Mock
+}
+
+var _ ah.LoadBalancersAPI = &LoadBalancersAPI{}
+
+// NewLoadBalancersAPI returns a new LoadBalancersAPI mock
+func NewLoadBalancersAPI() *LoadBalancersAPI {
+	return &LoadBalancersAPI{}
+}
+
+// List mocks ah.LoadBalancersAPI.List
+func (m *LoadBalancersAPI) List(arg0 context.Context, arg1 map[string]string, arg2 ...ah.RequestOption) ([]ah.LoadBalancer, error) {
+	args := []interface{}{arg0, arg1}
+	for _, arg := range arg2 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("List", args...)
+	return result[[]ah.LoadBalancer]("List", results, 0), errorResult("List", results, 1, err)
+}
+
+// ListPage mocks ah.LoadBalancersAPI.ListPage
+func (m *LoadBalancersAPI) ListPage(arg0 context.Context, arg1 *ah.ListOptions, arg2 ...ah.RequestOption) ([]ah.LoadBalancer, *ah.Meta, error) {
+	args := []interface{}{arg0, arg1}
+	for _, arg := range arg2 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("ListPage", args...)
+	return result[[]ah.LoadBalancer]("ListPage", results, 0), result[*ah.Meta]("ListPage", results, 1), errorResult("ListPage", results, 2, err)
+}
+
+// All mocks ah.LoadBalancersAPI.All
+func (m *LoadBalancersAPI) All(arg0 context.Context, arg1 *ah.ListOptions, arg2 ...ah.RequestOption) iter.Seq2[ah.LoadBalancer, error] {
+	args := []interface{}{arg0, arg1}
+	for _, arg := range arg2 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("All", args...)
+	return seqResult[ah.LoadBalancer]("All", results, 0, err)
+}
+
+// Get mocks ah.LoadBalancersAPI.Get
+func (m *LoadBalancersAPI) Get(arg0 context.Context, arg1 string, arg2 ...ah.RequestOption) (*ah.LoadBalancer, error) {
+	args := []interface{}{arg0, arg1}
+	for _, arg := range arg2 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("Get", args...)
+	return result[*ah.LoadBalancer]("Get", results, 0), errorResult("Get", results, 1, err)
+}
+
+// Create mocks ah.LoadBalancersAPI.Create
+func (m *LoadBalancersAPI) Create(arg0 context.Context, arg1 *ah.LoadBalancerCreateRequest, arg2 ...ah.RequestOption) (*ah.LoadBalancer, error) {
+	args := []interface{}{arg0, arg1}
+	for _, arg := range arg2 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("Create", args...)
+	return result[*ah.LoadBalancer]("Create", results, 0), errorResult("Create", results, 1, err)
+}
+
+// Update mocks ah.LoadBalancersAPI.Update
+func (m *LoadBalancersAPI) Update(arg0 context.Context, arg1 string, arg2 *ah.LoadBalancerUpdateRequest, arg3 ...ah.RequestOption) error {
+	args := []interface{}{arg0, arg1, arg2}
+	for _, arg := range arg3 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("Update", args...)
+	return errorResult("Update", results, 0, err)
+}
+
+// Delete mocks ah.LoadBalancersAPI.Delete
+func (m *LoadBalancersAPI) Delete(arg0 context.Context, arg1 string, arg2 ...ah.RequestOption) error {
+	args := []interface{}{arg0, arg1}
+	for _, arg := range arg2 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("Delete", args...)
+	return errorResult("Delete", results, 0, err)
+}
+
+// ListForwardingRules mocks ah.LoadBalancersAPI.ListForwardingRules
+func (m *LoadBalancersAPI) ListForwardingRules(arg0 context.Context, arg1 string, arg2 ...ah.RequestOption) ([]ah.LBForwardingRule, error) {
+	args := []interface{}{arg0, arg1}
+	for _, arg := range arg2 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("ListForwardingRules", args...)
+	return result[[]ah.LBForwardingRule]("ListForwardingRules", results, 0), errorResult("ListForwardingRules", results, 1, err)
+}
+
+// GetForwardingRule mocks ah.LoadBalancersAPI.GetForwardingRule
+func (m *LoadBalancersAPI) GetForwardingRule(arg0 context.Context, arg1 string, arg2 string, arg3 ...ah.RequestOption) (*ah.LBForwardingRule, error) {
+	args := []interface{}{arg0, arg1, arg2}
+	for _, arg := range arg3 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("GetForwardingRule", args...)
+	return result[*ah.LBForwardingRule]("GetForwardingRule", results, 0), errorResult("GetForwardingRule", results, 1, err)
+}
+
+// CreateForwardingRule mocks ah.LoadBalancersAPI.CreateForwardingRule
+func (m *LoadBalancersAPI) CreateForwardingRule(arg0 context.Context, arg1 string, arg2 *ah.LBForwardingRuleCreateRequest, arg3 ...ah.RequestOption) (*ah.LBForwardingRule, error) {
+	args := []interface{}{arg0, arg1, arg2}
+	for _, arg := range arg3 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("CreateForwardingRule", args...)
+	return result[*ah.LBForwardingRule]("CreateForwardingRule", results, 0), errorResult("CreateForwardingRule", results, 1, err)
+}
+
+// DeleteForwardingRule mocks ah.LoadBalancersAPI.DeleteForwardingRule
+func (m *LoadBalancersAPI) DeleteForwardingRule(arg0 context.Context, arg1 string, arg2 string, arg3 ...ah.RequestOption) error {
+	args := []interface{}{arg0, arg1, arg2}
+	for _, arg := range arg3 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("DeleteForwardingRule", args...)
+	return errorResult("DeleteForwardingRule", results, 0, err)
+}
+
+// ListPrivateNetworks mocks ah.LoadBalancersAPI.ListPrivateNetworks
+func (m *LoadBalancersAPI) ListPrivateNetworks(arg0 context.Context, arg1 string, arg2 ...ah.RequestOption) ([]ah.LBPrivateNetwork, error) {
+	args := []interface{}{arg0, arg1}
+	for _, arg := range arg2 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("ListPrivateNetworks", args...)
+	return result[[]ah.LBPrivateNetwork]("ListPrivateNetworks", results, 0), errorResult("ListPrivateNetworks", results, 1, err)
+}
+
+// GetPrivateNetwork mocks ah.LoadBalancersAPI.GetPrivateNetwork
+func (m *LoadBalancersAPI) GetPrivateNetwork(arg0 context.Context, arg1 string, arg2 string, arg3 ...ah.RequestOption) (*ah.LBPrivateNetwork, error) {
+	args := []interface{}{arg0, arg1, arg2}
+	for _, arg := range arg3 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("GetPrivateNetwork", args...)
+	return result[*ah.LBPrivateNetwork]("GetPrivateNetwork", results, 0), errorResult("GetPrivateNetwork", results, 1, err)
+}
+
+// ConnectPrivateNetworks mocks ah.LoadBalancersAPI.ConnectPrivateNetworks
+func (m *LoadBalancersAPI) ConnectPrivateNetworks(arg0 context.Context, arg1 string, arg2 []string, arg3 ...ah.RequestOption) ([]ah.LBPrivateNetwork, error) {
+	args := []interface{}{arg0, arg1, arg2}
+	for _, arg := range arg3 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("ConnectPrivateNetworks", args...)
+	return result[[]ah.LBPrivateNetwork]("ConnectPrivateNetworks", results, 0), errorResult("ConnectPrivateNetworks", results, 1, err)
+}
+
+// DisconnectPrivateNetwork mocks ah.LoadBalancersAPI.DisconnectPrivateNetwork
+func (m *LoadBalancersAPI) DisconnectPrivateNetwork(arg0 context.Context, arg1 string, arg2 string, arg3 ...ah.RequestOption) error {
+	args := []interface{}{arg0, arg1, arg2}
+	for _, arg := range arg3 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("DisconnectPrivateNetwork", args...)
+	return errorResult("DisconnectPrivateNetwork", results, 0, err)
+}
+
+// ListBackendNodes mocks ah.LoadBalancersAPI.ListBackendNodes
+func (m *LoadBalancersAPI) ListBackendNodes(arg0 context.Context, arg1 string, arg2 ...ah.RequestOption) ([]ah.LBBackendNode, error) {
+	args := []interface{}{arg0, arg1}
+	for _, arg := range arg2 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("ListBackendNodes", args...)
+	return result[[]ah.LBBackendNode]("ListBackendNodes", results, 0), errorResult("ListBackendNodes", results, 1, err)
+}
+
+// GetBackendNode mocks ah.LoadBalancersAPI.GetBackendNode
+func (m *LoadBalancersAPI) GetBackendNode(arg0 context.Context, arg1 string, arg2 string, arg3 ...ah.RequestOption) (*ah.LBBackendNode, error) {
+	args := []interface{}{arg0, arg1, arg2}
+	for _, arg := range arg3 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("GetBackendNode", args...)
+	return result[*ah.LBBackendNode]("GetBackendNode", results, 0), errorResult("GetBackendNode", results, 1, err)
+}
+
+// AddBackendNodes mocks ah.LoadBalancersAPI.AddBackendNodes
+func (m *LoadBalancersAPI) AddBackendNodes(arg0 context.Context, arg1 string, arg2 []string, arg3 ...ah.RequestOption) ([]ah.LBBackendNode, error) {
+	args := []interface{}{arg0, arg1, arg2}
+	for _, arg := range arg3 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("AddBackendNodes", args...)
+	return result[[]ah.LBBackendNode]("AddBackendNodes", results, 0), errorResult("AddBackendNodes", results, 1, err)
+}
+
+// DeleteBackendNode mocks ah.LoadBalancersAPI.DeleteBackendNode
+func (m *LoadBalancersAPI) DeleteBackendNode(arg0 context.Context, arg1 string, arg2 string, arg3 ...ah.RequestOption) error {
+	args := []interface{}{arg0, arg1, arg2}
+	for _, arg := range arg3 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("DeleteBackendNode", args...)
+	return errorResult("DeleteBackendNode", results, 0, err)
+}
+
+// ListHealthChecks mocks ah.LoadBalancersAPI.ListHealthChecks
+func (m *LoadBalancersAPI) ListHealthChecks(arg0 context.Context, arg1 string, arg2 ...ah.RequestOption) ([]ah.LBHealthCheck, error) {
+	args := []interface{}{arg0, arg1}
+	for _, arg := range arg2 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("ListHealthChecks", args...)
+	return result[[]ah.LBHealthCheck]("ListHealthChecks", results, 0), errorResult("ListHealthChecks", results, 1, err)
+}
+
+// GetHealthCheck mocks ah.LoadBalancersAPI.GetHealthCheck
+func (m *LoadBalancersAPI) GetHealthCheck(arg0 context.Context, arg1 string, arg2 string, arg3 ...ah.RequestOption) (*ah.LBHealthCheck, error) {
+	args := []interface{}{arg0, arg1, arg2}
+	for _, arg := range arg3 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("GetHealthCheck", args...)
+	return result[*ah.LBHealthCheck]("GetHealthCheck", results, 0), errorResult("GetHealthCheck", results, 1, err)
+}
+
+// CreateHealthCheck mocks ah.LoadBalancersAPI.CreateHealthCheck
+func (m *LoadBalancersAPI) CreateHealthCheck(arg0 context.Context, arg1 string, arg2 *ah.LBHealthCheckCreateRequest, arg3 ...ah.RequestOption) (*ah.LBHealthCheck, error) {
+	args := []interface{}{arg0, arg1, arg2}
+	for _, arg := range arg3 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("CreateHealthCheck", args...)
+	return result[*ah.LBHealthCheck]("CreateHealthCheck", results, 0), errorResult("CreateHealthCheck", results, 1, err)
+}
+
+// UpdateHealthCheck mocks ah.LoadBalancersAPI.UpdateHealthCheck
+func (m *LoadBalancersAPI) UpdateHealthCheck(arg0 context.Context, arg1 string, arg2 string, arg3 *ah.LBHealthCheckUpdateRequest, arg4 ...ah.RequestOption) error {
+	args := []interface{}{arg0, arg1, arg2, arg3}
+	for _, arg := range arg4 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("UpdateHealthCheck", args...)
+	return errorResult("UpdateHealthCheck", results, 0, err)
+}
+
+// DeleteHealthCheck mocks ah.LoadBalancersAPI.DeleteHealthCheck
+func (m *LoadBalancersAPI) DeleteHealthCheck(arg0 context.Context, arg1 string, arg2 string, arg3 ...ah.RequestOption) error {
+	args := []interface{}{arg0, arg1, arg2}
+	for _, arg := range arg3 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("DeleteHealthCheck", args...)
+	return errorResult("DeleteHealthCheck", results, 0, err)
+}
+
+// ListIPAddresses mocks ah.LoadBalancersAPI.ListIPAddresses
+func (m *LoadBalancersAPI) ListIPAddresses(arg0 context.Context, arg1 string, arg2 ...ah.RequestOption) ([]ah.LBIPAddress, error) {
+	args := []interface{}{arg0, arg1}
+	for _, arg := range arg2 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("ListIPAddresses", args...)
+	return result[[]ah.LBIPAddress]("ListIPAddresses", results, 0), errorResult("ListIPAddresses", results, 1, err)
+}
+
+// GetIPAddress mocks ah.LoadBalancersAPI.GetIPAddress
+func (m *LoadBalancersAPI) GetIPAddress(arg0 context.Context, arg1 string, arg2 string, arg3 ...ah.RequestOption) (*ah.LBIPAddress, error) {
+	args := []interface{}{arg0, arg1, arg2}
+	for _, arg := range arg3 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("GetIPAddress", args...)
+	return result[*ah.LBIPAddress]("GetIPAddress", results, 0), errorResult("GetIPAddress", results, 1, err)
+}
+
+// AssignIPAddresses mocks ah.LoadBalancersAPI.AssignIPAddresses
+func (m *LoadBalancersAPI) AssignIPAddresses(arg0 context.Context, arg1 string, arg2 []string, arg3 ...ah.RequestOption) ([]ah.LBIPAddress, error) {
+	args := []interface{}{arg0, arg1, arg2}
+	for _, arg := range arg3 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("AssignIPAddresses", args...)
+	return result[[]ah.LBIPAddress]("AssignIPAddresses", results, 0), errorResult("AssignIPAddresses", results, 1, err)
+}
+
+// ReleaseIPAddress mocks ah.LoadBalancersAPI.ReleaseIPAddress
+func (m *LoadBalancersAPI) ReleaseIPAddress(arg0 context.Context, arg1 string, arg2 string, arg3 ...ah.RequestOption) error {
+	args := []interface{}{arg0, arg1, arg2}
+	for _, arg := range arg3 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("ReleaseIPAddress", args...)
+	return errorResult("ReleaseIPAddress", results, 0, err)
+}
+
+// PrivateNetworksAPI is a mock of ah.PrivateNetworksAPI
+type PrivateNetworksAPI struct {
+	Mock
+}
+
+var _ ah.PrivateNetworksAPI = &PrivateNetworksAPI{}
+
+// NewPrivateNetworksAPI returns a new PrivateNetworksAPI mock
+func NewPrivateNetworksAPI() *PrivateNetworksAPI {
+	return &PrivateNetworksAPI{}
+}
+
+// List mocks ah.PrivateNetworksAPI.List
+func (m *PrivateNetworksAPI) List(arg0 context.Context, arg1 *ah.ListOptions, arg2 ...ah.RequestOption) ([]ah.PrivateNetwork, error) {
+	args := []interface{}{arg0, arg1}
+	for _, arg := range arg2 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("List", args...)
+	return result[[]ah.PrivateNetwork]("List", results, 0), errorResult("List", results, 1, err)
+}
+
+// ListPage mocks ah.PrivateNetworksAPI.ListPage
+func (m *PrivateNetworksAPI) ListPage(arg0 context.Context, arg1 *ah.ListOptions, arg2 ...ah.RequestOption) ([]ah.PrivateNetwork, *ah.Meta, error) {
+	args := []interface{}{arg0, arg1}
+	for _, arg := range arg2 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("ListPage", args...)
+	return result[[]ah.PrivateNetwork]("ListPage", results, 0), result[*ah.Meta]("ListPage", results, 1), errorResult("ListPage", results, 2, err)
+}
+
+// All mocks ah.PrivateNetworksAPI.All
+func (m *PrivateNetworksAPI) All(arg0 context.Context, arg1 *ah.ListOptions, arg2 ...ah.RequestOption) iter.Seq2[ah.PrivateNetwork, error] {
+	args := []interface{}{arg0, arg1}
+	for _, arg := range arg2 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("All", args...)
+	return seqResult[ah.PrivateNetwork]("All", results, 0, err)
+}
+
+// Get mocks ah.PrivateNetworksAPI.Get
+func (m *PrivateNetworksAPI) Get(arg0 context.Context, arg1 string, arg2 ...ah.RequestOption) (*ah.PrivateNetworkInfo, error) {
+	args := []interface{}{arg0, arg1}
+	for _, arg := range arg2 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("Get", args...)
+	return result[*ah.PrivateNetworkInfo]("Get", results, 0), errorResult("Get", results, 1, err)
+}
+
+// Create mocks ah.PrivateNetworksAPI.Create
+func (m *PrivateNetworksAPI) Create(arg0 context.Context, arg1 *ah.PrivateNetworkCreateRequest, arg2 ...ah.RequestOption) (*ah.PrivateNetworkInfo, error) {
+	args := []interface{}{arg0, arg1}
+	for _, arg := range arg2 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("Create", args...)
+	return result[*ah.PrivateNetworkInfo]("Create", results, 0), errorResult("Create", results, 1, err)
+}
+
+// Update mocks ah.PrivateNetworksAPI.Update
+func (m *PrivateNetworksAPI) Update(arg0 context.Context, arg1 string, arg2 *ah.PrivateNetworkUpdateRequest, arg3 ...ah.RequestOption) (*ah.PrivateNetworkInfo, error) {
+	args := []interface{}{arg0, arg1, arg2}
+	for _, arg := range arg3 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("Update", args...)
+	return result[*ah.PrivateNetworkInfo]("Update", results, 0), errorResult("Update", results, 1, err)
+}
+
+// Delete mocks ah.PrivateNetworksAPI.Delete
+func (m *PrivateNetworksAPI) Delete(arg0 context.Context, arg1 string, arg2 ...ah.RequestOption) error {
+	args := []interface{}{arg0, arg1}
+	for _, arg := range arg2 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("Delete", args...)
+	return errorResult("Delete", results, 0, err)
+}
+
+// SSHKeysAPI is a mock of ah.SSHKeysAPI
+type SSHKeysAPI struct {
+	Mock
+}
+
+var _ ah.SSHKeysAPI = &SSHKeysAPI{}
+
+// NewSSHKeysAPI returns a new SSHKeysAPI mock
+func NewSSHKeysAPI() *SSHKeysAPI {
+	return &SSHKeysAPI{}
+}
+
+// List mocks ah.SSHKeysAPI.List
+func (m *SSHKeysAPI) List(arg0 context.Context, arg1 *ah.ListOptions, arg2 ...ah.RequestOption) ([]ah.SSHKey, *ah.Meta, error) {
+	args := []interface{}{arg0, arg1}
+	for _, arg := range arg2 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("List", args...)
+	return result[[]ah.SSHKey]("List", results, 0), result[*ah.Meta]("List", results, 1), errorResult("List", results, 2, err)
+}
+
+// All mocks ah.SSHKeysAPI.All
+func (m *SSHKeysAPI) All(arg0 context.Context, arg1 *ah.ListOptions, arg2 ...ah.RequestOption) iter.Seq2[ah.SSHKey, error] {
+	args := []interface{}{arg0, arg1}
+	for _, arg := range arg2 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("All", args...)
+	return seqResult[ah.SSHKey]("All", results, 0, err)
+}
+
+// Get mocks ah.SSHKeysAPI.Get
+func (m *SSHKeysAPI) Get(arg0 context.Context, arg1 string, arg2 ...ah.RequestOption) (*ah.SSHKey, error) {
+	args := []interface{}{arg0, arg1}
+	for _, arg := range arg2 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("Get", args...)
+	return result[*ah.SSHKey]("Get", results, 0), errorResult("Get", results, 1, err)
+}
+
+// Create mocks ah.SSHKeysAPI.Create
+func (m *SSHKeysAPI) Create(arg0 context.Context, arg1 *ah.SSHKeyCreateRequest, arg2 ...ah.RequestOption) (*ah.SSHKey, error) {
+	args := []interface{}{arg0, arg1}
+	for _, arg := range arg2 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("Create", args...)
+	return result[*ah.SSHKey]("Create", results, 0), errorResult("Create", results, 1, err)
+}
+
+// Update mocks ah.SSHKeysAPI.Update
+func (m *SSHKeysAPI) Update(arg0 context.Context, arg1 string, arg2 *ah.SSHKeyUpdateRequest, arg3 ...ah.RequestOption) (*ah.SSHKey, error) {
+	args := []interface{}{arg0, arg1, arg2}
+	for _, arg := range arg3 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("Update", args...)
+	return result[*ah.SSHKey]("Update", results, 0), errorResult("Update", results, 1, err)
+}
+
+// Delete mocks ah.SSHKeysAPI.Delete
+func (m *SSHKeysAPI) Delete(arg0 context.Context, arg1 string, arg2 ...ah.RequestOption) error {
+	args := []interface{}{arg0, arg1}
+	for _, arg := range arg2 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("Delete", args...)
+	return errorResult("Delete", results, 0, err)
+}
+
+// TokensAPI is a mock of ah.TokensAPI
+type TokensAPI struct {
+	Mock
+}
+
+var _ ah.TokensAPI = &TokensAPI{}
+
+// NewTokensAPI returns a new TokensAPI mock
+func NewTokensAPI() *TokensAPI {
+	return &TokensAPI{}
+}
+
+// List mocks ah.TokensAPI.List
+func (m *TokensAPI) List(arg0 context.Context, arg1 *ah.ListOptions, arg2 ...ah.RequestOption) ([]ah.Token, error) {
+	args := []interface{}{arg0, arg1}
+	for _, arg := range arg2 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("List", args...)
+	return result[[]ah.Token]("List", results, 0), errorResult("List", results, 1, err)
+}
+
+// ListPage mocks ah.TokensAPI.ListPage
+func (m *TokensAPI) ListPage(arg0 context.Context, arg1 *ah.ListOptions, arg2 ...ah.RequestOption) ([]ah.Token, *ah.Meta, error) {
+	args := []interface{}{arg0, arg1}
+	for _, arg := range arg2 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("ListPage", args...)
+	return result[[]ah.Token]("ListPage", results, 0), result[*ah.Meta]("ListPage", results, 1), errorResult("ListPage", results, 2, err)
+}
+
+// All mocks ah.TokensAPI.All
+func (m *TokensAPI) All(arg0 context.Context, arg1 *ah.ListOptions, arg2 ...ah.RequestOption) iter.Seq2[ah.Token, error] {
+	args := []interface{}{arg0, arg1}
+	for _, arg := range arg2 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("All", args...)
+	return seqResult[ah.Token]("All", results, 0, err)
+}
+
+// Get mocks ah.TokensAPI.Get
+func (m *TokensAPI) Get(arg0 context.Context, arg1 string, arg2 ...ah.RequestOption) (*ah.Token, error) {
+	args := []interface{}{arg0, arg1}
+	for _, arg := range arg2 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("Get", args...)
+	return result[*ah.Token]("Get", results, 0), errorResult("Get", results, 1, err)
+}
+
+// Create mocks ah.TokensAPI.Create
+func (m *TokensAPI) Create(arg0 context.Context, arg1 *ah.TokenCreateRequest, arg2 ...ah.RequestOption) (*ah.Token, error) {
+	args := []interface{}{arg0, arg1}
+	for _, arg := range arg2 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("Create", args...)
+	return result[*ah.Token]("Create", results, 0), errorResult("Create", results, 1, err)
+}
+
+// Delete mocks ah.TokensAPI.Delete
+func (m *TokensAPI) Delete(arg0 context.Context, arg1 string, arg2 ...ah.RequestOption) error {
+	args := []interface{}{arg0, arg1}
+	for _, arg := range arg2 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("Delete", args...)
+	return errorResult("Delete", results, 0, err)
+}
+
+// VolumePlansAPI is a mock of ah.VolumePlansAPI
+type VolumePlansAPI struct {
+	Mock
+}
+
+var _ ah.VolumePlansAPI = &VolumePlansAPI{}
+
+// NewVolumePlansAPI returns a new VolumePlansAPI mock
+func NewVolumePlansAPI() *VolumePlansAPI {
+	return &VolumePlansAPI{}
+}
+
+// List mocks ah.VolumePlansAPI.List
+func (m *VolumePlansAPI) List(arg0 context.Context, arg1 ...ah.RequestOption) ([]ah.VolumePlan, error) {
+	args := []interface{}{arg0}
+	for _, arg := range arg1 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("List", args...)
+	return result[[]ah.VolumePlan]("List", results, 0), errorResult("List", results, 1, err)
+}
+
+// ListPage mocks ah.VolumePlansAPI.ListPage
+func (m *VolumePlansAPI) ListPage(arg0 context.Context, arg1 *ah.ListOptions, arg2 ...ah.RequestOption) ([]ah.VolumePlan, *ah.Meta, error) {
+	args := []interface{}{arg0, arg1}
+	for _, arg := range arg2 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("ListPage", args...)
+	return result[[]ah.VolumePlan]("ListPage", results, 0), result[*ah.Meta]("ListPage", results, 1), errorResult("ListPage", results, 2, err)
+}
+
+// All mocks ah.VolumePlansAPI.All
+func (m *VolumePlansAPI) All(arg0 context.Context, arg1 *ah.ListOptions, arg2 ...ah.RequestOption) iter.Seq2[ah.VolumePlan, error] {
+	args := []interface{}{arg0, arg1}
+	for _, arg := range arg2 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("All", args...)
+	return seqResult[ah.VolumePlan]("All", results, 0, err)
+}
+
+// VolumeProductsAPI is a mock of ah.VolumeProductsAPI
+type VolumeProductsAPI struct {
+	Mock
+}
+
+var _ ah.VolumeProductsAPI = &VolumeProductsAPI{}
+
+// NewVolumeProductsAPI returns a new VolumeProductsAPI mock
+func NewVolumeProductsAPI() *VolumeProductsAPI {
+	return &VolumeProductsAPI{}
+}
+
+// List mocks ah.VolumeProductsAPI.List
+func (m *VolumeProductsAPI) List(arg0 context.Context, arg1 *ah.ListOptions, arg2 ...ah.RequestOption) ([]ah.VolumeProduct, *ah.Meta, error) {
+	args := []interface{}{arg0, arg1}
+	for _, arg := range arg2 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("List", args...)
+	return result[[]ah.VolumeProduct]("List", results, 0), result[*ah.Meta]("List", results, 1), errorResult("List", results, 2, err)
+}
+
+// VolumesAPI is a mock of ah.VolumesAPI
+type VolumesAPI struct {
+	Mock
+}
+
+var _ ah.VolumesAPI = &VolumesAPI{}
+
+// NewVolumesAPI returns a new VolumesAPI mock
+func NewVolumesAPI() *VolumesAPI {
+	return &VolumesAPI{}
+}
+
+// List mocks ah.VolumesAPI.List
+func (m *VolumesAPI) List(arg0 context.Context, arg1 *ah.ListOptions, arg2 ...ah.RequestOption) ([]ah.Volume, *ah.Meta, error) {
+	args := []interface{}{arg0, arg1}
+	for _, arg := range arg2 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("List", args...)
+	return result[[]ah.Volume]("List", results, 0), result[*ah.Meta]("List", results, 1), errorResult("List", results, 2, err)
+}
+
+// All mocks ah.VolumesAPI.All
+func (m *VolumesAPI) All(arg0 context.Context, arg1 *ah.ListOptions, arg2 ...ah.RequestOption) iter.Seq2[ah.Volume, error] {
+	args := []interface{}{arg0, arg1}
+	for _, arg := range arg2 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("All", args...)
+	return seqResult[ah.Volume]("All", results, 0, err)
+}
+
+// Get mocks ah.VolumesAPI.Get
+func (m *VolumesAPI) Get(arg0 context.Context, arg1 string, arg2 ...ah.RequestOption) (*ah.Volume, error) {
+	args := []interface{}{arg0, arg1}
+	for _, arg := range arg2 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("Get", args...)
+	return result[*ah.Volume]("Get", results, 0), errorResult("Get", results, 1, err)
+}
+
+// Create mocks ah.VolumesAPI.Create
+func (m *VolumesAPI) Create(arg0 context.Context, arg1 *ah.VolumeCreateRequest, arg2 ...ah.RequestOption) (*ah.Volume, error) {
+	args := []interface{}{arg0, arg1}
+	for _, arg := range arg2 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("Create", args...)
+	return result[*ah.Volume]("Create", results, 0), errorResult("Create", results, 1, err)
+}
+
+// Update mocks ah.VolumesAPI.Update
+func (m *VolumesAPI) Update(arg0 context.Context, arg1 string, arg2 *ah.VolumeUpdateRequest, arg3 ...ah.RequestOption) (*ah.Volume, error) {
+	args := []interface{}{arg0, arg1, arg2}
+	for _, arg := range arg3 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("Update", args...)
+	return result[*ah.Volume]("Update", results, 0), errorResult("Update", results, 1, err)
+}
+
+// Copy mocks ah.VolumesAPI.Copy
+func (m *VolumesAPI) Copy(arg0 context.Context, arg1 string, arg2 *ah.VolumeCopyActionRequest, arg3 ...ah.RequestOption) (*ah.VolumeAction, error) {
+	args := []interface{}{arg0, arg1, arg2}
+	for _, arg := range arg3 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("Copy", args...)
+	return result[*ah.VolumeAction]("Copy", results, 0), errorResult("Copy", results, 1, err)
+}
+
+// Resize mocks ah.VolumesAPI.Resize
+func (m *VolumesAPI) Resize(arg0 context.Context, arg1 string, arg2 int, arg3 ...ah.RequestOption) (*ah.Action, error) {
+	args := []interface{}{arg0, arg1, arg2}
+	for _, arg := range arg3 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("Resize", args...)
+	return result[*ah.Action]("Resize", results, 0), errorResult("Resize", results, 1, err)
+}
+
+// ActionInfo mocks ah.VolumesAPI.ActionInfo
+func (m *VolumesAPI) ActionInfo(arg0 context.Context, arg1 string, arg2 string, arg3 ...ah.RequestOption) (*ah.VolumeAction, error) {
+	args := []interface{}{arg0, arg1, arg2}
+	for _, arg := range arg3 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("ActionInfo", args...)
+	return result[*ah.VolumeAction]("ActionInfo", results, 0), errorResult("ActionInfo", results, 1, err)
+}
+
+// Actions mocks ah.VolumesAPI.Actions
+func (m *VolumesAPI) Actions(arg0 context.Context, arg1 string, arg2 ...ah.RequestOption) ([]ah.VolumeAction, error) {
+	args := []interface{}{arg0, arg1}
+	for _, arg := range arg2 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("Actions", args...)
+	return result[[]ah.VolumeAction]("Actions", results, 0), errorResult("Actions", results, 1, err)
+}
+
+// Delete mocks ah.VolumesAPI.Delete
+func (m *VolumesAPI) Delete(arg0 context.Context, arg1 string, arg2 ...ah.RequestOption) error {
+	args := []interface{}{arg0, arg1}
+	for _, arg := range arg2 {
+		args = append(args, arg)
+	}
+	results, err := m.Called("Delete", args...)
+	return errorResult("Delete", results, 0, err)
+}
@@ -0,0 +1,270 @@
+/*
+Copyright 2023 Advanced Hosting
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ahrecord provides an HTTP transport recording AH API interactions
+// into cassette files and replaying them in tests.
+//
+// Access tokens, other secrets and IP addresses are scrubbed before interactions are saved.
+// The recorder replaces ClientOptions.HTTPClient, so the transport used for recording
+// must authenticate requests itself:
+//
+//	transport := &oauth2.Transport{Source: oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token})}
+//	recorder, err := ahrecord.New("testdata/create_instance.json", ahrecord.ModeReplayOrRecord, transport)
+//	if err != nil {
+//		t.Fatal(err)
+//	}
+//	defer recorder.Stop()
+//
+//	client, err := ah.NewAPIClient(&ah.ClientOptions{Token: "token", HTTPClient: recorder.Client()})
+package ahrecord
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+)
+
+// Mode defines whether the recorder sends requests to the API or replays the cassette
+type Mode int
+
+const (
+	// ModeReplay serves responses from the cassette. Unmatched requests fail.
+	ModeReplay Mode = iota
+	// ModeRecord sends requests to the API and saves interactions to the cassette on Stop
+	ModeRecord
+	// ModeReplayOrRecord replays the cassette if it exists, otherwise records it
+	ModeReplayOrRecord
+)
+
+var (
+	// ErrInteractionNotFound is returned in replay mode when no recorded interaction matches the request
+	ErrInteractionNotFound = errors.New("recorded interaction not found")
+	// ErrAddressesExhausted is returned in record mode when the cassette has more IPv4 addresses than
+	// the documentation ranges used instead of them
+	ErrAddressesExhausted = errors.New("documentation IP address ranges exhausted")
+)
+
+// Cassette is a list of recorded interactions
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a recorded request with its response
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request is a recorded request. Headers aren't recorded.
+type Request struct {
+	Query  url.Values      `json:"query,omitempty"`
+	Method string          `json:"method"`
+	Path   string          `json:"path"`
+	Text   string          `json:"text,omitempty"`
+	Body   json.RawMessage `json:"body,omitempty"`
+}
+
+// Response is a recorded response
+type Response struct {
+	Header     http.Header     `json:"header,omitempty"`
+	Text       string          `json:"text,omitempty"`
+	Body       json.RawMessage `json:"body,omitempty"`
+	StatusCode int             `json:"status_code"`
+}
+
+// Recorder is an http.RoundTripper recording or replaying AH API interactions
+type Recorder struct {
+	transport http.RoundTripper
+	cassette  *Cassette
+	scrubber  *scrubber
+	used      []bool
+	path      string
+	mu        sync.Mutex
+	recording bool
+}
+
+// New returns a Recorder using the cassette file at path. The transport sends
+// requests in record mode, http.DefaultTransport is used if it's nil.
+func New(path string, mode Mode, transport http.RoundTripper) (*Recorder, error) {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	r := &Recorder{
+		transport: transport,
+		cassette:  &Cassette{},
+		scrubber:  newScrubber(),
+		path:      path,
+	}
+
+	data, err := os.ReadFile(path)
+	switch {
+	case mode == ModeRecord:
+		r.recording = true
+	case err == nil:
+		if err := json.Unmarshal(data, r.cassette); err != nil {
+			return nil, fmt.Errorf("reading cassette %s: %w", path, err)
+		}
+		r.used = make([]bool, len(r.cassette.Interactions))
+	case errors.Is(err, os.ErrNotExist) && mode == ModeReplayOrRecord:
+		r.recording = true
+	default:
+		return nil, err
+	}
+	return r, nil
+}
+
+// Recording reports whether the recorder sends requests to the API
+func (r *Recorder) Recording() bool {
+	return r.recording
+}
+
+// Client returns an http.Client using the recorder as transport
+func (r *Recorder) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// Stop saves the cassette in record mode
+func (r *Recorder) Stop() error {
+	if !r.recording {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(r.path, append(data, '\n'), 0o644)
+}
+
+// RoundTrip records or replays the request
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	if r.recording {
+		return r.record(req, body)
+	}
+	return r.replay(req, body)
+}
+
+func (r *Recorder) record(req *http.Request, body []byte) (*http.Response, error) {
+	resp, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	data, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	interaction := Interaction{
+		Request: Request{
+			Method: req.Method,
+			Path:   r.scrubber.text(req.URL.Path),
+			Query:  r.scrubber.query(req.URL.Query()),
+		},
+		Response: Response{
+			StatusCode: resp.StatusCode,
+			Header:     scrubHeader(resp.Header),
+		},
+	}
+	interaction.Request.Body, interaction.Request.Text = r.scrubber.body(body)
+	interaction.Response.Body, interaction.Response.Text = r.scrubber.body(data)
+	if r.scrubber.err != nil {
+		return nil, r.scrubber.err
+	}
+	r.cassette.Interactions = append(r.cassette.Interactions, interaction)
+	return resp, nil
+}
+
+// replay returns the response of the first unused interaction matching the request
+func (r *Recorder) replay(req *http.Request, body []byte) (*http.Response, error) {
+	request := normalizeRequest(req.Method, req.URL.Path, req.URL.Query(), body)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, interaction := range r.cassette.Interactions {
+		if r.used[i] {
+			continue
+		}
+		recorded := interaction.Request
+		recordedBody := []byte(recorded.Text)
+		if len(recorded.Body) > 0 {
+			recordedBody = recorded.Body
+		}
+		if !reflect.DeepEqual(request, normalizeRequest(recorded.Method, recorded.Path, recorded.Query, recordedBody)) {
+			continue
+		}
+
+		r.used[i] = true
+		return interaction.Response.httpResponse(req), nil
+	}
+	return nil, fmt.Errorf("%w: %s %s", ErrInteractionNotFound, req.Method, req.URL.RequestURI())
+}
+
+func (resp *Response) httpResponse(req *http.Request) *http.Response {
+	body := []byte(resp.Text)
+	if len(resp.Body) > 0 {
+		body = resp.Body
+	}
+	header := resp.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", resp.StatusCode, http.StatusText(resp.StatusCode)),
+		StatusCode:    resp.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+// readRequestBody reads the request body keeping it available for sending
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	data, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(data))
+	return data, nil
+}
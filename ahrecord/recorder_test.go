@@ -0,0 +1,204 @@
+/*
+Copyright 2023 Advanced Hosting
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ahrecord
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+
+	"github.com/advancedhosting/advancedhosting-api-go/ah"
+	"github.com/advancedhosting/advancedhosting-api-go/ahtest"
+)
+
+// provision creates an instance with public ip address and waits until it's running
+func provision(ctx context.Context, client *ah.APIClient) (*ah.Instance, error) {
+	instance, err := client.Instances.Create(ctx, &ah.InstanceCreateRequest{Name: "web", CreatePublicIPAddress: true})
+	if err != nil {
+		return nil, err
+	}
+	options := &ah.ListOptions{
+		Filters: []ah.FilterInterface{&ah.ContFilter{Keys: []string{"name"}, Value: "we"}},
+	}
+	if _, _, err := client.Instances.List(ctx, options); err != nil {
+		return nil, err
+	}
+	return client.WaitForInstanceState(ctx, instance.ID, ah.InstanceStateRunning, &ah.WaitOptions{PollInterval: time.Millisecond})
+}
+
+func newRecordedClient(t *testing.T, recorder *Recorder, baseURL string) *ah.APIClient {
+	t.Helper()
+	client, err := ah.NewAPIClient(&ah.ClientOptions{
+		Token:      "token",
+		BaseURL:    baseURL,
+		HTTPClient: recorder.Client(),
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	return client
+}
+
+func TestRecorder_RecordAndReplay(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "cassettes", "provision.json")
+
+	server := ahtest.NewServer()
+	transport := &oauth2.Transport{Source: oauth2.StaticTokenSource(&oauth2.Token{AccessToken: ahtest.Token})}
+	recorder, err := New(path, ModeReplayOrRecord, transport)
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if !recorder.Recording() {
+		t.Fatal("Recorder must record missing cassette")
+	}
+
+	recorded, err := provision(ctx, newRecordedClient(t, recorder, server.URL))
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	server.Close()
+	if err := recorder.Stop(); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if strings.Contains(string(data), ahtest.Token) || strings.Contains(string(data), "100.64.") {
+		t.Errorf("Cassette isn't scrubbed: %s", data)
+	}
+
+	recorder, err = New(path, ModeReplayOrRecord, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if recorder.Recording() {
+		t.Fatal("Recorder must replay existing cassette")
+	}
+	replayed, err := provision(ctx, newRecordedClient(t, recorder, server.URL))
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if replayed.ID != recorded.ID || replayed.State != ah.InstanceStateRunning {
+		t.Errorf("Unexpected instance %v", replayed)
+	}
+	primaryIP, _ := replayed.PrimaryIPAddr()
+	if primaryIP == nil || !strings.HasPrefix(primaryIP.Address, "192.0.2.") {
+		t.Errorf("Unexpected primary ip %v", primaryIP)
+	}
+
+	_, err = newRecordedClient(t, recorder, server.URL).Instances.Get(ctx, recorded.ID)
+	if !errors.Is(err, ErrInteractionNotFound) {
+		t.Errorf("Unexpected error %v", err)
+	}
+}
+
+func TestRecorder_ReplayMatching(t *testing.T) {
+	cassette := `{"interactions": [
+		{
+			"request": {"method": "GET", "path": "/api/v1/instances", "query": {"q[name_cont]": ["web"], "q[s][]": ["name asc", "id desc"]}},
+			"response": {"status_code": 200, "body": {"instances": [{"id": "filtered"}], "meta": {"page": 1, "per_page": 25, "total": 1}}}
+		},
+		{
+			"request": {"method": "POST", "path": "/api/v1/ip_addresses", "body": {"ip_address": {"address_type": "public", "reverse_dns": "192.0.2.1"}}},
+			"response": {"status_code": 422, "body": {"errors": {"reverse_dns": ["is invalid"]}}}
+		}
+	]}`
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	if err := os.WriteFile(path, []byte(cassette), 0o644); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	recorder, err := New(path, ModeReplay, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	client := newRecordedClient(t, recorder, "http://api.example.com")
+	ctx := context.Background()
+
+	options := &ah.ListOptions{
+		Filters:  []ah.FilterInterface{&ah.ContFilter{Keys: []string{"name"}, Value: "web"}},
+		Sortings: []*ah.Sorting{{Key: "name", Order: "asc"}, {Key: "id", Order: "desc"}},
+	}
+	instances, _, err := client.Instances.List(ctx, options)
+	if err != nil || len(instances) != 1 || instances[0].ID != "filtered" {
+		t.Errorf("Unexpected result %v, %v", instances, err)
+	}
+
+	_, err = client.IPAddresses.Create(ctx, &ah.IPAddressCreateRequest{Type: "private", ReverseDNS: "10.0.0.1"})
+	if !errors.Is(err, ErrInteractionNotFound) {
+		t.Errorf("Unexpected error %v", err)
+	}
+	_, err = client.IPAddresses.Create(ctx, &ah.IPAddressCreateRequest{Type: "public", ReverseDNS: "10.0.0.1"})
+	var apiErr *ah.APIError
+	if !errors.As(err, &apiErr) || !apiErr.IsValidationError() {
+		t.Errorf("Unexpected error %v", err)
+	}
+}
+
+func TestRecorder_ReplayMissingCassette(t *testing.T) {
+	_, err := New(filepath.Join(t.TempDir(), "missing.json"), ModeReplay, nil)
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Unexpected error %v", err)
+	}
+}
+
+func TestScrubber(t *testing.T) {
+	s := newScrubber()
+	body, _ := s.body([]byte(`{"token": "secret", "address": "203.0.113.200", "cidr": "10.1.0.0/16", "ipv6": "2a01:4f8::1", "ids": ["203.0.113.200"]}`))
+
+	expected := `{"address":"192.0.2.1","cidr":"192.0.2.2/16","ids":["192.0.2.1"],"ipv6":"2001:db8::3","token":"[REDACTED]"}`
+	if string(body) != expected {
+		t.Errorf("Unexpected body %s", body)
+	}
+
+	if _, text := s.body([]byte("connect to 203.0.113.200 failed")); text != "connect to 192.0.2.1 failed" {
+		t.Errorf("Unexpected text %s", text)
+	}
+
+	for _, text := range []string{"ActiveRecord::RecordNotFound", "Foo::Bar", "at 12:30:45"} {
+		if scrubbed := s.text(text); scrubbed != text {
+			t.Errorf("Unexpected scrubbed text %s of %s", scrubbed, text)
+		}
+	}
+
+	if text := s.text("route via 2a01:4f8::1, fe80::1:2 failed"); text != "route via 2001:db8::3, 2001:db8::4 failed" {
+		t.Errorf("Unexpected text %s", text)
+	}
+}
+
+func TestScrubber_AddressesExhausted(t *testing.T) {
+	s := newScrubber()
+	for i := 0; i < len(ipv4Ranges)*254; i++ {
+		s.text(fmt.Sprintf("10.0.%d.%d", i/256, i%256))
+	}
+	if s.err != nil {
+		t.Fatalf("Unexpected error %v", s.err)
+	}
+
+	if text := s.text("10.1.0.0"); text != ipPlaceholder || !errors.Is(s.err, ErrAddressesExhausted) {
+		t.Errorf("Unexpected text %s, error %v", text, s.err)
+	}
+}
@@ -0,0 +1,217 @@
+/*
+Copyright 2023 Advanced Hosting
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ahrecord
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/advancedhosting/advancedhosting-api-go/internal/redact"
+)
+
+const ipPlaceholder = "<ip>"
+
+// sensitiveHeaders are response headers which aren't recorded
+var sensitiveHeaders = []string{"Authorization", "Set-Cookie", "Cookie"}
+
+var (
+	ipv4Pattern = regexp.MustCompile(`\b(?:\d{1,3}\.){3}\d{1,3}\b`)
+	ipv6Pattern = regexp.MustCompile(`[0-9A-Fa-f]{0,4}(?::[0-9A-Fa-f]{0,4}){2,7}`)
+	// ipv4Ranges are documentation ranges used instead of real addresses
+	ipv4Ranges = []string{"192.0.2", "198.51.100", "203.0.113"}
+)
+
+// scrubber replaces secrets and IP addresses. Every IP address is consistently
+// replaced with the same address from documentation ranges.
+type scrubber struct {
+	ips map[string]string
+	// ipv4Count is the number of IPv4 addresses mapped to documentation ranges
+	ipv4Count int
+	// err is set when documentation ranges are exhausted
+	err error
+}
+
+func newScrubber() *scrubber {
+	return &scrubber{ips: map[string]string{}}
+}
+
+func (s *scrubber) ip(address string) string {
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return address
+	}
+	if replacement, ok := s.ips[ip.String()]; ok {
+		return replacement
+	}
+
+	n := len(s.ips)
+	replacement := fmt.Sprintf("2001:db8::%x", n+1)
+	if ip.To4() != nil {
+		if s.ipv4Count >= len(ipv4Ranges)*254 {
+			s.err = fmt.Errorf("%w: more than %d IPv4 addresses", ErrAddressesExhausted, len(ipv4Ranges)*254)
+			return ipPlaceholder
+		}
+		replacement = fmt.Sprintf("%s.%d", ipv4Ranges[s.ipv4Count/254], s.ipv4Count%254+1)
+		s.ipv4Count++
+	}
+	s.ips[ip.String()] = replacement
+	return replacement
+}
+
+func (s *scrubber) text(text string) string {
+	return replaceIPs(text, s.ip)
+}
+
+func (s *scrubber) query(query url.Values) url.Values {
+	if len(query) == 0 {
+		return nil
+	}
+	return scrubQuery(query, s.text)
+}
+
+// body returns the scrubbed body as JSON, or as text if it isn't JSON
+func (s *scrubber) body(data []byte) (json.RawMessage, string) {
+	if len(data) == 0 {
+		return nil, ""
+	}
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, s.text(string(data))
+	}
+	scrubbed, _ := json.Marshal(scrubJSON(v, s.text))
+	return scrubbed, ""
+}
+
+// replaceIPs replaces IPv4 and IPv6 addresses in text
+func replaceIPs(text string, replace func(string) string) string {
+	text = ipv4Pattern.ReplaceAllStringFunc(text, replace)
+
+	var result strings.Builder
+	last := 0
+	for _, loc := range ipv6Pattern.FindAllStringIndex(text, -1) {
+		match := text[loc[0]:loc[1]]
+		if !isIPv6(text, loc[0], loc[1]) {
+			continue
+		}
+		result.WriteString(text[last:loc[0]])
+		result.WriteString(replace(match))
+		last = loc[1]
+	}
+	result.WriteString(text[last:])
+	return result.String()
+}
+
+// isIPv6 reports whether text[start:end] is a standalone IPv6 address with at least two hex groups.
+// It rules out text like "ActiveRecord::RecordNotFound", where "d::" is a valid address.
+func isIPv6(text string, start, end int) bool {
+	if start > 0 && isAddressChar(text[start-1]) || end < len(text) && isAddressChar(text[end]) {
+		return false
+	}
+	match := text[start:end]
+	groups := 0
+	for _, group := range strings.Split(match, ":") {
+		if group != "" {
+			groups++
+		}
+	}
+	return groups >= 2 && net.ParseIP(match) != nil
+}
+
+// isAddressChar reports whether c may adjoin an IPv6 address within a word
+func isAddressChar(c byte) bool {
+	return c == ':' || c == '_' || '0' <= c && c <= '9' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
+
+func scrubQuery(query url.Values, text func(string) string) url.Values {
+	result := url.Values{}
+	for _, key := range slices.Sorted(maps.Keys(query)) {
+		for _, value := range query[key] {
+			if redact.IsSensitiveKey(key) {
+				value = redact.Value
+			}
+			result.Add(key, text(value))
+		}
+	}
+	return result
+}
+
+// scrubJSON visits object keys in sorted order, so addresses are mapped the same way on every run
+func scrubJSON(v interface{}, text func(string) string) interface{} {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		for _, key := range slices.Sorted(maps.Keys(value)) {
+			item := value[key]
+			if redact.IsSensitiveKey(key) && item != nil {
+				value[key] = redact.Value
+				continue
+			}
+			value[key] = scrubJSON(item, text)
+		}
+	case []interface{}:
+		for i, item := range value {
+			value[i] = scrubJSON(item, text)
+		}
+	case string:
+		return text(value)
+	}
+	return v
+}
+
+func scrubHeader(header http.Header) http.Header {
+	result := header.Clone()
+	for _, key := range sensitiveHeaders {
+		result.Del(key)
+	}
+	return result
+}
+
+// normalizedRequest is compared to find a recorded interaction matching the request
+type normalizedRequest struct {
+	Body   interface{}
+	Query  url.Values
+	Method string
+	Path   string
+}
+
+// normalizeRequest scrubs the request replacing all IP addresses with a placeholder,
+// so requests match regardless of the addresses used during recording
+func normalizeRequest(method, path string, query url.Values, body []byte) normalizedRequest {
+	placeholder := func(text string) string {
+		return replaceIPs(text, func(string) string { return ipPlaceholder })
+	}
+
+	request := normalizedRequest{Method: method, Path: placeholder(path)}
+	if len(query) > 0 {
+		request.Query = scrubQuery(query, placeholder)
+	}
+	if len(body) > 0 {
+		var v interface{}
+		if err := json.Unmarshal(body, &v); err != nil {
+			request.Body = placeholder(string(body))
+		} else {
+			request.Body = scrubJSON(v, placeholder)
+		}
+	}
+	return request
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2023 Advanced Hosting
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ahtest
+
+import (
+	"github.com/advancedhosting/advancedhosting-api-go/ah"
+)
+
+// action is a simulated asynchronous operation
+type action struct {
+	ah.Action
+	ResultParams map[string]string `json:"result_params,omitempty"`
+	onSuccess    func()
+	onFailure    func()
+	fail         *string
+	ticks        int
+}
+
+// newAction registers an action completing after ActionSteps requests
+func (s *Server) newAction(resourceType, resourceID string, actionType ah.ActionType, onSuccess func()) *action {
+	a := &action{
+		Action: ah.Action{
+			ID:           s.newID(),
+			State:        ah.ActionStatePending,
+			ResourceID:   resourceID,
+			ResourceType: resourceType,
+			Type:         actionType,
+			CreatedAt:    now(),
+			UpdatedAt:    now(),
+		},
+		onSuccess: onSuccess,
+		fail:      s.fail,
+	}
+	s.fail = nil
+	s.actions = append(s.actions, a)
+	return a
+}
+
+func (a *action) finished() bool {
+	return a.State == ah.ActionStateSuccess || a.State == ah.ActionStateFailed
+}
+
+// step moves the action to the next state
+func (a *action) step(s *Server) {
+	a.UpdatedAt = now()
+	switch a.State {
+	case ah.ActionStatePending:
+		a.State = ah.ActionStateRunning
+		a.StartedAt = now()
+	case ah.ActionStateRunning:
+		a.CompletedAt = now()
+		if a.fail != nil {
+			a.State = ah.ActionStateFailed
+			a.Note = *a.fail
+			if a.onFailure != nil {
+				a.onFailure()
+			}
+			return
+		}
+		a.State = ah.ActionStateSuccess
+		if a.onSuccess != nil {
+			a.onSuccess()
+		}
+	}
+}
+
+// tick advances pending actions. It's called on every API request.
+func (s *Server) tick() {
+	steps := s.ActionSteps
+	if steps < 1 {
+		steps = 1
+	}
+	for _, a := range s.actions {
+		if a.finished() {
+			continue
+		}
+		a.ticks++
+		if a.State == ah.ActionStatePending {
+			a.step(s)
+		}
+		if a.ticks >= steps {
+			a.step(s)
+		}
+	}
+}
+
+func (s *Server) findAction(resourceType, resourceID, actionID string) *action {
+	for _, a := range s.actions {
+		if a.ID == actionID && a.ResourceType == resourceType && a.ResourceID == resourceID {
+			return a
+		}
+	}
+	return nil
+}
+
+func (s *Server) resourceActions(resourceType, resourceID string) []*action {
+	var result []*action
+	for _, a := range s.actions {
+		if a.ResourceType == resourceType && a.ResourceID == resourceID {
+			result = append(result, a)
+		}
+	}
+	return result
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2023 Advanced Hosting
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ahtest
+
+import (
+	"net/http"
+
+	"github.com/advancedhosting/advancedhosting-api-go/ah"
+)
+
+const backupStatusActive = "active"
+
+func (s *Server) registerBackups() {
+	s.handle("GET /api/v1/backups", s.listBackups)
+	s.handle("GET /api/v1/backups/{id}", s.getBackup)
+	s.handle("PUT /api/v1/backups/{id}", s.updateBackup)
+	s.handle("DELETE /api/v1/backups/{id}", s.deleteBackup)
+}
+
+func (s *Server) findBackup(rw http.ResponseWriter, req *http.Request) (*ah.Backup, bool) {
+	backup, ok := s.backups[req.PathValue("id")]
+	if !ok {
+		writeNotFound(rw, "backup")
+	}
+	return backup, ok
+}
+
+func (s *Server) listBackups(rw http.ResponseWriter, req *http.Request) {
+	backups, meta := query(req, s.backups)
+	result := []ah.BackupWithEmbeddedInstance{}
+	for _, backup := range backups {
+		result = append(result, ah.BackupWithEmbeddedInstance{
+			Backup: backup,
+			Instance: ah.InstanceInfo{
+				Name:               backup.InstanceName,
+				SnapshotBySchedule: backup.InstanceSnapshotBySchedule,
+				InstanceRemoved:    backup.InstanceRemoved,
+			},
+		})
+	}
+	writeJSON(rw, http.StatusOK, map[string]interface{}{"backups": result, "meta": meta})
+}
+
+func (s *Server) getBackup(rw http.ResponseWriter, req *http.Request) {
+	if backup, ok := s.findBackup(rw, req); ok {
+		writeJSON(rw, http.StatusOK, map[string]interface{}{"backup": backup})
+	}
+}
+
+func (s *Server) updateBackup(rw http.ResponseWriter, req *http.Request) {
+	backup, ok := s.findBackup(rw, req)
+	if !ok {
+		return
+	}
+	var request ah.BackUpUpdateRequest
+	if !readJSON(rw, req, &request) {
+		return
+	}
+	if request.Name != "" {
+		backup.Name = request.Name
+	}
+	if request.Note != "" {
+		backup.Note = request.Note
+	}
+	backup.UpdatedAt = now()
+	writeJSON(rw, http.StatusOK, map[string]interface{}{"backup": backup})
+}
+
+// deleteBackup removes the backup with an instance action, so it can be awaited with WaitForAction
+func (s *Server) deleteBackup(rw http.ResponseWriter, req *http.Request) {
+	backup, ok := s.findBackup(rw, req)
+	if !ok {
+		return
+	}
+	a := s.newAction("instance", backup.InstanceID, "destroy_backup", func() {
+		delete(s.backups, backup.ID)
+	})
+	writeJSON(rw, http.StatusAccepted, map[string]interface{}{"action": a})
+}
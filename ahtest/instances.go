@@ -0,0 +1,434 @@
+/*
+Copyright 2023 Advanced Hosting
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ahtest
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/advancedhosting/advancedhosting-api-go/ah"
+)
+
+const (
+	defaultInstanceDisk = 20
+	defaultInstanceVcpu = 1
+	defaultInstanceRAM  = 1024
+)
+
+type instanceActionRequest struct {
+	Type                ah.ActionType `json:"type"`
+	BackupID            string        `json:"backup_id"`
+	ImageID             string        `json:"image_id"`
+	ImageSlug           string        `json:"image_slug"`
+	VolumeID            string        `json:"volume_id"`
+	InstanceIPAddressID string        `json:"instance_ip_address_id"`
+	PlanID              int           `json:"plan_id"`
+}
+
+func (s *Server) registerInstances() {
+	s.handle("GET /api/v1/instances", s.listInstances)
+	s.handle("POST /api/v1/instances", s.createInstance)
+	s.handle("GET /api/v1/instances/{id}", s.getInstance)
+	s.handle("PATCH /api/v1/instances/{id}", s.updateInstance)
+	s.handle("DELETE /api/v1/instances/{id}", s.destroyInstance)
+	s.handle("GET /api/v1/instances/{id}/actions", s.listInstanceActions)
+	s.handle("POST /api/v1/instances/{id}/actions", s.createInstanceAction)
+	s.handle("GET /api/v1/instances/{id}/actions/{actionID}", s.getInstanceAction)
+	s.handle("GET /api/v1/instances/{id}/available_volumes", s.listAvailableVolumes)
+	s.handle("POST /api/v1/instances/{id}/backups", s.createInstanceBackup)
+}
+
+// instance returns the instance with volumes, ip addresses and private networks
+func (s *Server) instance(i *ah.Instance) ah.Instance {
+	result := *i
+	result.Volumes = nil
+	for _, volume := range values(s.volumes) {
+		if volume.Instance != nil && volume.Instance.ID == i.ID {
+			result.Volumes = append(result.Volumes, volume)
+		}
+	}
+	result.IPAddresses = nil
+	for _, assignment := range values(s.ipAssignments) {
+		if assignment.InstanceID == i.ID {
+			result.IPAddresses = append(result.IPAddresses, ah.InstanceIPAddress{
+				ID:          assignment.ID,
+				InstanceID:  assignment.InstanceID,
+				IPAddressID: assignment.IPAddressID,
+				Address:     s.ipAddresses[assignment.IPAddressID].Address,
+				CreatedAt:   assignment.CreatedAt,
+				UpdatedAt:   assignment.UpdatedAt,
+			})
+		}
+	}
+	result.PrivateNetworks = nil
+	for _, ipn := range values(s.instancePrivateNetworks) {
+		if ipn.Instance != nil && ipn.Instance.ID == i.ID {
+			result.PrivateNetworks = append(result.PrivateNetworks, ipn)
+		}
+	}
+	return result
+}
+
+func (s *Server) findInstance(rw http.ResponseWriter, req *http.Request) (*ah.Instance, bool) {
+	instance, ok := s.instances[req.PathValue("id")]
+	if !ok {
+		writeNotFound(rw, "instance")
+	}
+	return instance, ok
+}
+
+func (s *Server) listInstances(rw http.ResponseWriter, req *http.Request) {
+	instances, meta := query(req, s.instances)
+	for i := range instances {
+		instances[i] = s.instance(&instances[i])
+	}
+	writeJSON(rw, http.StatusOK, map[string]interface{}{"instances": instances, "meta": meta})
+}
+
+func (s *Server) getInstance(rw http.ResponseWriter, req *http.Request) {
+	if instance, ok := s.findInstance(rw, req); ok {
+		writeJSON(rw, http.StatusOK, map[string]interface{}{"instance": s.instance(instance)})
+	}
+}
+
+func (s *Server) createInstance(rw http.ResponseWriter, req *http.Request) {
+	var request struct {
+		Instance *ah.InstanceCreateRequest `json:"instance"`
+	}
+	if !readJSON(rw, req, &request) {
+		return
+	}
+	createRequest := request.Instance
+	if createRequest == nil || createRequest.Name == "" {
+		writeValidationError(rw, "name", "can't be blank")
+		return
+	}
+
+	instance := &ah.Instance{
+		ID:                 s.newID(),
+		Number:             strconv.Itoa(s.nextNumber()),
+		Name:               createRequest.Name,
+		State:              ah.InstanceStateCreating,
+		Tags:               createRequest.Tags,
+		SnapshotPeriod:     createRequest.SnapshotPeriod,
+		SnapshotBySchedule: createRequest.SnapshotBySchedule,
+		UseSSHPassword:     createRequest.UseSSHPassword,
+		PlanID:             createRequest.PlanID,
+		Disk:               createRequest.Disk,
+		Vcpu:               createRequest.Vcpu,
+		RAM:                createRequest.Ram,
+		CreatedAt:          now(),
+		UpdatedAt:          now(),
+	}
+	if plan, ok := s.findPlan(createRequest.PlanID, createRequest.PlanSlug); ok {
+		instance.PlanID = plan.ID
+		if instance.Disk == 0 {
+			instance.Disk = plan.CustomAttributes.Disk
+		}
+	} else if createRequest.PlanID != 0 || createRequest.PlanSlug != "" {
+		writeValidationError(rw, "plan_id", "plan not found")
+		return
+	}
+	if instance.Disk == 0 {
+		instance.Disk = defaultInstanceDisk
+	}
+	if backup, ok := s.backups[createRequest.ImageID]; ok && backup.MinDiskSize > instance.Disk*bytesPerGB {
+		writeValidationError(rw, "image_id", "backup doesn't fit the disk")
+		return
+	}
+	if instance.Vcpu == 0 {
+		instance.Vcpu = defaultInstanceVcpu
+	}
+	if instance.RAM == 0 {
+		instance.RAM = defaultInstanceRAM
+	}
+	for _, sshKeyID := range createRequest.SSHKeyIDs {
+		sshKey, ok := s.sshKeys[sshKeyID]
+		if !ok {
+			writeValidationError(rw, "ssh_key_ids", fmt.Sprintf("ssh key %s not found", sshKeyID))
+			return
+		}
+		instance.SSHKeys = append(instance.SSHKeys, ah.InstanceSSHKey{
+			ID:          sshKey.ID,
+			Name:        sshKey.Name,
+			PublicKey:   sshKey.PublicKey,
+			Fingerprint: sshKey.Fingerprint,
+			CreatedAt:   sshKey.CreatedAt,
+		})
+	}
+	s.instances[instance.ID] = instance
+
+	if createRequest.CreatePublicIPAddress {
+		ip := s.newIPAddress("public", instance.Name)
+		assignment := s.assignIPAddress(ip, instance)
+		instance.PrimaryInstanceIPAddressID = assignment.ID
+	}
+
+	s.newAction("instance", instance.ID, "create", func() {
+		instance.State = ah.InstanceStateRunning
+		instance.UpdatedAt = now()
+	}).onFailure = func() {
+		instance.State = ah.InstanceStateError
+		instance.StateDescription = "creation failed"
+	}
+
+	writeJSON(rw, http.StatusAccepted, map[string]interface{}{"instance": s.instance(instance)})
+}
+
+func (s *Server) updateInstance(rw http.ResponseWriter, req *http.Request) {
+	instance, ok := s.findInstance(rw, req)
+	if !ok {
+		return
+	}
+	var request ah.InstanceUpdateRequest
+	if !readJSON(rw, req, &request) {
+		return
+	}
+	if request.Name != nil && *request.Name == "" {
+		writeValidationError(rw, "name", "can't be blank")
+		return
+	}
+	if request.Name != nil {
+		instance.Name = *request.Name
+	}
+	if request.Tags != nil {
+		instance.Tags = *request.Tags
+	}
+	if request.SnapshotBySchedule != nil {
+		instance.SnapshotBySchedule = *request.SnapshotBySchedule
+	}
+	if request.SnapshotPeriod != nil {
+		instance.SnapshotPeriod = *request.SnapshotPeriod
+	}
+	instance.UpdatedAt = now()
+	writeJSON(rw, http.StatusOK, map[string]interface{}{"instance": s.instance(instance)})
+}
+
+func (s *Server) destroyInstance(rw http.ResponseWriter, req *http.Request) {
+	instance, ok := s.findInstance(rw, req)
+	if !ok {
+		return
+	}
+	if instance.Locked {
+		writeError(rw, http.StatusUnprocessableEntity, "instance is locked")
+		return
+	}
+	var request ah.InstanceDestroyRequest
+	if !readJSON(rw, req, &request) {
+		return
+	}
+
+	delete(s.instances, instance.ID)
+	for _, volume := range s.volumes {
+		if volume.Instance != nil && volume.Instance.ID == instance.ID {
+			volume.Instance = nil
+			volume.State = volumeStateReady
+			volume.AttachedAt = ah.Timestamp{}
+		}
+	}
+	for _, assignment := range s.ipAssignments {
+		if assignment.InstanceID == instance.ID {
+			s.unassignIPAddress(assignment)
+		}
+	}
+	for id, ipn := range s.instancePrivateNetworks {
+		if ipn.Instance != nil && ipn.Instance.ID == instance.ID {
+			delete(s.instancePrivateNetworks, id)
+		}
+	}
+	for id, backup := range s.backups {
+		if backup.InstanceID != instance.ID {
+			continue
+		}
+		if request.BackupsStrategy == ah.BackupsStrategyDestroy {
+			delete(s.backups, id)
+			continue
+		}
+		backup.InstanceRemoved = true
+	}
+	rw.WriteHeader(http.StatusAccepted)
+}
+
+func (s *Server) listInstanceActions(rw http.ResponseWriter, req *http.Request) {
+	if _, ok := s.findInstance(rw, req); !ok {
+		return
+	}
+	actions := []*action{}
+	actions = append(actions, s.resourceActions("instance", req.PathValue("id"))...)
+	writeJSON(rw, http.StatusOK, map[string]interface{}{"actions": actions})
+}
+
+func (s *Server) getInstanceAction(rw http.ResponseWriter, req *http.Request) {
+	a := s.findAction("instance", req.PathValue("id"), req.PathValue("actionID"))
+	if a == nil {
+		writeNotFound(rw, "action")
+		return
+	}
+	writeJSON(rw, http.StatusOK, map[string]interface{}{"action": a})
+}
+
+func (s *Server) createInstanceAction(rw http.ResponseWriter, req *http.Request) {
+	instance, ok := s.findInstance(rw, req)
+	if !ok {
+		return
+	}
+	var request instanceActionRequest
+	if !readJSON(rw, req, &request) {
+		return
+	}
+
+	var onSuccess, onFailure func()
+	switch request.Type {
+	case ah.ActionTypeShutdown, ah.ActionTypePowerOff:
+		onSuccess = func() {
+			instance.State = ah.InstanceStateStopped
+		}
+	case ah.ActionTypePowerOn, ah.ActionTypeReboot, ah.ActionTypeHardReboot, ah.ActionTypeReset:
+		onSuccess = func() {
+			instance.State = ah.InstanceStateRunning
+		}
+	case ah.ActionTypeRebuild:
+		if request.ImageID == "" && request.ImageSlug == "" {
+			writeValidationError(rw, "image_id", "can't be blank")
+			return
+		}
+		onSuccess = func() {
+			instance.State = ah.InstanceStateRunning
+		}
+	case ah.ActionTypeRestore:
+		backup, ok := s.backups[request.BackupID]
+		if !ok {
+			writeValidationError(rw, "backup_id", "backup not found")
+			return
+		}
+		if backup.MinDiskSize > instance.Disk*bytesPerGB {
+			writeValidationError(rw, "backup_id", "backup doesn't fit the disk")
+			return
+		}
+		onSuccess = func() {
+			instance.State = ah.InstanceStateRunning
+		}
+	case ah.ActionTypeUpgrade:
+		onSuccess = func() {
+			if request.PlanID != 0 {
+				instance.PlanID = request.PlanID
+			}
+		}
+	case ah.ActionTypeSetPrimaryIP:
+		assignment, ok := s.ipAssignments[request.InstanceIPAddressID]
+		if !ok || assignment.InstanceID != instance.ID {
+			writeValidationError(rw, "instance_ip_address_id", "is not assigned to the instance")
+			return
+		}
+		onSuccess = func() {
+			instance.PrimaryInstanceIPAddressID = assignment.ID
+		}
+	case ah.ActionTypeAttachVolume:
+		volume, ok := s.volumes[request.VolumeID]
+		if !ok {
+			writeValidationError(rw, "volume_id", "volume not found")
+			return
+		}
+		if volume.Instance != nil {
+			writeValidationError(rw, "volume_id", "volume is already attached")
+			return
+		}
+		volume.State = volumeStateAttaching
+		onFailure = func() {
+			volume.State = volumeStateReady
+		}
+		onSuccess = func() {
+			volume.Instance = &struct {
+				ID   string `json:"id,omitempty"`
+				Name string `json:"name,omitempty"`
+			}{ID: instance.ID, Name: instance.Name}
+			volume.State = volumeStateAttached
+			volume.AttachedAt = now()
+		}
+	case ah.ActionTypeDetachVolume:
+		volume, ok := s.volumes[request.VolumeID]
+		if !ok || volume.Instance == nil || volume.Instance.ID != instance.ID {
+			writeValidationError(rw, "volume_id", "volume is not attached to the instance")
+			return
+		}
+		volume.State = volumeStateDetaching
+		onFailure = func() {
+			volume.State = volumeStateAttached
+		}
+		onSuccess = func() {
+			volume.Instance = nil
+			volume.State = volumeStateReady
+			volume.AttachedAt = ah.Timestamp{}
+		}
+	default:
+		writeValidationError(rw, "type", fmt.Sprintf("unsupported action type %q", request.Type))
+		return
+	}
+
+	a := s.newAction("instance", instance.ID, request.Type, onSuccess)
+	a.onFailure = onFailure
+	instance.CurrentAction = &ah.InstanceAction{Action: &a.Action}
+	writeJSON(rw, http.StatusAccepted, map[string]interface{}{"action": a})
+}
+
+func (s *Server) listAvailableVolumes(rw http.ResponseWriter, req *http.Request) {
+	if _, ok := s.findInstance(rw, req); !ok {
+		return
+	}
+	available := map[string]*ah.Volume{}
+	for id, volume := range s.volumes {
+		if volume.Instance == nil {
+			available[id] = volume
+		}
+	}
+	volumes, meta := query(req, available)
+	writeJSON(rw, http.StatusOK, map[string]interface{}{"volumes": volumes, "meta": meta})
+}
+
+func (s *Server) createInstanceBackup(rw http.ResponseWriter, req *http.Request) {
+	instance, ok := s.findInstance(rw, req)
+	if !ok {
+		return
+	}
+	var request struct {
+		Note string `json:"note"`
+	}
+	if !readJSON(rw, req, &request) {
+		return
+	}
+
+	backupID := s.newID()
+	a := s.newAction("instance", instance.ID, ah.ActionTypeBackup, func() {
+		s.backups[backupID] = &ah.Backup{
+			ID:                         backupID,
+			Name:                       fmt.Sprintf("%s backup", instance.Name),
+			Status:                     backupStatusActive,
+			Type:                       "manual",
+			Note:                       request.Note,
+			InstanceID:                 instance.ID,
+			InstanceName:               instance.Name,
+			InstanceSnapshotBySchedule: instance.SnapshotBySchedule,
+			Size:                       instance.Disk * bytesPerGB / 10,
+			MinDiskSize:                instance.Disk * bytesPerGB,
+			CreatedAt:                  now(),
+			UpdatedAt:                  now(),
+		}
+	})
+	a.ResultParams = map[string]string{"snapshot_id": backupID}
+	writeJSON(rw, http.StatusAccepted, map[string]interface{}{"action": a})
+}
@@ -0,0 +1,317 @@
+/*
+Copyright 2023 Advanced Hosting
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ahtest
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/advancedhosting/advancedhosting-api-go/ah"
+)
+
+const (
+	clusterStateCreating = "creating"
+	clusterStateError    = "error"
+	workerStateActive    = "active"
+)
+
+// KubernetesVersions are the kubernetes versions supported by the server
+var KubernetesVersions = []string{"1.29.10", "1.30.6", "1.31.2"}
+
+func (s *Server) registerKubernetesClusters() {
+	s.handle("GET /api/v2/kubernetes/clusters", s.listClusters)
+	s.handle("POST /api/v2/kubernetes/clusters", s.createCluster)
+	s.handle("GET /api/v2/kubernetes/clusters/versions", s.listKubernetesVersions)
+	s.handle("GET /api/v2/kubernetes/clusters/{id}", s.getCluster)
+	s.handle("PATCH /api/v2/kubernetes/clusters/{id}", s.updateCluster)
+	s.handle("DELETE /api/v2/kubernetes/clusters/{id}", s.deleteCluster)
+	s.handle("GET /api/v2/kubernetes/clusters/{id}/kubeconfig", s.getKubeconfig)
+	s.handle("GET /api/v2/kubernetes/clusters/{id}/worker_pools", s.listWorkerPools)
+	s.handle("POST /api/v2/kubernetes/clusters/{id}/worker_pools", s.createWorkerPool)
+	s.handle("GET /api/v2/kubernetes/clusters/{id}/worker_pools/{poolID}", s.getWorkerPool)
+	s.handle("PATCH /api/v2/kubernetes/clusters/{id}/worker_pools/{poolID}", s.updateWorkerPool)
+	s.handle("DELETE /api/v2/kubernetes/clusters/{id}/worker_pools/{poolID}", s.deleteWorkerPool)
+	s.handle("DELETE /api/v2/kubernetes/clusters/{id}/worker_pools/{poolID}/workers/{workerID}", s.deleteWorker)
+}
+
+func (s *Server) findCluster(rw http.ResponseWriter, req *http.Request) (*ah.KubernetesCluster, bool) {
+	cluster, ok := s.clusters[req.PathValue("id")]
+	if !ok {
+		writeNotFound(rw, "cluster")
+	}
+	return cluster, ok
+}
+
+func (s *Server) findWorkerPool(rw http.ResponseWriter, req *http.Request) (*ah.KubernetesCluster, int, bool) {
+	cluster, ok := s.findCluster(rw, req)
+	if !ok {
+		return nil, 0, false
+	}
+	index := slices.IndexFunc(cluster.WorkerPools, func(pool ah.KubernetesWorkerPool) bool {
+		return pool.ID == req.PathValue("poolID")
+	})
+	if index < 0 {
+		writeNotFound(rw, "worker pool")
+		return nil, 0, false
+	}
+	return cluster, index, true
+}
+
+func (s *Server) newWorker(pool *ah.KubernetesWorkerPool) ah.KubernetesWorker {
+	number := s.nextNumber()
+	return ah.KubernetesWorker{
+		ID:        s.newID(),
+		Name:      fmt.Sprintf("worker-%d", number),
+		State:     workerStateActive,
+		Type:      pool.Type,
+		Labels:    pool.Labels,
+		CreatedAt: now(),
+	}
+}
+
+// resizeWorkerPool adds or removes workers to match the pool's count
+func (s *Server) resizeWorkerPool(pool *ah.KubernetesWorkerPool) {
+	for len(pool.Workers) < pool.Count {
+		pool.Workers = append(pool.Workers, s.newWorker(pool))
+	}
+	if len(pool.Workers) > pool.Count {
+		pool.Workers = pool.Workers[:pool.Count]
+	}
+}
+
+func (s *Server) newWorkerPool(request *ah.CreateKubernetesWorkerPoolRequest) ah.KubernetesWorkerPool {
+	pool := ah.KubernetesWorkerPool{
+		ID:        s.newID(),
+		Name:      fmt.Sprintf("pool-%d", s.nextNumber()),
+		Type:      request.Type,
+		Count:     request.Count,
+		AutoScale: request.AutoScale,
+		MinCount:  request.MinCount,
+		MaxCount:  request.MaxCount,
+		CreatedAt: now(),
+	}
+	if request.Labels != nil {
+		pool.Labels = *request.Labels
+	}
+	if request.PublicProperties != nil {
+		pool.PublicProperties = *request.PublicProperties
+	}
+	if request.PrivateProperties != nil {
+		pool.PrivateProperties = *request.PrivateProperties
+	}
+	s.resizeWorkerPool(&pool)
+	return pool
+}
+
+func (s *Server) listClusters(rw http.ResponseWriter, req *http.Request) {
+	clusters, meta := query(req, s.clusters)
+	writeJSON(rw, http.StatusOK, map[string]interface{}{"clusters": clusters, "meta": meta})
+}
+
+func (s *Server) getCluster(rw http.ResponseWriter, req *http.Request) {
+	if cluster, ok := s.findCluster(rw, req); ok {
+		writeJSON(rw, http.StatusOK, map[string]interface{}{"cluster": cluster})
+	}
+}
+
+func (s *Server) createCluster(rw http.ResponseWriter, req *http.Request) {
+	var request ah.KubernetesClusterCreateRequest
+	if !readJSON(rw, req, &request) {
+		return
+	}
+	if request.Name == "" {
+		writeValidationError(rw, "name", "can't be blank")
+		return
+	}
+	if !slices.Contains(KubernetesVersions, request.K8sVersion) {
+		writeValidationError(rw, "k8s_version", "is not supported")
+		return
+	}
+
+	cluster := &ah.KubernetesCluster{
+		ID:           s.newID(),
+		Number:       strconv.Itoa(s.nextNumber()),
+		Name:         request.Name,
+		DatacenterID: request.DatacenterID,
+		K8sVersion:   request.K8sVersion,
+		State:        clusterStateCreating,
+		CreatedAt:    now(),
+	}
+	for _, poolRequest := range request.WorkerPools {
+		cluster.WorkerPools = append(cluster.WorkerPools, s.newWorkerPool(&poolRequest))
+	}
+	s.clusters[cluster.ID] = cluster
+
+	s.newAction("cluster", cluster.ID, "create", func() {
+		cluster.State = ah.KubernetesClusterStateActive
+	}).onFailure = func() {
+		cluster.State = clusterStateError
+	}
+	writeJSON(rw, http.StatusCreated, map[string]interface{}{"cluster": cluster})
+}
+
+func (s *Server) updateCluster(rw http.ResponseWriter, req *http.Request) {
+	cluster, ok := s.findCluster(rw, req)
+	if !ok {
+		return
+	}
+	var request ah.KubernetesClusterUpdateRequest
+	if !readJSON(rw, req, &request) {
+		return
+	}
+	if request.Name != "" {
+		cluster.Name = request.Name
+	}
+	writeJSON(rw, http.StatusOK, map[string]interface{}{"cluster": cluster})
+}
+
+func (s *Server) deleteCluster(rw http.ResponseWriter, req *http.Request) {
+	cluster, ok := s.findCluster(rw, req)
+	if !ok {
+		return
+	}
+	delete(s.clusters, cluster.ID)
+	rw.WriteHeader(http.StatusAccepted)
+}
+
+func (s *Server) listKubernetesVersions(rw http.ResponseWriter, req *http.Request) {
+	writeJSON(rw, http.StatusOK, KubernetesVersions)
+}
+
+func (s *Server) getKubeconfig(rw http.ResponseWriter, req *http.Request) {
+	cluster, ok := s.findCluster(rw, req)
+	if !ok {
+		return
+	}
+	if cluster.State != ah.KubernetesClusterStateActive {
+		writeError(rw, http.StatusUnprocessableEntity, "cluster is not active")
+		return
+	}
+	config := fmt.Sprintf(`apiVersion: v1
+kind: Config
+clusters:
+- name: %[1]s
+  cluster:
+    server: https://%[2]s.k8s.ahtest
+contexts:
+- name: %[1]s
+  context:
+    cluster: %[1]s
+    user: %[1]s-admin
+current-context: %[1]s
+users:
+- name: %[1]s-admin
+  user:
+    token: %[2]s
+`, cluster.Name, cluster.ID)
+	writeJSON(rw, http.StatusOK, map[string]string{"config": config})
+}
+
+func (s *Server) listWorkerPools(rw http.ResponseWriter, req *http.Request) {
+	if cluster, ok := s.findCluster(rw, req); ok {
+		byID := map[string]*ah.KubernetesWorkerPool{}
+		for i := range cluster.WorkerPools {
+			byID[cluster.WorkerPools[i].ID] = &cluster.WorkerPools[i]
+		}
+		pools, meta := query(req, byID)
+		writeJSON(rw, http.StatusOK, map[string]interface{}{"worker_pools": pools, "meta": meta})
+	}
+}
+
+func (s *Server) createWorkerPool(rw http.ResponseWriter, req *http.Request) {
+	cluster, ok := s.findCluster(rw, req)
+	if !ok {
+		return
+	}
+	var request ah.CreateKubernetesWorkerPoolRequest
+	if !readJSON(rw, req, &request) {
+		return
+	}
+	if request.Type == "" {
+		writeValidationError(rw, "type", "can't be blank")
+		return
+	}
+	pool := s.newWorkerPool(&request)
+	cluster.WorkerPools = append(cluster.WorkerPools, pool)
+	writeJSON(rw, http.StatusCreated, map[string]interface{}{"worker_pool": pool})
+}
+
+func (s *Server) getWorkerPool(rw http.ResponseWriter, req *http.Request) {
+	if cluster, i, ok := s.findWorkerPool(rw, req); ok {
+		writeJSON(rw, http.StatusOK, map[string]interface{}{"worker_pool": cluster.WorkerPools[i]})
+	}
+}
+
+func (s *Server) updateWorkerPool(rw http.ResponseWriter, req *http.Request) {
+	cluster, i, ok := s.findWorkerPool(rw, req)
+	if !ok {
+		return
+	}
+	var request ah.UpdateKubernetesWorkerPoolRequest
+	if !readJSON(rw, req, &request) {
+		return
+	}
+	pool := &cluster.WorkerPools[i]
+	if request.Labels != nil {
+		pool.Labels = *request.Labels
+	}
+	if request.Count != 0 {
+		pool.Count = request.Count
+	}
+	if request.MinCount != 0 {
+		pool.MinCount = request.MinCount
+	}
+	if request.MaxCount != 0 {
+		pool.MaxCount = request.MaxCount
+	}
+	pool.AutoScale = request.AutoScale
+	s.resizeWorkerPool(pool)
+	writeJSON(rw, http.StatusOK, map[string]interface{}{"worker_pool": pool})
+}
+
+func (s *Server) deleteWorkerPool(rw http.ResponseWriter, req *http.Request) {
+	cluster, i, ok := s.findWorkerPool(rw, req)
+	if !ok {
+		return
+	}
+	cluster.WorkerPools = slices.Delete(cluster.WorkerPools, i, i+1)
+	rw.WriteHeader(http.StatusAccepted)
+}
+
+func (s *Server) deleteWorker(rw http.ResponseWriter, req *http.Request) {
+	cluster, i, ok := s.findWorkerPool(rw, req)
+	if !ok {
+		return
+	}
+	pool := &cluster.WorkerPools[i]
+	index := slices.IndexFunc(pool.Workers, func(worker ah.KubernetesWorker) bool {
+		return worker.ID == req.PathValue("workerID")
+	})
+	if index < 0 {
+		writeNotFound(rw, "worker")
+		return
+	}
+	pool.Workers = slices.Delete(pool.Workers, index, index+1)
+	if replace, _ := strconv.ParseBool(req.URL.Query().Get("replace")); replace {
+		pool.Workers = append(pool.Workers, s.newWorker(pool))
+	} else {
+		pool.Count--
+	}
+	rw.WriteHeader(http.StatusAccepted)
+}
@@ -0,0 +1,538 @@
+/*
+Copyright 2023 Advanced Hosting
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ahtest
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/advancedhosting/advancedhosting-api-go/ah"
+)
+
+const (
+	loadBalancerStateCreating = "creating"
+	loadBalancerStateActive   = "active"
+	loadBalancerStateError    = "error"
+)
+
+func (s *Server) registerLoadBalancers() {
+	s.handle("GET /api/v1/load_balancers", s.listLoadBalancers)
+	s.handle("POST /api/v1/load_balancers", s.createLoadBalancer)
+	s.handle("GET /api/v1/load_balancers/{id}", s.getLoadBalancer)
+	s.handle("PATCH /api/v1/load_balancers/{id}", s.updateLoadBalancer)
+	s.handle("DELETE /api/v1/load_balancers/{id}", s.deleteLoadBalancer)
+
+	s.handle("GET /api/v1/load_balancers/{id}/forwarding_rules", s.listLBForwardingRules)
+	s.handle("POST /api/v1/load_balancers/{id}/forwarding_rules", s.createLBForwardingRule)
+	s.handle("GET /api/v1/load_balancers/{id}/forwarding_rules/{subID}", s.getLBForwardingRule)
+	s.handle("DELETE /api/v1/load_balancers/{id}/forwarding_rules/{subID}", s.deleteLBForwardingRule)
+
+	s.handle("GET /api/v1/load_balancers/{id}/private_networks", s.listLBPrivateNetworks)
+	s.handle("POST /api/v1/load_balancers/{id}/private_networks", s.connectLBPrivateNetworks)
+	s.handle("GET /api/v1/load_balancers/{id}/private_networks/{subID}", s.getLBPrivateNetwork)
+	s.handle("DELETE /api/v1/load_balancers/{id}/private_networks/{subID}", s.disconnectLBPrivateNetwork)
+
+	s.handle("GET /api/v1/load_balancers/{id}/backend_nodes", s.listLBBackendNodes)
+	s.handle("POST /api/v1/load_balancers/{id}/backend_nodes", s.addLBBackendNodes)
+	s.handle("GET /api/v1/load_balancers/{id}/backend_nodes/{subID}", s.getLBBackendNode)
+	s.handle("DELETE /api/v1/load_balancers/{id}/backend_nodes/{subID}", s.deleteLBBackendNode)
+
+	s.handle("GET /api/v1/load_balancers/{id}/health_checks", s.listLBHealthChecks)
+	s.handle("POST /api/v1/load_balancers/{id}/health_checks", s.createLBHealthCheck)
+	s.handle("GET /api/v1/load_balancers/{id}/health_checks/{subID}", s.getLBHealthCheck)
+	s.handle("PATCH /api/v1/load_balancers/{id}/health_checks/{subID}", s.updateLBHealthCheck)
+	s.handle("DELETE /api/v1/load_balancers/{id}/health_checks/{subID}", s.deleteLBHealthCheck)
+
+	s.handle("GET /api/v1/load_balancers/{id}/ip_addresses", s.listLBIPAddresses)
+	s.handle("POST /api/v1/load_balancers/{id}/ip_addresses", s.assignLBIPAddresses)
+	s.handle("GET /api/v1/load_balancers/{id}/ip_addresses/{subID}", s.getLBIPAddress)
+	s.handle("DELETE /api/v1/load_balancers/{id}/ip_addresses/{subID}", s.releaseLBIPAddress)
+}
+
+func (s *Server) findLoadBalancer(rw http.ResponseWriter, req *http.Request) (*ah.LoadBalancer, bool) {
+	lb, ok := s.loadBalancers[req.PathValue("id")]
+	if !ok {
+		writeNotFound(rw, "load balancer")
+	}
+	return lb, ok
+}
+
+// findLBItem returns the index of the load balancer's subresource with subID path value
+func findLBItem[T any](rw http.ResponseWriter, req *http.Request, items []T, id func(T) string) (int, bool) {
+	index := slices.IndexFunc(items, func(item T) bool {
+		return id(item) == req.PathValue("subID")
+	})
+	if index < 0 {
+		writeNotFound(rw, "load balancer resource")
+	}
+	return index, index >= 0
+}
+
+func (s *Server) listLoadBalancers(rw http.ResponseWriter, req *http.Request) {
+	loadBalancers, meta := query(req, s.loadBalancers)
+	writeJSON(rw, http.StatusOK, map[string]interface{}{"load_balancers": loadBalancers, "meta": meta})
+}
+
+func (s *Server) getLoadBalancer(rw http.ResponseWriter, req *http.Request) {
+	if lb, ok := s.findLoadBalancer(rw, req); ok {
+		writeJSON(rw, http.StatusOK, map[string]interface{}{"load_balancer": lb})
+	}
+}
+
+func (s *Server) createLoadBalancer(rw http.ResponseWriter, req *http.Request) {
+	var request struct {
+		LoadBalancer *ah.LoadBalancerCreateRequest `json:"load_balancer"`
+	}
+	if !readJSON(rw, req, &request) {
+		return
+	}
+	createRequest := request.LoadBalancer
+	if createRequest == nil || createRequest.Name == "" {
+		writeValidationError(rw, "name", "can't be blank")
+		return
+	}
+
+	lb := &ah.LoadBalancer{
+		ID:                 s.newID(),
+		Name:               createRequest.Name,
+		DatacenterID:       createRequest.DatacenterID,
+		State:              loadBalancerStateCreating,
+		BalancingAlgorithm: createRequest.BalancingAlgorithm,
+		ProxyProtocol:      createRequest.ProxyProtocol,
+		Meta:               createRequest.Meta,
+		CUCount:            createRequest.CUCount,
+		CUMax:              createRequest.CUMax,
+		InstanceCount:      createRequest.InstanceCount,
+		HAOn:               createRequest.HAOn,
+	}
+	if !s.assignLBIPAddressIDs(rw, lb, createRequest.IPAddressIDs) ||
+		!s.connectLBPrivateNetworkIDs(rw, lb, createRequest.PrivateNetworkIDs) ||
+		!s.addLBBackendNodeIDs(rw, lb, createRequest.BackendNodes) {
+		return
+	}
+	if createRequest.CreatePublicIPAddress {
+		ip := s.newIPAddress("public", "")
+		lb.IPAddresses = append(lb.IPAddresses, ah.LBIPAddress{
+			ID: ip.ID, Type: ip.Type, Address: ip.Address, State: loadBalancerStateActive,
+		})
+	}
+	for _, rule := range createRequest.ForwardingRules {
+		lb.ForwardingRules = append(lb.ForwardingRules, s.newLBForwardingRule(&rule))
+	}
+	if createRequest.HealthCheck != nil {
+		lb.HealthCheck = s.newLBHealthCheck(createRequest.HealthCheck)
+	}
+	s.loadBalancers[lb.ID] = lb
+
+	s.newAction("load_balancer", lb.ID, "create", func() {
+		lb.State = loadBalancerStateActive
+	}).onFailure = func() {
+		lb.State = loadBalancerStateError
+	}
+	writeJSON(rw, http.StatusCreated, map[string]interface{}{"load_balancer": lb})
+}
+
+func (s *Server) updateLoadBalancer(rw http.ResponseWriter, req *http.Request) {
+	lb, ok := s.findLoadBalancer(rw, req)
+	if !ok {
+		return
+	}
+	var request struct {
+		LoadBalancer *ah.LoadBalancerUpdateRequest `json:"load_balancer"`
+	}
+	if !readJSON(rw, req, &request) {
+		return
+	}
+	if update := request.LoadBalancer; update != nil {
+		if update.Name != "" {
+			lb.Name = update.Name
+		}
+		if update.BalancingAlgorithm != "" {
+			lb.BalancingAlgorithm = update.BalancingAlgorithm
+		}
+		if update.ProxyProtocol != "" {
+			lb.ProxyProtocol = update.ProxyProtocol
+		}
+		if update.CUCount != 0 {
+			lb.CUCount = update.CUCount
+		}
+		if update.CUMax != 0 {
+			lb.CUMax = update.CUMax
+		}
+		if update.InstanceCount != 0 {
+			lb.InstanceCount = update.InstanceCount
+		}
+		lb.HAOn = update.HAOn
+	}
+	writeJSON(rw, http.StatusOK, map[string]interface{}{"load_balancer": lb})
+}
+
+func (s *Server) deleteLoadBalancer(rw http.ResponseWriter, req *http.Request) {
+	lb, ok := s.findLoadBalancer(rw, req)
+	if !ok {
+		return
+	}
+	delete(s.loadBalancers, lb.ID)
+	rw.WriteHeader(http.StatusAccepted)
+}
+
+func (s *Server) newLBForwardingRule(request *ah.LBForwardingRuleCreateRequest) ah.LBForwardingRule {
+	return ah.LBForwardingRule{
+		ID:                    s.newID(),
+		State:                 loadBalancerStateActive,
+		RequestProtocol:       request.RequestProtocol,
+		CommunicationProtocol: request.CommunicationProtocol,
+		RequestPort:           request.RequestPort,
+		CommunicationPort:     request.CommunicationPort,
+	}
+}
+
+func (s *Server) listLBForwardingRules(rw http.ResponseWriter, req *http.Request) {
+	if lb, ok := s.findLoadBalancer(rw, req); ok {
+		writeJSON(rw, http.StatusOK, map[string]interface{}{"forwarding_rules": lb.ForwardingRules})
+	}
+}
+
+func (s *Server) createLBForwardingRule(rw http.ResponseWriter, req *http.Request) {
+	lb, ok := s.findLoadBalancer(rw, req)
+	if !ok {
+		return
+	}
+	var request ah.LBForwardingRuleCreateRequest
+	if !readJSON(rw, req, &request) {
+		return
+	}
+	if request.RequestPort == 0 {
+		writeValidationError(rw, "request_port", "can't be blank")
+		return
+	}
+	rule := s.newLBForwardingRule(&request)
+	lb.ForwardingRules = append(lb.ForwardingRules, rule)
+	writeJSON(rw, http.StatusCreated, map[string]interface{}{"forwarding_rule": rule})
+}
+
+func lbForwardingRuleID(rule ah.LBForwardingRule) string { return rule.ID }
+
+func (s *Server) getLBForwardingRule(rw http.ResponseWriter, req *http.Request) {
+	lb, ok := s.findLoadBalancer(rw, req)
+	if !ok {
+		return
+	}
+	if i, ok := findLBItem(rw, req, lb.ForwardingRules, lbForwardingRuleID); ok {
+		writeJSON(rw, http.StatusOK, map[string]interface{}{"forwarding_rule": lb.ForwardingRules[i]})
+	}
+}
+
+func (s *Server) deleteLBForwardingRule(rw http.ResponseWriter, req *http.Request) {
+	lb, ok := s.findLoadBalancer(rw, req)
+	if !ok {
+		return
+	}
+	if i, ok := findLBItem(rw, req, lb.ForwardingRules, lbForwardingRuleID); ok {
+		lb.ForwardingRules = slices.Delete(lb.ForwardingRules, i, i+1)
+		rw.WriteHeader(http.StatusAccepted)
+	}
+}
+
+// connectLBPrivateNetworkIDs connects the load balancer to private networks and writes 422 response on failure
+func (s *Server) connectLBPrivateNetworkIDs(rw http.ResponseWriter, lb *ah.LoadBalancer, pnIDs []string) bool {
+	for _, pnID := range pnIDs {
+		if _, ok := s.privateNetworks[pnID]; !ok {
+			writeValidationError(rw, "private_network_ids", fmt.Sprintf("private network %s not found", pnID))
+			return false
+		}
+	}
+	for _, pnID := range pnIDs {
+		lb.PrivateNetworks = append(lb.PrivateNetworks, ah.LBPrivateNetwork{ID: pnID, State: loadBalancerStateActive})
+	}
+	return true
+}
+
+func lbPrivateNetworkID(pn ah.LBPrivateNetwork) string { return pn.ID }
+
+func (s *Server) listLBPrivateNetworks(rw http.ResponseWriter, req *http.Request) {
+	if lb, ok := s.findLoadBalancer(rw, req); ok {
+		writeJSON(rw, http.StatusOK, map[string]interface{}{"private_networks": lb.PrivateNetworks})
+	}
+}
+
+func (s *Server) connectLBPrivateNetworks(rw http.ResponseWriter, req *http.Request) {
+	lb, ok := s.findLoadBalancer(rw, req)
+	if !ok {
+		return
+	}
+	var request struct {
+		PrivateNetworkIDs []string `json:"private_network_ids"`
+	}
+	if !readJSON(rw, req, &request) {
+		return
+	}
+	if s.connectLBPrivateNetworkIDs(rw, lb, request.PrivateNetworkIDs) {
+		writeJSON(rw, http.StatusCreated, map[string]interface{}{"private_networks": lb.PrivateNetworks})
+	}
+}
+
+func (s *Server) getLBPrivateNetwork(rw http.ResponseWriter, req *http.Request) {
+	lb, ok := s.findLoadBalancer(rw, req)
+	if !ok {
+		return
+	}
+	if i, ok := findLBItem(rw, req, lb.PrivateNetworks, lbPrivateNetworkID); ok {
+		writeJSON(rw, http.StatusOK, map[string]interface{}{"private_network": lb.PrivateNetworks[i]})
+	}
+}
+
+func (s *Server) disconnectLBPrivateNetwork(rw http.ResponseWriter, req *http.Request) {
+	lb, ok := s.findLoadBalancer(rw, req)
+	if !ok {
+		return
+	}
+	if i, ok := findLBItem(rw, req, lb.PrivateNetworks, lbPrivateNetworkID); ok {
+		lb.PrivateNetworks = slices.Delete(lb.PrivateNetworks, i, i+1)
+		rw.WriteHeader(http.StatusAccepted)
+	}
+}
+
+// addLBBackendNodeIDs adds instances to the load balancer and writes 422 response on failure
+func (s *Server) addLBBackendNodeIDs(rw http.ResponseWriter, lb *ah.LoadBalancer, nodes []ah.LBBackendNodeCreateRequest) bool {
+	for _, node := range nodes {
+		if _, ok := s.instances[node.CloudServerID]; !ok {
+			writeValidationError(rw, "cloud_server_id", fmt.Sprintf("instance %s not found", node.CloudServerID))
+			return false
+		}
+	}
+	for _, node := range nodes {
+		lb.BackendNodes = append(lb.BackendNodes, ah.LBBackendNode{
+			ID:            s.newID(),
+			State:         loadBalancerStateActive,
+			CloudServerID: node.CloudServerID,
+		})
+	}
+	return true
+}
+
+func lbBackendNodeID(node ah.LBBackendNode) string { return node.ID }
+
+func (s *Server) listLBBackendNodes(rw http.ResponseWriter, req *http.Request) {
+	if lb, ok := s.findLoadBalancer(rw, req); ok {
+		writeJSON(rw, http.StatusOK, map[string]interface{}{"backend_nodes": lb.BackendNodes})
+	}
+}
+
+func (s *Server) addLBBackendNodes(rw http.ResponseWriter, req *http.Request) {
+	lb, ok := s.findLoadBalancer(rw, req)
+	if !ok {
+		return
+	}
+	var request []ah.LBBackendNodeCreateRequest
+	if !readJSON(rw, req, &request) {
+		return
+	}
+	count := len(lb.BackendNodes)
+	if s.addLBBackendNodeIDs(rw, lb, request) {
+		writeJSON(rw, http.StatusCreated, map[string]interface{}{"backend_nodes": lb.BackendNodes[count:]})
+	}
+}
+
+func (s *Server) getLBBackendNode(rw http.ResponseWriter, req *http.Request) {
+	lb, ok := s.findLoadBalancer(rw, req)
+	if !ok {
+		return
+	}
+	if i, ok := findLBItem(rw, req, lb.BackendNodes, lbBackendNodeID); ok {
+		writeJSON(rw, http.StatusOK, map[string]interface{}{"backend_node": lb.BackendNodes[i]})
+	}
+}
+
+func (s *Server) deleteLBBackendNode(rw http.ResponseWriter, req *http.Request) {
+	lb, ok := s.findLoadBalancer(rw, req)
+	if !ok {
+		return
+	}
+	if i, ok := findLBItem(rw, req, lb.BackendNodes, lbBackendNodeID); ok {
+		lb.BackendNodes = slices.Delete(lb.BackendNodes, i, i+1)
+		rw.WriteHeader(http.StatusAccepted)
+	}
+}
+
+func (s *Server) newLBHealthCheck(request *ah.LBHealthCheckCreateRequest) ah.LBHealthCheck {
+	return ah.LBHealthCheck{
+		ID:                 s.newID(),
+		State:              loadBalancerStateActive,
+		Type:               request.Type,
+		URL:                request.URL,
+		Interval:           request.Interval,
+		Timeout:            request.Timeout,
+		UnhealthyThreshold: request.UnhealthyThreshold,
+		HealthyThreshold:   request.HealthyThreshold,
+		Port:               request.Port,
+	}
+}
+
+// lbHealthChecks returns the load balancer's health check as a list
+func lbHealthChecks(lb *ah.LoadBalancer) []ah.LBHealthCheck {
+	if lb.HealthCheck.ID == "" {
+		return []ah.LBHealthCheck{}
+	}
+	return []ah.LBHealthCheck{lb.HealthCheck}
+}
+
+func lbHealthCheckID(hc ah.LBHealthCheck) string { return hc.ID }
+
+func (s *Server) listLBHealthChecks(rw http.ResponseWriter, req *http.Request) {
+	if lb, ok := s.findLoadBalancer(rw, req); ok {
+		writeJSON(rw, http.StatusOK, map[string]interface{}{"health_checks": lbHealthChecks(lb)})
+	}
+}
+
+func (s *Server) createLBHealthCheck(rw http.ResponseWriter, req *http.Request) {
+	lb, ok := s.findLoadBalancer(rw, req)
+	if !ok {
+		return
+	}
+	var request ah.LBHealthCheckCreateRequest
+	if !readJSON(rw, req, &request) {
+		return
+	}
+	if lb.HealthCheck.ID != "" {
+		writeValidationError(rw, "health_check", "load balancer already has a health check")
+		return
+	}
+	lb.HealthCheck = s.newLBHealthCheck(&request)
+	writeJSON(rw, http.StatusCreated, map[string]interface{}{"health_check": lb.HealthCheck})
+}
+
+func (s *Server) getLBHealthCheck(rw http.ResponseWriter, req *http.Request) {
+	lb, ok := s.findLoadBalancer(rw, req)
+	if !ok {
+		return
+	}
+	if _, ok := findLBItem(rw, req, lbHealthChecks(lb), lbHealthCheckID); ok {
+		writeJSON(rw, http.StatusOK, map[string]interface{}{"health_check": lb.HealthCheck})
+	}
+}
+
+func (s *Server) updateLBHealthCheck(rw http.ResponseWriter, req *http.Request) {
+	lb, ok := s.findLoadBalancer(rw, req)
+	if !ok {
+		return
+	}
+	if _, ok := findLBItem(rw, req, lbHealthChecks(lb), lbHealthCheckID); !ok {
+		return
+	}
+	var request ah.LBHealthCheckUpdateRequest
+	if !readJSON(rw, req, &request) {
+		return
+	}
+	hc := &lb.HealthCheck
+	if request.Type != "" {
+		hc.Type = request.Type
+	}
+	if request.URL != "" {
+		hc.URL = request.URL
+	}
+	if request.Interval != 0 {
+		hc.Interval = request.Interval
+	}
+	if request.Timeout != 0 {
+		hc.Timeout = request.Timeout
+	}
+	if request.UnhealthyThreshold != 0 {
+		hc.UnhealthyThreshold = request.UnhealthyThreshold
+	}
+	if request.HealthyThreshold != 0 {
+		hc.HealthyThreshold = request.HealthyThreshold
+	}
+	if request.Port != 0 {
+		hc.Port = request.Port
+	}
+	writeJSON(rw, http.StatusOK, map[string]interface{}{"health_check": hc})
+}
+
+func (s *Server) deleteLBHealthCheck(rw http.ResponseWriter, req *http.Request) {
+	lb, ok := s.findLoadBalancer(rw, req)
+	if !ok {
+		return
+	}
+	if _, ok := findLBItem(rw, req, lbHealthChecks(lb), lbHealthCheckID); ok {
+		lb.HealthCheck = ah.LBHealthCheck{}
+		rw.WriteHeader(http.StatusAccepted)
+	}
+}
+
+// assignLBIPAddressIDs assigns ip addresses to the load balancer and writes 422 response on failure
+func (s *Server) assignLBIPAddressIDs(rw http.ResponseWriter, lb *ah.LoadBalancer, ipIDs []string) bool {
+	for _, ipID := range ipIDs {
+		if _, ok := s.ipAddresses[ipID]; !ok {
+			writeValidationError(rw, "ip_address_ids", fmt.Sprintf("ip address %s not found", ipID))
+			return false
+		}
+	}
+	for _, ipID := range ipIDs {
+		ip := s.ipAddresses[ipID]
+		lb.IPAddresses = append(lb.IPAddresses, ah.LBIPAddress{
+			ID: ip.ID, Type: ip.Type, Address: ip.Address, State: loadBalancerStateActive,
+		})
+	}
+	return true
+}
+
+func lbIPAddressID(ip ah.LBIPAddress) string { return ip.ID }
+
+func (s *Server) listLBIPAddresses(rw http.ResponseWriter, req *http.Request) {
+	if lb, ok := s.findLoadBalancer(rw, req); ok {
+		writeJSON(rw, http.StatusOK, map[string]interface{}{"ip_addresses": lb.IPAddresses})
+	}
+}
+
+func (s *Server) assignLBIPAddresses(rw http.ResponseWriter, req *http.Request) {
+	lb, ok := s.findLoadBalancer(rw, req)
+	if !ok {
+		return
+	}
+	var request struct {
+		IPAddressIDs []string `json:"ip_address_ids"`
+	}
+	if !readJSON(rw, req, &request) {
+		return
+	}
+	if s.assignLBIPAddressIDs(rw, lb, request.IPAddressIDs) {
+		writeJSON(rw, http.StatusCreated, map[string]interface{}{"ip_addresses": lb.IPAddresses})
+	}
+}
+
+func (s *Server) getLBIPAddress(rw http.ResponseWriter, req *http.Request) {
+	lb, ok := s.findLoadBalancer(rw, req)
+	if !ok {
+		return
+	}
+	if i, ok := findLBItem(rw, req, lb.IPAddresses, lbIPAddressID); ok {
+		writeJSON(rw, http.StatusOK, map[string]interface{}{"ip_address": lb.IPAddresses[i]})
+	}
+}
+
+func (s *Server) releaseLBIPAddress(rw http.ResponseWriter, req *http.Request) {
+	lb, ok := s.findLoadBalancer(rw, req)
+	if !ok {
+		return
+	}
+	if i, ok := findLBItem(rw, req, lb.IPAddresses, lbIPAddressID); ok {
+		lb.IPAddresses = slices.Delete(lb.IPAddresses, i, i+1)
+		rw.WriteHeader(http.StatusAccepted)
+	}
+}
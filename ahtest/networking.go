@@ -0,0 +1,409 @@
+/*
+Copyright 2023 Advanced Hosting
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ahtest
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/advancedhosting/advancedhosting-api-go/ah"
+)
+
+const (
+	ipAssignmentStateAssigned            = "assigned"
+	privateNetworkStateActive            = "active"
+	instancePrivateNetworkStateConnected = "connected"
+	defaultPrivateNetworkCIDR            = "10.0.0.0/24"
+	defaultIPAddressDatacenterFullName   = "ahtest"
+)
+
+func (s *Server) registerIPAddresses() {
+	s.handle("GET /api/v1/ip_addresses", s.listIPAddresses)
+	s.handle("POST /api/v1/ip_addresses", s.createIPAddress)
+	s.handle("PATCH /api/v1/ip_addresses/{id}", s.updateIPAddress)
+	s.handle("DELETE /api/v1/ip_addresses/{id}", s.deleteIPAddress)
+	s.handle("GET /api/v1/instance_ip_addresses", s.listIPAddressAssignments)
+	s.handle("POST /api/v1/instance_ip_addresses", s.createIPAddressAssignment)
+	s.handle("GET /api/v1/instance_ip_addresses/{id}", s.getIPAddressAssignment)
+	s.handle("DELETE /api/v1/instance_ip_addresses/{id}", s.deleteIPAddressAssignment)
+}
+
+func (s *Server) registerPrivateNetworks() {
+	s.handle("GET /api/v1/private_networks", s.listPrivateNetworks)
+	s.handle("POST /api/v1/private_networks", s.createPrivateNetwork)
+	s.handle("GET /api/v1/private_networks/{id}", s.getPrivateNetwork)
+	s.handle("PUT /api/v1/private_networks/{id}", s.updatePrivateNetwork)
+	s.handle("DELETE /api/v1/private_networks/{id}", s.deletePrivateNetwork)
+	s.handle("POST /api/v1/instance_private_networks", s.createInstancePrivateNetwork)
+	s.handle("GET /api/v1/instance_private_networks/{id}", s.getInstancePrivateNetwork)
+	s.handle("PATCH /api/v1/instance_private_networks/{id}", s.updateInstancePrivateNetwork)
+	s.handle("DELETE /api/v1/instance_private_networks/{id}", s.deleteInstancePrivateNetwork)
+}
+
+// newIPAddress allocates an address from 100.64.0.0/10 shared address space
+func (s *Server) newIPAddress(addressType, reverseDNS string) *ah.IPAddress {
+	number := s.nextNumber()
+	ip := &ah.IPAddress{
+		ID:                 s.newID(),
+		Type:               addressType,
+		Address:            fmt.Sprintf("100.64.%d.%d", number/254%256, number%254+1),
+		ReverseDNS:         reverseDNS,
+		DatacenterFullName: defaultIPAddressDatacenterFullName,
+		CreatedAt:          now(),
+		UpdatedAt:          now(),
+	}
+	s.ipAddresses[ip.ID] = ip
+	return ip
+}
+
+func (s *Server) assignIPAddress(ip *ah.IPAddress, instance *ah.Instance) *ah.IPAddressAssignment {
+	assignment := &ah.IPAddressAssignment{
+		ID:          s.newID(),
+		InstanceID:  instance.ID,
+		IPAddressID: ip.ID,
+		State:       ipAssignmentStateAssigned,
+		CreatedAt:   now(),
+		UpdatedAt:   now(),
+	}
+	s.ipAssignments[assignment.ID] = assignment
+	ip.InstanceIDs = append(ip.InstanceIDs, instance.ID)
+	return assignment
+}
+
+func (s *Server) unassignIPAddress(assignment *ah.IPAddressAssignment) {
+	delete(s.ipAssignments, assignment.ID)
+	if ip, ok := s.ipAddresses[assignment.IPAddressID]; ok {
+		ip.InstanceIDs = slices.DeleteFunc(ip.InstanceIDs, func(id string) bool {
+			return id == assignment.InstanceID
+		})
+	}
+	if instance, ok := s.instances[assignment.InstanceID]; ok && instance.PrimaryInstanceIPAddressID == assignment.ID {
+		instance.PrimaryInstanceIPAddressID = ""
+	}
+}
+
+func (s *Server) listIPAddresses(rw http.ResponseWriter, req *http.Request) {
+	ipAddresses, meta := query(req, s.ipAddresses)
+	writeJSON(rw, http.StatusOK, map[string]interface{}{"ip_addresses": ipAddresses, "meta": meta})
+}
+
+func (s *Server) createIPAddress(rw http.ResponseWriter, req *http.Request) {
+	var request struct {
+		IPAddress *ah.IPAddressCreateRequest `json:"ip_address"`
+	}
+	if !readJSON(rw, req, &request) {
+		return
+	}
+	createRequest := request.IPAddress
+	if createRequest == nil || createRequest.Type == "" {
+		writeValidationError(rw, "address_type", "can't be blank")
+		return
+	}
+	var instances []*ah.Instance
+	for _, instanceID := range createRequest.InstanceIDs {
+		instance, ok := s.instances[instanceID]
+		if !ok {
+			writeValidationError(rw, "instance_ids", fmt.Sprintf("instance %s not found", instanceID))
+			return
+		}
+		instances = append(instances, instance)
+	}
+
+	ip := s.newIPAddress(createRequest.Type, createRequest.ReverseDNS)
+	ip.DeleteProtection = createRequest.DeleteProtection
+	for _, instance := range instances {
+		s.assignIPAddress(ip, instance)
+	}
+	writeJSON(rw, http.StatusCreated, map[string]interface{}{"ip_address": ip})
+}
+
+func (s *Server) updateIPAddress(rw http.ResponseWriter, req *http.Request) {
+	ip, ok := s.ipAddresses[req.PathValue("id")]
+	if !ok {
+		writeNotFound(rw, "ip address")
+		return
+	}
+	var request ah.IPAddressUpdateRequest
+	if !readJSON(rw, req, &request) {
+		return
+	}
+	if request.ReverseDNS != "" {
+		ip.ReverseDNS = request.ReverseDNS
+	}
+	ip.DeleteProtection = request.DeleteProtection
+	ip.UpdatedAt = now()
+	writeJSON(rw, http.StatusOK, map[string]interface{}{"ip_address": ip})
+}
+
+func (s *Server) deleteIPAddress(rw http.ResponseWriter, req *http.Request) {
+	ip, ok := s.ipAddresses[req.PathValue("id")]
+	if !ok {
+		writeNotFound(rw, "ip address")
+		return
+	}
+	if ip.DeleteProtection {
+		writeError(rw, http.StatusUnprocessableEntity, "ip address is protected from deletion")
+		return
+	}
+	if len(ip.InstanceIDs) > 0 {
+		writeError(rw, http.StatusUnprocessableEntity, "ip address is assigned to an instance")
+		return
+	}
+	delete(s.ipAddresses, ip.ID)
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) listIPAddressAssignments(rw http.ResponseWriter, req *http.Request) {
+	assignments, meta := query(req, s.ipAssignments)
+	writeJSON(rw, http.StatusOK, map[string]interface{}{"instance_ip_addresses": assignments, "meta": meta})
+}
+
+func (s *Server) createIPAddressAssignment(rw http.ResponseWriter, req *http.Request) {
+	var request struct {
+		InstanceIPAddress *ah.IPAddressAssignmentCreateRequest `json:"instance_ip_address"`
+	}
+	if !readJSON(rw, req, &request) {
+		return
+	}
+	if request.InstanceIPAddress == nil {
+		writeValidationError(rw, "instance_ip_address", "can't be blank")
+		return
+	}
+	ip, ok := s.ipAddresses[request.InstanceIPAddress.IPAddressID]
+	if !ok {
+		writeValidationError(rw, "ip_address_id", "ip address not found")
+		return
+	}
+	instance, ok := s.instances[request.InstanceIPAddress.InstanceID]
+	if !ok {
+		writeValidationError(rw, "instance_id", "instance not found")
+		return
+	}
+	if slices.Contains(ip.InstanceIDs, instance.ID) {
+		writeValidationError(rw, "ip_address_id", "ip address is already assigned to the instance")
+		return
+	}
+
+	assignment := s.assignIPAddress(ip, instance)
+	writeJSON(rw, http.StatusCreated, map[string]interface{}{"instance_ip_address": assignment})
+}
+
+func (s *Server) getIPAddressAssignment(rw http.ResponseWriter, req *http.Request) {
+	assignment, ok := s.ipAssignments[req.PathValue("id")]
+	if !ok {
+		writeNotFound(rw, "instance ip address")
+		return
+	}
+	writeJSON(rw, http.StatusOK, map[string]interface{}{"instance_ip_address": assignment})
+}
+
+func (s *Server) deleteIPAddressAssignment(rw http.ResponseWriter, req *http.Request) {
+	assignment, ok := s.ipAssignments[req.PathValue("id")]
+	if !ok {
+		writeNotFound(rw, "instance ip address")
+		return
+	}
+	s.unassignIPAddress(assignment)
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+// privateNetwork returns the private network with connected instances
+func (s *Server) privateNetwork(pn *ah.PrivateNetwork) *ah.PrivateNetworkInfo {
+	info := &ah.PrivateNetworkInfo{PrivateNetwork: *pn}
+	for _, ipn := range values(s.instancePrivateNetworks) {
+		if ipn.PrivateNetwork != nil && ipn.PrivateNetwork.ID == pn.ID {
+			info.InstancePrivateNetworks = append(info.InstancePrivateNetworks, ipn.InstancePrivateNetworkInfo)
+		}
+	}
+	info.InstancesCount = len(info.InstancePrivateNetworks)
+	return info
+}
+
+func (s *Server) findPrivateNetwork(rw http.ResponseWriter, req *http.Request) (*ah.PrivateNetwork, bool) {
+	pn, ok := s.privateNetworks[req.PathValue("id")]
+	if !ok {
+		writeNotFound(rw, "private network")
+	}
+	return pn, ok
+}
+
+func (s *Server) listPrivateNetworks(rw http.ResponseWriter, req *http.Request) {
+	privateNetworks, meta := query(req, s.privateNetworks)
+	for i := range privateNetworks {
+		privateNetworks[i] = s.privateNetwork(&privateNetworks[i]).PrivateNetwork
+	}
+	writeJSON(rw, http.StatusOK, map[string]interface{}{"private_networks": privateNetworks, "meta": meta})
+}
+
+func (s *Server) getPrivateNetwork(rw http.ResponseWriter, req *http.Request) {
+	if pn, ok := s.findPrivateNetwork(rw, req); ok {
+		writeJSON(rw, http.StatusOK, map[string]interface{}{"private_network": s.privateNetwork(pn)})
+	}
+}
+
+func (s *Server) createPrivateNetwork(rw http.ResponseWriter, req *http.Request) {
+	var request struct {
+		PrivateNetwork *ah.PrivateNetworkCreateRequest `json:"private_network"`
+	}
+	if !readJSON(rw, req, &request) {
+		return
+	}
+	createRequest := request.PrivateNetwork
+	if createRequest == nil || createRequest.Name == "" {
+		writeValidationError(rw, "name", "can't be blank")
+		return
+	}
+	for _, attributes := range createRequest.InstancePrivateNetworkAttributes {
+		if _, ok := s.instances[attributes.InstanceID]; !ok {
+			writeValidationError(rw, "instance_private_networks_attributes", fmt.Sprintf("instance %s not found", attributes.InstanceID))
+			return
+		}
+	}
+
+	pn := &ah.PrivateNetwork{
+		ID:        s.newID(),
+		Number:    fmt.Sprint(s.nextNumber()),
+		Name:      createRequest.Name,
+		CIDR:      createRequest.CIDR,
+		State:     privateNetworkStateActive,
+		CreatedAt: now(),
+	}
+	if pn.CIDR == "" {
+		pn.CIDR = defaultPrivateNetworkCIDR
+	}
+	s.privateNetworks[pn.ID] = pn
+	for _, attributes := range createRequest.InstancePrivateNetworkAttributes {
+		s.connectInstance(pn, s.instances[attributes.InstanceID], attributes.IP)
+	}
+	writeJSON(rw, http.StatusCreated, map[string]interface{}{"private_network": s.privateNetwork(pn)})
+}
+
+func (s *Server) updatePrivateNetwork(rw http.ResponseWriter, req *http.Request) {
+	pn, ok := s.findPrivateNetwork(rw, req)
+	if !ok {
+		return
+	}
+	var request ah.PrivateNetworkUpdateRequest
+	if !readJSON(rw, req, &request) {
+		return
+	}
+	if request.Name != "" {
+		pn.Name = request.Name
+	}
+	if request.CIDR != "" {
+		pn.CIDR = request.CIDR
+	}
+	writeJSON(rw, http.StatusOK, map[string]interface{}{"private_network": s.privateNetwork(pn)})
+}
+
+func (s *Server) deletePrivateNetwork(rw http.ResponseWriter, req *http.Request) {
+	pn, ok := s.findPrivateNetwork(rw, req)
+	if !ok {
+		return
+	}
+	if s.privateNetwork(pn).InstancesCount > 0 {
+		writeError(rw, http.StatusUnprocessableEntity, "private network has connected instances")
+		return
+	}
+	delete(s.privateNetworks, pn.ID)
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+// connectInstance connects the instance to the private network
+func (s *Server) connectInstance(pn *ah.PrivateNetwork, instance *ah.Instance, ip string) *ah.InstancePrivateNetwork {
+	ipn := &ah.InstancePrivateNetwork{PrivateNetwork: pn}
+	ipn.ID = s.newID()
+	ipn.IP = ip
+	ipn.MACAddress = fmt.Sprintf("02:00:00:00:%02x:%02x", s.seq/256%256, s.seq%256)
+	ipn.State = instancePrivateNetworkStateConnected
+	ipn.ConnectedAt = now()
+	ipn.Instance = &struct {
+		ID      string `json:"id,omitempty"`
+		ImageID string `json:"image_id"`
+		Name    string `json:"name"`
+		Number  string `json:"number,omitempty"`
+	}{ID: instance.ID, Name: instance.Name, Number: instance.Number}
+	s.instancePrivateNetworks[ipn.ID] = ipn
+	return ipn
+}
+
+func (s *Server) findInstancePrivateNetwork(rw http.ResponseWriter, req *http.Request) (*ah.InstancePrivateNetwork, bool) {
+	ipn, ok := s.instancePrivateNetworks[req.PathValue("id")]
+	if !ok {
+		writeNotFound(rw, "instance private network")
+	}
+	return ipn, ok
+}
+
+func (s *Server) createInstancePrivateNetwork(rw http.ResponseWriter, req *http.Request) {
+	var request struct {
+		InstancePrivateNetwork *ah.InstancePrivateNetworkCreateRequest `json:"instance_private_network"`
+	}
+	if !readJSON(rw, req, &request) {
+		return
+	}
+	if request.InstancePrivateNetwork == nil {
+		writeValidationError(rw, "instance_private_network", "can't be blank")
+		return
+	}
+	pn, ok := s.privateNetworks[request.InstancePrivateNetwork.PrivateNetworkID]
+	if !ok {
+		writeValidationError(rw, "private_network_id", "private network not found")
+		return
+	}
+	instance, ok := s.instances[request.InstancePrivateNetwork.InstanceID]
+	if !ok {
+		writeValidationError(rw, "instance_id", "instance not found")
+		return
+	}
+
+	ipn := s.connectInstance(pn, instance, request.InstancePrivateNetwork.IP)
+	writeJSON(rw, http.StatusCreated, map[string]interface{}{"instance_private_network": ipn})
+}
+
+func (s *Server) getInstancePrivateNetwork(rw http.ResponseWriter, req *http.Request) {
+	if ipn, ok := s.findInstancePrivateNetwork(rw, req); ok {
+		writeJSON(rw, http.StatusOK, map[string]interface{}{"instance_private_network": ipn})
+	}
+}
+
+func (s *Server) updateInstancePrivateNetwork(rw http.ResponseWriter, req *http.Request) {
+	ipn, ok := s.findInstancePrivateNetwork(rw, req)
+	if !ok {
+		return
+	}
+	var request struct {
+		InstancePrivateNetwork *ah.InstancePrivateNetworkUpdateRequest `json:"instance_private_network"`
+	}
+	if !readJSON(rw, req, &request) {
+		return
+	}
+	if request.InstancePrivateNetwork != nil {
+		ipn.IP = request.InstancePrivateNetwork.IP
+	}
+	writeJSON(rw, http.StatusOK, map[string]interface{}{"instance_private_network": ipn})
+}
+
+func (s *Server) deleteInstancePrivateNetwork(rw http.ResponseWriter, req *http.Request) {
+	ipn, ok := s.findInstancePrivateNetwork(rw, req)
+	if !ok {
+		return
+	}
+	delete(s.instancePrivateNetworks, ipn.ID)
+	writeJSON(rw, http.StatusOK, map[string]interface{}{"instance_private_network": ipn})
+}
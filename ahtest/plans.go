@@ -0,0 +1,60 @@
+/*
+Copyright 2023 Advanced Hosting
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ahtest
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/advancedhosting/advancedhosting-api-go/ah"
+)
+
+// bytesPerGB converts disk sizes of instances in GB to sizes of backups in bytes
+const bytesPerGB = 1000 * 1000 * 1000
+
+// defaultPlans are instance plans available on every server
+var defaultPlans = []ah.InstancePlanAttributes{
+	{Slug: "start-xs", Vcpu: 1, RAM: 1024, Disk: 20},
+	{Slug: "start-s", Vcpu: 1, RAM: 2048, Disk: 40},
+	{Slug: "start-m", Vcpu: 2, RAM: 4096, Disk: 80},
+}
+
+func (s *Server) registerPlans() {
+	for i, attributes := range defaultPlans {
+		plan := &ah.InstancePlan{
+			CustomAttributes: &attributes,
+			Plan:             ah.Plan{ID: i + 1, Type: "vps", Currency: "usd", Name: attributes.Slug},
+		}
+		s.plans[strconv.Itoa(plan.ID)] = plan
+	}
+	s.handle("GET /api/v1/plans/public", s.listPlans)
+}
+
+// findPlan returns the plan by ID or slug
+func (s *Server) findPlan(id int, slug string) (*ah.InstancePlan, bool) {
+	for _, plan := range s.plans {
+		if (id != 0 && plan.ID == id) || (id == 0 && slug != "" && plan.CustomAttributes.Slug == slug) {
+			return plan, true
+		}
+	}
+	return nil, false
+}
+
+func (s *Server) listPlans(rw http.ResponseWriter, req *http.Request) {
+	plans, meta := query(req, s.plans)
+	writeJSON(rw, http.StatusOK, map[string]interface{}{"data": plans, "meta": meta})
+}
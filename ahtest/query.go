@@ -0,0 +1,325 @@
+/*
+Copyright 2023 Advanced Hosting
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ahtest
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/advancedhosting/advancedhosting-api-go/ah"
+)
+
+const defaultPerPage = 25
+
+// predicates are supported Ransack predicates, longer suffixes go first
+var predicates = []string{
+	"not_cont", "not_null", "matches", "present", "not_eq", "not_in",
+	"blank", "start", "gteq", "lteq", "cont", "null", "end", "eq", "in", "lt", "gt",
+}
+
+type condition struct {
+	attributes []string
+	predicate  string
+	// quantifier is "any" or "all" for "*_any" and "*_all" predicates
+	quantifier string
+	values     []string
+}
+
+// group is a Ransack grouping of conditions and nested groups joined with the combinator
+type group struct {
+	combinator string
+	conditions []condition
+	groups     map[string]*group
+}
+
+type entry[T any] struct {
+	item   *T
+	fields map[string]interface{}
+}
+
+// query filters, sorts and paginates items according to Ransack parameters of the request
+func query[T any](req *http.Request, items map[string]*T) ([]T, *ah.Meta) {
+	params := req.URL.Query()
+
+	root := &group{}
+	for key, values := range params {
+		if segments, ok := parseKey(key); ok && segments[0] != "s" {
+			root.add(segments, values)
+		}
+	}
+	// Several sortings are sent as "q[s][]" array
+	sortings := append(params["q[s]"], params["q[s][]"]...)
+
+	var entries []entry[T]
+	for _, item := range items {
+		e := entry[T]{item: item, fields: fields(item)}
+		if root.match(e.fields) {
+			entries = append(entries, e)
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		for _, sorting := range sortings {
+			attribute, order, _ := strings.Cut(sorting, " ")
+			a, b := fmt.Sprint(entries[i].fields[attribute]), fmt.Sprint(entries[j].fields[attribute])
+			if a == b {
+				continue
+			}
+			if order == "desc" {
+				return a > b
+			}
+			return a < b
+		}
+		return fmt.Sprint(entries[i].fields["id"]) < fmt.Sprint(entries[j].fields["id"])
+	})
+
+	page, _ := strconv.Atoi(params.Get("page"))
+	if page < 1 {
+		page = 1
+	}
+	perPage, _ := strconv.Atoi(params.Get("per_page"))
+	if perPage < 1 {
+		perPage = defaultPerPage
+	}
+
+	result := []T{}
+	for i := (page - 1) * perPage; i < len(entries) && i < page*perPage; i++ {
+		result = append(result, *entries[i].item)
+	}
+	return result, &ah.Meta{Page: page, PerPage: perPage, Total: len(entries)}
+}
+
+// values returns all items in creation order, ids are sequential
+func values[T any](items map[string]*T) []T {
+	var entries []entry[T]
+	for _, item := range items {
+		entries = append(entries, entry[T]{item: item, fields: fields(item)})
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return fmt.Sprint(entries[i].fields["id"]) < fmt.Sprint(entries[j].fields["id"])
+	})
+
+	result := []T{}
+	for _, e := range entries {
+		result = append(result, *e.item)
+	}
+	return result
+}
+
+func fields(item interface{}) map[string]interface{} {
+	data, _ := json.Marshal(item)
+	var result map[string]interface{}
+	_ = json.Unmarshal(data, &result)
+	return result
+}
+
+// parseKey splits Ransack parameter name like "q[g][0][name_eq][]" into ["g", "0", "name_eq"]
+func parseKey(key string) ([]string, bool) {
+	rest, ok := strings.CutPrefix(key, "q[")
+	if !ok {
+		return nil, false
+	}
+	rest = strings.TrimSuffix(strings.TrimSuffix(rest, "[]"), "]")
+	return strings.Split(rest, "]["), true
+}
+
+func (g *group) add(segments []string, values []string) {
+	switch {
+	case len(segments) >= 3 && segments[0] == "g":
+		if g.groups == nil {
+			g.groups = map[string]*group{}
+		}
+		nested, ok := g.groups[segments[1]]
+		if !ok {
+			nested = &group{}
+			g.groups[segments[1]] = nested
+		}
+		nested.add(segments[2:], values)
+	case len(segments) == 1 && segments[0] == "m":
+		g.combinator = values[0]
+	case len(segments) == 1:
+		if c, ok := parseCondition(segments[0], values); ok {
+			g.conditions = append(g.conditions, c)
+		}
+	}
+}
+
+func parseCondition(name string, values []string) (condition, bool) {
+	quantifier := ""
+	for _, q := range []string{"any", "all"} {
+		if trimmed, ok := strings.CutSuffix(name, "_"+q); ok {
+			name, quantifier = trimmed, q
+			break
+		}
+	}
+	for _, predicate := range predicates {
+		if attributes, ok := strings.CutSuffix(name, "_"+predicate); ok {
+			return condition{
+				attributes: strings.Split(attributes, "_or_"),
+				predicate:  predicate,
+				quantifier: quantifier,
+				values:     values,
+			}, true
+		}
+	}
+	return condition{}, false
+}
+
+func (g *group) match(fields map[string]interface{}) bool {
+	or := g.combinator == "or"
+	results := make([]bool, 0, len(g.conditions)+len(g.groups))
+	for _, c := range g.conditions {
+		results = append(results, c.match(fields))
+	}
+	for _, nested := range g.groups {
+		results = append(results, nested.match(fields))
+	}
+	if len(results) == 0 {
+		return true
+	}
+	for _, result := range results {
+		if result == or {
+			return or
+		}
+	}
+	return !or
+}
+
+func (c condition) match(fields map[string]interface{}) bool {
+	for _, attribute := range c.attributes {
+		if c.matchValue(fields[attribute]) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c condition) matchValue(value interface{}) bool {
+	var actual []string
+	switch v := value.(type) {
+	case nil:
+		actual = nil
+	case []interface{}:
+		for _, item := range v {
+			actual = append(actual, fmt.Sprint(item))
+		}
+	default:
+		actual = []string{fmt.Sprint(v)}
+	}
+
+	switch c.predicate {
+	case "in":
+		return slices.ContainsFunc(c.values, func(expected string) bool { return slices.Contains(actual, expected) })
+	case "not_in":
+		return !slices.ContainsFunc(c.values, func(expected string) bool { return slices.Contains(actual, expected) })
+	case "null", "not_null", "present", "blank":
+		flag := len(c.values) == 0 || c.values[0] == "true" || c.values[0] == "1"
+		present := len(actual) > 0 && !(len(actual) == 1 && actual[0] == "")
+		switch c.predicate {
+		case "null":
+			return (value == nil) == flag
+		case "not_null":
+			return (value != nil) == flag
+		case "present":
+			return present == flag
+		}
+		return !present == flag
+	}
+
+	matchOne := func(expected string) bool {
+		return slices.ContainsFunc(actual, func(a string) bool { return compare(c.predicate, a, expected) })
+	}
+	if strings.HasPrefix(c.predicate, "not_") {
+		positive := strings.TrimPrefix(c.predicate, "not_")
+		matchOne = func(expected string) bool {
+			return !slices.ContainsFunc(actual, func(a string) bool { return compare(positive, a, expected) })
+		}
+	}
+
+	switch {
+	case len(c.values) == 0:
+		return false
+	case c.quantifier == "all":
+		for _, expected := range c.values {
+			if !matchOne(expected) {
+				return false
+			}
+		}
+		return true
+	case c.quantifier == "any":
+		return slices.ContainsFunc(c.values, matchOne)
+	}
+	return matchOne(c.values[0])
+}
+
+// compare applies the positive predicate to the actual and expected values
+func compare(predicate, actual, expected string) bool {
+	switch predicate {
+	case "eq":
+		return actual == expected
+	case "cont":
+		return strings.Contains(strings.ToLower(actual), strings.ToLower(expected))
+	case "start":
+		return strings.HasPrefix(actual, expected)
+	case "end":
+		return strings.HasSuffix(actual, expected)
+	case "matches":
+		return likePattern(expected).MatchString(actual)
+	case "lt", "lteq", "gt", "gteq":
+		order := strings.Compare(actual, expected)
+		a, errA := strconv.ParseFloat(actual, 64)
+		e, errE := strconv.ParseFloat(expected, 64)
+		if errA == nil && errE == nil {
+			order = cmp.Compare(a, e)
+		}
+		switch predicate {
+		case "lt":
+			return order < 0
+		case "lteq":
+			return order <= 0
+		case "gt":
+			return order > 0
+		}
+		return order >= 0
+	}
+	return false
+}
+
+// likePattern converts SQL LIKE pattern used by "matches" predicate to a regular expression
+func likePattern(pattern string) *regexp.Regexp {
+	var expr strings.Builder
+	expr.WriteString("(?is)^")
+	for _, r := range pattern {
+		switch r {
+		case '%':
+			expr.WriteString(".*")
+		case '_':
+			expr.WriteString(".")
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	expr.WriteString("$")
+	return regexp.MustCompile(expr.String())
+}
@@ -0,0 +1,214 @@
+/*
+Copyright 2023 Advanced Hosting
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ahtest provides a stateful in-memory fake of AH API for tests.
+//
+// The server keeps instances, volumes, ip addresses, private networks, load balancers,
+// backups, ssh keys and kubernetes clusters in memory and serves the same paths
+// the ah package calls. Asynchronous operations are simulated with actions which
+// complete after ActionSteps API requests:
+//
+//	server := ahtest.NewServer()
+//	defer server.Close()
+//
+//	client, _ := server.Client()
+//	instance, _ := client.Instances.Create(ctx, &ah.InstanceCreateRequest{Name: "test"})
+//	instance, _ = client.WaitForInstanceState(ctx, instance.ID, ah.InstanceStateRunning, nil)
+package ahtest
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	"github.com/advancedhosting/advancedhosting-api-go/ah"
+)
+
+// Token is the access token accepted by the server
+const Token = "ahtest-token"
+
+const defaultActionSteps = 2
+
+// Server is a stateful in-memory fake of AH API
+type Server struct {
+	srv  *httptest.Server
+	mux  *http.ServeMux
+	mu   sync.Mutex
+	seq  int
+	fail *string
+
+	instances               map[string]*ah.Instance
+	volumes                 map[string]*ah.Volume
+	ipAddresses             map[string]*ah.IPAddress
+	ipAssignments           map[string]*ah.IPAddressAssignment
+	privateNetworks         map[string]*ah.PrivateNetwork
+	instancePrivateNetworks map[string]*ah.InstancePrivateNetwork
+	loadBalancers           map[string]*ah.LoadBalancer
+	backups                 map[string]*ah.Backup
+	sshKeys                 map[string]*ah.SSHKey
+	clusters                map[string]*ah.KubernetesCluster
+	plans                   map[string]*ah.InstancePlan
+	actions                 []*action
+
+	// URL is the base URL of the server
+	URL string
+	// ActionSteps is the number of API requests after which an action completes.
+	// Zero completes actions on the next request.
+	ActionSteps int
+}
+
+// NewServer starts and returns a new Server. The caller should call Close when finished.
+func NewServer() *Server {
+	s := &Server{
+		mux:                     http.NewServeMux(),
+		instances:               map[string]*ah.Instance{},
+		volumes:                 map[string]*ah.Volume{},
+		ipAddresses:             map[string]*ah.IPAddress{},
+		ipAssignments:           map[string]*ah.IPAddressAssignment{},
+		privateNetworks:         map[string]*ah.PrivateNetwork{},
+		instancePrivateNetworks: map[string]*ah.InstancePrivateNetwork{},
+		loadBalancers:           map[string]*ah.LoadBalancer{},
+		backups:                 map[string]*ah.Backup{},
+		sshKeys:                 map[string]*ah.SSHKey{},
+		clusters:                map[string]*ah.KubernetesCluster{},
+		plans:                   map[string]*ah.InstancePlan{},
+		ActionSteps:             defaultActionSteps,
+	}
+	s.registerInstances()
+	s.registerVolumes()
+	s.registerIPAddresses()
+	s.registerPrivateNetworks()
+	s.registerLoadBalancers()
+	s.registerBackups()
+	s.registerSSHKeys()
+	s.registerKubernetesClusters()
+	s.registerPlans()
+
+	s.srv = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	s.URL = s.srv.URL
+	return s
+}
+
+// Close shuts down the server
+func (s *Server) Close() {
+	s.srv.Close()
+}
+
+// ClientOptions returns options of ah.APIClient connected to the server
+func (s *Server) ClientOptions() *ah.ClientOptions {
+	return &ah.ClientOptions{
+		Token:   Token,
+		BaseURL: s.URL,
+	}
+}
+
+// Client returns ah.APIClient connected to the server
+func (s *Server) Client() (*ah.APIClient, error) {
+	return ah.NewAPIClient(s.ClientOptions())
+}
+
+// Settle completes all pending actions
+func (s *Server) Settle() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, a := range s.actions {
+		for !a.finished() {
+			a.step(s)
+		}
+	}
+}
+
+// FailNextAction makes the next created action fail with the note
+func (s *Server) FailNextAction(note string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.fail = &note
+}
+
+func (s *Server) serveHTTP(rw http.ResponseWriter, req *http.Request) {
+	if req.Header.Get("Authorization") != "Bearer "+Token {
+		writeError(rw, http.StatusUnauthorized, "invalid access token")
+		return
+	}
+
+	s.mu.Lock()
+	s.tick()
+	rw.Header().Set("X-Request-Id", s.newID())
+	s.mu.Unlock()
+
+	s.mux.ServeHTTP(rw, req)
+}
+
+// handle registers the handler executed under the server lock
+func (s *Server) handle(pattern string, handler func(http.ResponseWriter, *http.Request)) {
+	s.mux.HandleFunc(pattern, func(rw http.ResponseWriter, req *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		handler(rw, req)
+	})
+}
+
+// newID returns a UUID-like ID. IDs start with a sequence number, so they are sorted in creation order.
+func (s *Server) newID() string {
+	var b [12]byte
+	_, _ = rand.Read(b[:])
+	b[2] = (b[2] & 0x0f) | 0x40
+	b[4] = (b[4] & 0x3f) | 0x80
+	return fmt.Sprintf("%08x-%x-%x-%x-%x", s.nextNumber(), b[0:2], b[2:4], b[4:6], b[6:])
+}
+
+// nextNumber returns a sequential number used for resource numbers and addresses
+func (s *Server) nextNumber() int {
+	s.seq++
+	return s.seq
+}
+
+func now() ah.Timestamp {
+	return ah.NewTimestamp(time.Now().UTC().Truncate(time.Millisecond))
+}
+
+func writeJSON(rw http.ResponseWriter, statusCode int, v interface{}) {
+	rw.Header().Set("Content-Type", "application/json")
+	rw.WriteHeader(statusCode)
+	_ = json.NewEncoder(rw).Encode(v)
+}
+
+func writeError(rw http.ResponseWriter, statusCode int, message string) {
+	writeJSON(rw, statusCode, map[string]string{"error": message})
+}
+
+func writeValidationError(rw http.ResponseWriter, field, message string) {
+	writeJSON(rw, http.StatusUnprocessableEntity, map[string]map[string][]string{
+		"errors": {field: {message}},
+	})
+}
+
+func writeNotFound(rw http.ResponseWriter, resource string) {
+	writeError(rw, http.StatusNotFound, fmt.Sprintf("%s not found", resource))
+}
+
+// readJSON decodes the request body and writes 400 response on failure
+func readJSON(rw http.ResponseWriter, req *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(req.Body).Decode(v); err != nil {
+		writeError(rw, http.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err))
+		return false
+	}
+	return true
+}
@@ -0,0 +1,375 @@
+/*
+Copyright 2023 Advanced Hosting
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ahtest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/advancedhosting/advancedhosting-api-go/ah"
+)
+
+var fastWait = &ah.WaitOptions{PollInterval: time.Millisecond}
+
+func newTestClient(t *testing.T) (*Server, *ah.APIClient) {
+	t.Helper()
+	server := NewServer()
+	t.Cleanup(server.Close)
+	client, err := server.Client()
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	return server, client
+}
+
+func TestServer_InstanceLifecycle(t *testing.T) {
+	_, client := newTestClient(t)
+	ctx := context.Background()
+
+	instance, err := client.Instances.Create(ctx, &ah.InstanceCreateRequest{Name: "web", CreatePublicIPAddress: true})
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if instance.State != ah.InstanceStateCreating {
+		t.Errorf("Unexpected state %s", instance.State)
+	}
+
+	instance, err = client.WaitForInstanceState(ctx, instance.ID, ah.InstanceStateRunning, fastWait)
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if _, err := instance.PrimaryIPAddr(); err != nil {
+		t.Errorf("Unexpected error %v", err)
+	}
+
+	if instance, err = client.Instances.Rename(ctx, instance.ID, "api"); err != nil || instance.Name != "api" {
+		t.Errorf("Unexpected rename result %v, %v", instance, err)
+	}
+
+	if err := client.Instances.Shutdown(ctx, instance.ID); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if _, err := client.WaitForInstanceState(ctx, instance.ID, ah.InstanceStateStopped, fastWait); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	action, err := client.Instances.PowerOn(ctx, instance.ID)
+	if err != nil || action.Type != ah.ActionTypePowerOn {
+		t.Fatalf("Unexpected power on result %v, %v", action, err)
+	}
+	if _, err := client.WaitForAction(ctx, action.Action, fastWait); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if instance, err = client.Instances.Get(ctx, instance.ID); err != nil || instance.State != ah.InstanceStateRunning {
+		t.Errorf("Unexpected instance %v, %v", instance, err)
+	}
+
+	if err := client.Instances.Destroy(ctx, instance.ID); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if _, err := client.Instances.Get(ctx, instance.ID); !errors.Is(err, ah.ErrResourceNotFound) {
+		t.Errorf("Unexpected error %v", err)
+	}
+}
+
+func TestServer_UpdateInstanceTags(t *testing.T) {
+	_, client := newTestClient(t)
+	ctx := context.Background()
+
+	instance, _ := client.Instances.Create(ctx, &ah.InstanceCreateRequest{Name: "web", Tags: []string{"prod"}, SnapshotBySchedule: true})
+	instance, err := client.Instances.AddTags(ctx, instance.ID, []string{"web", "prod"})
+	if err != nil || !reflect.DeepEqual(instance.Tags, []string{"prod", "web"}) {
+		t.Fatalf("Unexpected result %v, %v", instance, err)
+	}
+	instance, err = client.Instances.RemoveTags(ctx, instance.ID, []string{"prod"})
+	if err != nil || !reflect.DeepEqual(instance.Tags, []string{"web"}) {
+		t.Fatalf("Unexpected result %v, %v", instance, err)
+	}
+
+	instance, err = client.Instances.Update(ctx, instance.ID, &ah.InstanceUpdateRequest{SnapshotBySchedule: ah.Ptr(false)})
+	if err != nil || instance.SnapshotBySchedule || instance.Name != "web" {
+		t.Errorf("Unexpected result %v, %v", instance, err)
+	}
+}
+
+func TestServer_Teardown(t *testing.T) {
+	server, client := newTestClient(t)
+	ctx := context.Background()
+
+	instance, _ := client.Instances.Create(ctx, &ah.InstanceCreateRequest{Name: "db", CreatePublicIPAddress: true})
+	volume, _ := client.Volumes.Create(ctx, &ah.VolumeCreateRequest{Name: "data", Size: 10})
+	server.Settle()
+	_, _ = client.Instances.AttachVolume(ctx, instance.ID, volume.ID)
+	_, _ = client.Instances.CreateBackup(ctx, instance.ID, "")
+	server.Settle()
+	instance, _ = client.Instances.Get(ctx, instance.ID)
+
+	options := &ah.InstanceTeardownOptions{
+		WaitOptions:        fastWait,
+		BackupsStrategy:    ah.BackupsStrategyKeep,
+		DetachVolumes:      true,
+		ReleaseIPAddresses: true,
+	}
+	if err := client.Instances.Teardown(ctx, instance.ID, options); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	if volume, err := client.Volumes.Get(ctx, volume.ID); err != nil || volume.Instance != nil {
+		t.Errorf("Unexpected volume %v, %v", volume, err)
+	}
+	if _, err := client.IPAddresses.Get(ctx, instance.IPAddresses[0].IPAddressID); !errors.Is(err, ah.ErrResourceNotFound) {
+		t.Errorf("Unexpected error %v", err)
+	}
+	backups, _, err := client.Backups.ListPage(ctx, nil)
+	if err != nil || len(backups) != 1 || !backups[0].Instance.InstanceRemoved {
+		t.Errorf("Unexpected backups %v, %v", backups, err)
+	}
+}
+
+func TestServer_AttachVolume(t *testing.T) {
+	server, client := newTestClient(t)
+	ctx := context.Background()
+
+	instance, _ := client.Instances.Create(ctx, &ah.InstanceCreateRequest{Name: "db"})
+	volume, err := client.Volumes.Create(ctx, &ah.VolumeCreateRequest{Name: "data", Size: 10})
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	server.Settle()
+
+	action, err := client.Instances.AttachVolume(ctx, instance.ID, volume.ID)
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if action, err = client.WaitForAction(ctx, action, fastWait); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if action.State != ah.ActionStateSuccess {
+		t.Errorf("Unexpected action state %s", action.State)
+	}
+
+	instance, _ = client.Instances.Get(ctx, instance.ID)
+	if len(instance.Volumes) != 1 || instance.Volumes[0].ID != volume.ID {
+		t.Errorf("Unexpected volumes %v", instance.Volumes)
+	}
+	if volume, _ = client.Volumes.Get(ctx, volume.ID); volume.State != "attached" {
+		t.Errorf("Unexpected volume state %s", volume.State)
+	}
+}
+
+func TestServer_FailNextAction(t *testing.T) {
+	server, client := newTestClient(t)
+	ctx := context.Background()
+
+	volume, _ := client.Volumes.Create(ctx, &ah.VolumeCreateRequest{Name: "data", Size: 10})
+	server.Settle()
+
+	server.FailNextAction("no space left")
+	action, err := client.Volumes.Resize(ctx, volume.ID, 20)
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	_, err = client.WaitForAction(ctx, action, fastWait)
+	var actionErr *ah.ActionError
+	if !errors.As(err, &actionErr) {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if actionErr.Action.Note != "no space left" {
+		t.Errorf("Unexpected note %s", actionErr.Action.Note)
+	}
+	if volume, _ = client.Volumes.Get(ctx, volume.ID); volume.Size != 10 {
+		t.Errorf("Unexpected size %d", volume.Size)
+	}
+}
+
+func TestServer_CreateBackup(t *testing.T) {
+	server, client := newTestClient(t)
+	ctx := context.Background()
+
+	instance, _ := client.Instances.Create(ctx, &ah.InstanceCreateRequest{Name: "web"})
+	server.Settle()
+
+	action, err := client.Instances.CreateBackup(ctx, instance.ID, "nightly")
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if _, err := client.WaitForAction(ctx, action.Action, fastWait); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	backup, err := client.Backups.Get(ctx, action.ResultParams.SnapshotID)
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if backup.Note != "nightly" || backup.InstanceID != instance.ID {
+		t.Errorf("Unexpected backup %v", backup)
+	}
+
+	backups, err := client.Backups.List(ctx, nil)
+	if err != nil || len(backups) != 1 || len(backups[0].Backups) != 1 {
+		t.Errorf("Unexpected backups %v, %v", backups, err)
+	}
+}
+
+func TestServer_RestoreFromBackup(t *testing.T) {
+	server, client := newTestClient(t)
+	ctx := context.Background()
+
+	instance, _ := client.Instances.Create(ctx, &ah.InstanceCreateRequest{Name: "web", PlanSlug: "start-s"})
+	server.Settle()
+	backupAction, _ := client.Instances.CreateBackup(ctx, instance.ID, "")
+	server.Settle()
+	backupID := backupAction.ResultParams.SnapshotID
+
+	action, err := client.Instances.RestoreFromBackup(ctx, instance.ID, backupID)
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if _, err := client.WaitForAction(ctx, action.Action, fastWait); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	_, err = client.Instances.CreateFromBackup(ctx, backupID, &ah.InstanceCreateRequest{Name: "small", PlanSlug: "start-xs"})
+	if !errors.Is(err, ah.ErrDiskTooSmall) {
+		t.Errorf("Unexpected error %v", err)
+	}
+	copied, err := client.Instances.CreateFromBackup(ctx, backupID, &ah.InstanceCreateRequest{Name: "copy", PlanSlug: "start-m"})
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if copied.Disk != 80 {
+		t.Errorf("Unexpected instance %v", copied)
+	}
+}
+
+func TestServer_ListFilterAndPaginate(t *testing.T) {
+	_, client := newTestClient(t)
+	ctx := context.Background()
+
+	for i := 0; i < 30; i++ {
+		name := fmt.Sprintf("web-%02d", i)
+		if i%3 == 0 {
+			name = fmt.Sprintf("db-%02d", i)
+		}
+		if _, err := client.SSHKeys.Create(ctx, &ah.SSHKeyCreateRequest{Name: name, PublicKey: "ssh-ed25519 " + name}); err != nil {
+			t.Fatalf("Unexpected error %v", err)
+		}
+	}
+
+	options := &ah.ListOptions{
+		Filters:  []ah.FilterInterface{&ah.ContFilter{Keys: []string{"name"}, Value: "web"}},
+		Sortings: []*ah.Sorting{{Key: "name", Order: "desc"}},
+	}
+	keys, meta, err := client.SSHKeys.List(ctx, options)
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if len(keys) != 20 || keys[0].Name != "web-29" || meta.Total != 20 {
+		t.Errorf("Unexpected keys %v, meta %v", keys, meta)
+	}
+
+	all, err := ah.ListAll(client.SSHKeys.All(ctx, nil))
+	if err != nil || len(all) != 30 || all[29].Name != "web-29" {
+		t.Errorf("Unexpected keys %v, %v", all, err)
+	}
+
+	options = &ah.ListOptions{
+		Filters: []ah.FilterInterface{
+			&ah.FilterGroup{
+				Combinator: ah.CombinatorOr,
+				Filters: []ah.FilterInterface{
+					&ah.Filter{Keys: []string{"name"}, Predicate: ah.PredicateLt, Values: []string{"db-06"}},
+					&ah.Filter{Keys: []string{"name"}, Predicate: ah.PredicateEnd.Any(), Values: []string{"-28", "-29"}},
+				},
+			},
+			&ah.Filter{Keys: []string{"name"}, Predicate: ah.PredicateNotEq, Values: []string{"db-00"}},
+		},
+		Sortings: []*ah.Sorting{{Key: "public_key", Order: "desc"}, {Key: "name", Order: "asc"}},
+	}
+	keys, _, err = client.SSHKeys.List(ctx, options)
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if len(keys) != 3 || keys[0].Name != "web-29" || keys[2].Name != "db-03" {
+		t.Errorf("Unexpected keys %v", keys)
+	}
+}
+
+func TestServer_PaginateIPAddresses(t *testing.T) {
+	_, client := newTestClient(t)
+	ctx := context.Background()
+
+	for i := 0; i < 12; i++ {
+		if _, err := client.IPAddresses.Create(ctx, &ah.IPAddressCreateRequest{Type: "public"}); err != nil {
+			t.Fatalf("Unexpected error %v", err)
+		}
+	}
+
+	options, _ := ah.IPAddressQuery().PerPage(5).Page(3).Build()
+	_, meta, err := client.IPAddresses.ListPage(ctx, options)
+	if err != nil || meta.Total != 12 || !meta.IsLastPage() {
+		t.Errorf("Unexpected meta %v, %v", meta, err)
+	}
+
+	options, _ = ah.IPAddressQuery().PerPage(5).Build()
+	all, err := ah.ListAll(client.IPAddresses.All(ctx, options))
+	if err != nil || len(all) != 12 {
+		t.Errorf("Unexpected ip addresses %v, %v", all, err)
+	}
+}
+
+func TestServer_KubernetesCluster(t *testing.T) {
+	_, client := newTestClient(t)
+	ctx := context.Background()
+
+	cluster, err := client.KubernetesClusters.Create(ctx, &ah.KubernetesClusterCreateRequest{
+		Name:        "k8s",
+		K8sVersion:  KubernetesVersions[0],
+		WorkerPools: []ah.CreateKubernetesWorkerPoolRequest{{Type: "public", Count: 2}},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if cluster, err = client.WaitForKubernetesClusterReady(ctx, cluster.ID, fastWait); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if len(cluster.WorkerPools) != 1 || len(cluster.WorkerPools[0].Workers) != 2 {
+		t.Errorf("Unexpected worker pools %v", cluster.WorkerPools)
+	}
+
+	if _, err := client.KubernetesClusters.GetConfig(ctx, cluster.ID); err != nil {
+		t.Errorf("Unexpected error %v", err)
+	}
+}
+
+func TestServer_ValidationError(t *testing.T) {
+	_, client := newTestClient(t)
+
+	_, err := client.Instances.Create(context.Background(), &ah.InstanceCreateRequest{})
+	var apiErr *ah.APIError
+	if !errors.As(err, &apiErr) || !apiErr.IsValidationError() {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if len(apiErr.Errors["name"]) != 1 {
+		t.Errorf("Unexpected errors %v", apiErr.Errors)
+	}
+}
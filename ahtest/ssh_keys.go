@@ -0,0 +1,119 @@
+/*
+Copyright 2023 Advanced Hosting
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ahtest
+
+import (
+	"crypto/md5"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/advancedhosting/advancedhosting-api-go/ah"
+)
+
+func (s *Server) registerSSHKeys() {
+	s.handle("GET /api/v1/ssh_keys", s.listSSHKeys)
+	s.handle("POST /api/v1/ssh_keys", s.createSSHKey)
+	s.handle("GET /api/v1/ssh_keys/{id}", s.getSSHKey)
+	s.handle("PUT /api/v1/ssh_keys/{id}", s.updateSSHKey)
+	s.handle("DELETE /api/v1/ssh_keys/{id}", s.deleteSSHKey)
+}
+
+// fingerprint returns MD5 fingerprint of the public key data
+func fingerprint(publicKey string) string {
+	sum := md5.Sum([]byte(publicKey))
+	parts := make([]string, len(sum))
+	for i, b := range sum {
+		parts[i] = fmt.Sprintf("%02x", b)
+	}
+	return strings.Join(parts, ":")
+}
+
+func (s *Server) findSSHKey(rw http.ResponseWriter, req *http.Request) (*ah.SSHKey, bool) {
+	sshKey, ok := s.sshKeys[req.PathValue("id")]
+	if !ok {
+		writeNotFound(rw, "ssh key")
+	}
+	return sshKey, ok
+}
+
+func (s *Server) listSSHKeys(rw http.ResponseWriter, req *http.Request) {
+	sshKeys, meta := query(req, s.sshKeys)
+	writeJSON(rw, http.StatusOK, map[string]interface{}{"ssh_keys": sshKeys, "meta": meta})
+}
+
+func (s *Server) getSSHKey(rw http.ResponseWriter, req *http.Request) {
+	if sshKey, ok := s.findSSHKey(rw, req); ok {
+		writeJSON(rw, http.StatusOK, map[string]interface{}{"ssh_key": sshKey})
+	}
+}
+
+func (s *Server) createSSHKey(rw http.ResponseWriter, req *http.Request) {
+	var request struct {
+		SSHKey *ah.SSHKeyCreateRequest `json:"ssh_key"`
+	}
+	if !readJSON(rw, req, &request) {
+		return
+	}
+	createRequest := request.SSHKey
+	if createRequest == nil || createRequest.Name == "" {
+		writeValidationError(rw, "name", "can't be blank")
+		return
+	}
+	if createRequest.PublicKey == "" {
+		writeValidationError(rw, "public_key", "can't be blank")
+		return
+	}
+
+	sshKey := &ah.SSHKey{
+		ID:          s.newID(),
+		Name:        createRequest.Name,
+		PublicKey:   createRequest.PublicKey,
+		Fingerprint: fingerprint(createRequest.PublicKey),
+		CreatedAt:   now(),
+	}
+	s.sshKeys[sshKey.ID] = sshKey
+	writeJSON(rw, http.StatusCreated, map[string]interface{}{"ssh_key": sshKey})
+}
+
+func (s *Server) updateSSHKey(rw http.ResponseWriter, req *http.Request) {
+	sshKey, ok := s.findSSHKey(rw, req)
+	if !ok {
+		return
+	}
+	var request ah.SSHKeyUpdateRequest
+	if !readJSON(rw, req, &request) {
+		return
+	}
+	if request.Name != "" {
+		sshKey.Name = request.Name
+	}
+	if request.PublicKey != "" {
+		sshKey.PublicKey = request.PublicKey
+		sshKey.Fingerprint = fingerprint(request.PublicKey)
+	}
+	writeJSON(rw, http.StatusOK, map[string]interface{}{"ssh_key": sshKey})
+}
+
+func (s *Server) deleteSSHKey(rw http.ResponseWriter, req *http.Request) {
+	sshKey, ok := s.findSSHKey(rw, req)
+	if !ok {
+		return
+	}
+	delete(s.sshKeys, sshKey.ID)
+	rw.WriteHeader(http.StatusNoContent)
+}
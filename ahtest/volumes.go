@@ -0,0 +1,233 @@
+/*
+Copyright 2023 Advanced Hosting
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ahtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/advancedhosting/advancedhosting-api-go/ah"
+)
+
+const (
+	volumeStateCreating  = "creating"
+	volumeStateReady     = "ready"
+	volumeStateAttaching = "attaching"
+	volumeStateAttached  = "attached"
+	volumeStateDetaching = "detaching"
+	volumeStateResizing  = "resizing"
+)
+
+type volumeActionRequest struct {
+	Type   ah.ActionType `json:"type"`
+	Name   string        `json:"name"`
+	Size   int           `json:"size"`
+	PlanID int           `json:"plan_id"`
+}
+
+func (s *Server) registerVolumes() {
+	s.handle("GET /api/v1/volumes", s.listVolumes)
+	s.handle("POST /api/v1/volumes", s.createVolume)
+	s.handle("GET /api/v1/volumes/{id}", s.getVolume)
+	s.handle("PUT /api/v1/volumes/{id}", s.updateVolume)
+	s.handle("DELETE /api/v1/volumes/{id}", s.deleteVolume)
+	s.handle("GET /api/v1/volumes/{id}/actions", s.listVolumeActions)
+	s.handle("POST /api/v1/volumes/{id}/actions", s.createVolumeAction)
+	s.handle("GET /api/v1/volumes/{id}/actions/{actionID}", s.getVolumeAction)
+}
+
+func (s *Server) findVolume(rw http.ResponseWriter, req *http.Request) (*ah.Volume, bool) {
+	volume, ok := s.volumes[req.PathValue("id")]
+	if !ok {
+		writeNotFound(rw, "volume")
+	}
+	return volume, ok
+}
+
+func (s *Server) listVolumes(rw http.ResponseWriter, req *http.Request) {
+	volumes, meta := query(req, s.volumes)
+	writeJSON(rw, http.StatusOK, map[string]interface{}{"volumes": volumes, "meta": meta})
+}
+
+func (s *Server) getVolume(rw http.ResponseWriter, req *http.Request) {
+	if volume, ok := s.findVolume(rw, req); ok {
+		writeJSON(rw, http.StatusOK, map[string]interface{}{"volume": volume})
+	}
+}
+
+// newVolume creates a volume which becomes ready after ActionSteps requests
+func (s *Server) newVolume(name string, size, planID int, fileSystem string) *ah.Volume {
+	volume := &ah.Volume{
+		ID:         s.newID(),
+		Number:     strconv.Itoa(s.nextNumber()),
+		Name:       name,
+		Size:       size,
+		PlanID:     planID,
+		FileSystem: fileSystem,
+		State:      volumeStateCreating,
+		CreatedAt:  now(),
+	}
+	s.volumes[volume.ID] = volume
+	return volume
+}
+
+func (s *Server) createVolume(rw http.ResponseWriter, req *http.Request) {
+	var request struct {
+		Volume *ah.VolumeCreateRequest `json:"volume"`
+	}
+	if !readJSON(rw, req, &request) {
+		return
+	}
+	createRequest := request.Volume
+	if createRequest == nil || createRequest.Name == "" {
+		writeValidationError(rw, "name", "can't be blank")
+		return
+	}
+	if createRequest.Size <= 0 {
+		writeValidationError(rw, "size", "must be greater than 0")
+		return
+	}
+	var instance *ah.Instance
+	if createRequest.InstanceID != "" {
+		var ok bool
+		if instance, ok = s.instances[createRequest.InstanceID]; !ok {
+			writeValidationError(rw, "instance_id", "instance not found")
+			return
+		}
+	}
+
+	volume := s.newVolume(createRequest.Name, createRequest.Size, createRequest.PlanID, createRequest.FileSystem)
+	if createRequest.Meta != "" {
+		volume.Meta = json.RawMessage(createRequest.Meta)
+	}
+	s.newAction("volume", volume.ID, "create", func() {
+		volume.State = volumeStateReady
+		if instance != nil {
+			volume.Instance = &struct {
+				ID   string `json:"id,omitempty"`
+				Name string `json:"name,omitempty"`
+			}{ID: instance.ID, Name: instance.Name}
+			volume.State = volumeStateAttached
+			volume.AttachedAt = now()
+		}
+	})
+
+	writeJSON(rw, http.StatusCreated, map[string]interface{}{"volume": volume})
+}
+
+func (s *Server) updateVolume(rw http.ResponseWriter, req *http.Request) {
+	volume, ok := s.findVolume(rw, req)
+	if !ok {
+		return
+	}
+	var request ah.VolumeUpdateRequest
+	if !readJSON(rw, req, &request) {
+		return
+	}
+	if request.Name != "" {
+		volume.Name = request.Name
+	}
+	if request.Meta != nil {
+		volume.Meta, _ = json.Marshal(request.Meta)
+	}
+	writeJSON(rw, http.StatusOK, map[string]interface{}{"volume": volume})
+}
+
+func (s *Server) deleteVolume(rw http.ResponseWriter, req *http.Request) {
+	volume, ok := s.findVolume(rw, req)
+	if !ok {
+		return
+	}
+	if volume.Instance != nil {
+		writeError(rw, http.StatusUnprocessableEntity, "volume is attached to an instance")
+		return
+	}
+	delete(s.volumes, volume.ID)
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) listVolumeActions(rw http.ResponseWriter, req *http.Request) {
+	if _, ok := s.findVolume(rw, req); !ok {
+		return
+	}
+	actions := []*action{}
+	actions = append(actions, s.resourceActions("volume", req.PathValue("id"))...)
+	writeJSON(rw, http.StatusOK, map[string]interface{}{"actions": actions})
+}
+
+func (s *Server) getVolumeAction(rw http.ResponseWriter, req *http.Request) {
+	a := s.findAction("volume", req.PathValue("id"), req.PathValue("actionID"))
+	if a == nil {
+		writeNotFound(rw, "action")
+		return
+	}
+	writeJSON(rw, http.StatusOK, map[string]interface{}{"action": a})
+}
+
+func (s *Server) createVolumeAction(rw http.ResponseWriter, req *http.Request) {
+	volume, ok := s.findVolume(rw, req)
+	if !ok {
+		return
+	}
+	var request volumeActionRequest
+	if !readJSON(rw, req, &request) {
+		return
+	}
+
+	var a *action
+	switch request.Type {
+	case ah.ActionTypeCopy:
+		if request.Name == "" {
+			writeValidationError(rw, "name", "can't be blank")
+			return
+		}
+		planID := request.PlanID
+		if planID == 0 {
+			planID = volume.PlanID
+		}
+		copied := s.newVolume(request.Name, volume.Size, planID, volume.FileSystem)
+		copied.OriginalID = volume.ID
+		a = s.newAction("volume", volume.ID, request.Type, func() {
+			copied.State = volumeStateReady
+		})
+		a.onFailure = func() {
+			delete(s.volumes, copied.ID)
+		}
+		a.ResultParams = map[string]string{"copied_volume_id": copied.ID}
+	case ah.ActionTypeResize:
+		if request.Size <= volume.Size {
+			writeValidationError(rw, "size", fmt.Sprintf("must be greater than %d", volume.Size))
+			return
+		}
+		state := volume.State
+		volume.State = volumeStateResizing
+		a = s.newAction("volume", volume.ID, request.Type, func() {
+			volume.Size = request.Size
+			volume.State = state
+		})
+		a.onFailure = func() {
+			volume.State = state
+		}
+	default:
+		writeValidationError(rw, "type", fmt.Sprintf("unsupported action type %q", request.Type))
+		return
+	}
+
+	writeJSON(rw, http.StatusAccepted, map[string]interface{}{"action": a})
+}
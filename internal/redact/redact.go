@@ -0,0 +1,41 @@
+/*
+Copyright 2023 Advanced Hosting
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package redact lists values which are never logged or recorded
+package redact
+
+import "strings"
+
+// Value replaces the values of sensitive keys
+const Value = "[REDACTED]"
+
+// sensitiveKeys are JSON keys and query parameters which values are secret,
+// e.g. Token.Token of created access tokens and kubeconfig contents
+var sensitiveKeys = map[string]bool{
+	"token":         true,
+	"access_token":  true,
+	"refresh_token": true,
+	"config":        true,
+	"kubeconfig":    true,
+	"password":      true,
+	"private_key":   true,
+	"secret":        true,
+}
+
+// IsSensitiveKey reports whether the value of the key must be redacted. Keys are case insensitive.
+func IsSensitiveKey(key string) bool {
+	return sensitiveKeys[strings.ToLower(key)]
+}